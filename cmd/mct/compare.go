@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"middleware-chaos-testing/internal/compare"
+	"middleware-chaos-testing/internal/config"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <baseline.json> <report.json>...",
+	Short: "Compare JSON reports against a baseline",
+	Long: `Compare one or more JSON reports (mct test --output json) against the first one as the baseline.
+Reports per-metric deltas and flags statistically significant regressions in availability,
+error rate and latency percentiles. Exits with code 1 if any regression is found.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runCompare,
+}
+
+var (
+	compareOutput         string
+	latencyTolerance      config.Percent
+	minLatencyChange      time.Duration
+	availabilityTolerance config.Percent
+	errorRateTolerance    config.Percent
+	significance          float64
+)
+
+func init() {
+	defaults := compare.DefaultOptions()
+	latencyTolerance = config.Percent(defaults.LatencyTolerance)
+	availabilityTolerance = config.Percent(defaults.AvailabilityTolerance)
+	errorRateTolerance = config.Percent(defaults.ErrorRateTolerance)
+
+	compareCmd.Flags().StringVar(&compareOutput, "output", "console", "Output format (console|markdown)")
+	compareCmd.Flags().Var(&latencyTolerance, "latency-tolerance", "Allowed relative latency increase (e.g. 10%)")
+	compareCmd.Flags().DurationVar(&minLatencyChange, "min-latency-change", defaults.MinLatencyChange,
+		"Latency increases smaller than this are never regressions")
+	compareCmd.Flags().Var(&availabilityTolerance, "availability-tolerance",
+		"Allowed absolute availability drop (e.g. 0.1%)")
+	compareCmd.Flags().Var(&errorRateTolerance, "error-rate-tolerance", "Allowed absolute error rate increase (e.g. 0.1%)")
+	compareCmd.Flags().Float64Var(&significance, "significance", defaults.Significance,
+		"Significance level of the one-sided tests; changes beyond tolerance must also be significant")
+
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	if significance <= 0 || significance >= 1 {
+		return fmt.Errorf("--significance must be between 0 and 1")
+	}
+	opts := compare.Options{
+		LatencyTolerance:      float64(latencyTolerance),
+		MinLatencyChange:      minLatencyChange,
+		AvailabilityTolerance: float64(availabilityTolerance),
+		ErrorRateTolerance:    float64(errorRateTolerance),
+		Significance:          significance,
+	}
+
+	reports := make([]*compare.Report, 0, len(args))
+	for _, path := range args {
+		report, err := compare.Load(path)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	comparisons := make([]*compare.Comparison, 0, len(reports)-1)
+	for _, candidate := range reports[1:] {
+		comparisons = append(comparisons, compare.Compare(reports[0], candidate, opts))
+	}
+
+	var err error
+	switch compareOutput {
+	case "console", "":
+		err = compare.WriteConsole(os.Stdout, comparisons)
+	case "markdown", "md":
+		err = compare.WriteMarkdown(os.Stdout, comparisons)
+	default:
+		return fmt.Errorf("unsupported output format: %s", compareOutput)
+	}
+	if err != nil {
+		return err
+	}
+
+	if compare.HasRegression(comparisons) {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/history"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recorded runs and show score and latency trends",
+	Long: `List runs recorded by mct test (--history-dir, $MCT_HISTORY_DIR or history.dir in the config file)
+and print score, availability, throughput and latency percentile trends over time.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var (
+	historyDirFlag    string
+	historyConfig     string
+	historyMiddleware string
+	historyName       string
+	historyLabels     []string
+	historySince      string
+	historyLimit      int
+)
+
+func init() {
+	historyCmd.Flags().StringVar(&historyDirFlag, "dir", "", "History directory (default: $MCT_HISTORY_DIR or history.dir in --config)")
+	historyCmd.Flags().StringVar(&historyConfig, "config", "", "Config file whose history.dir is used")
+	historyCmd.Flags().StringVar(&historyMiddleware, "middleware", "", "Only runs against this middleware (redis|kafka)")
+	historyCmd.Flags().StringVar(&historyName, "name", "", "Only runs with this test name")
+	historyCmd.Flags().StringArrayVar(&historyLabels, "label", nil,
+		"Only runs with this label, key=value or key to require the label, repeatable")
+	historyCmd.Flags().StringVar(&historySince, "since", "",
+		"Only runs started after this date (2006-01-02) or within this duration (e.g. 168h)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 30, "Show at most this many recent runs (0: all)")
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+// resolveHistoryDir 历史目录：配置文件 < 环境变量 < --dir
+func resolveHistoryDir(cmd *cobra.Command) (string, error) {
+	cfg := config.New()
+	if historyConfig != "" {
+		var err error
+		if cfg, err = config.Load(historyConfig); err != nil {
+			return "", err
+		}
+	}
+	cfg.ApplyEnv()
+	if cmd.Flags().Changed("dir") {
+		cfg.History.Dir = historyDirFlag
+	}
+	if cfg.History.Dir == "" {
+		return "", fmt.Errorf("%w: history directory not set (use --dir, %s or history.dir in --config)",
+			core.ErrInvalidConfig, config.EnvHistoryDir)
+	}
+	return cfg.History.Dir, nil
+}
+
+// parseSince 解析 --since：日期或距今的时长
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid --since %q (want a date like 2006-01-02 or a duration like 168h)",
+			core.ErrInvalidConfig, value)
+	}
+	return t, nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	dir, err := resolveHistoryDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	filter := history.Filter{
+		Middleware: historyMiddleware,
+		Name:       historyName,
+		Limit:      historyLimit,
+	}
+	for _, spec := range historyLabels {
+		key, value, _ := strings.Cut(spec, "=")
+		if filter.Labels == nil {
+			filter.Labels = make(map[string]string, len(historyLabels))
+		}
+		filter.Labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if historySince != "" {
+		if filter.Since, err = parseSince(historySince, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	records, err := history.NewStore(dir).List(filter)
+	if err != nil {
+		return err
+	}
+	return history.WriteConsole(os.Stdout, records)
+}
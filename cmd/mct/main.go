@@ -3,15 +3,28 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"middleware-chaos-testing/internal/chaos"
 	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/config"
 	"middleware-chaos-testing/internal/core"
 	"middleware-chaos-testing/internal/evaluator"
+	"middleware-chaos-testing/internal/exporter"
+	"middleware-chaos-testing/internal/history"
+	"middleware-chaos-testing/internal/i18n"
 	"middleware-chaos-testing/internal/middleware"
+	"middleware-chaos-testing/internal/orchestrator"
 	"middleware-chaos-testing/internal/reporter"
+	"middleware-chaos-testing/internal/scenario"
+	"middleware-chaos-testing/internal/workload"
 )
 
 var (
@@ -44,80 +57,292 @@ var (
 	middlewareType string
 	host           string
 	port           int
+	brokers        []string
 	duration       time.Duration
 	operations     int
+	concurrency    int
+	rate           float64
+	profileSpec    string
+	workloadSpecs  []string
 	outputFormat   string
 	reportPath     string
+	templateFile   string
+	reportLang     string
 	configFile     string
+	proxyListen    string
+	metricsAddr    string
+	progressMode   string
+	faultSpec      string
+	scenarioFile   string
+	verify         bool
+	historyDir     string
+	labelSpecs     []string
 )
 
 func init() {
-	testCmd.Flags().StringVar(&middlewareType, "middleware", "", "Middleware type (redis|kafka) [required]")
+	testCmd.Flags().StringVar(&middlewareType, "middleware", "", "Middleware type (redis|kafka) [required unless set in --config]")
 	testCmd.Flags().StringVar(&host, "host", "localhost", "Middleware host")
 	testCmd.Flags().IntVar(&port, "port", 0, "Middleware port (default: 6379 for redis, 9092 for kafka)")
+	testCmd.Flags().StringSliceVar(&brokers, "brokers", nil, "Kafka broker list (default: host:port)")
 	testCmd.Flags().DurationVar(&duration, "duration", 60*time.Second, "Test duration")
 	testCmd.Flags().IntVar(&operations, "operations", 10000, "Number of operations to perform")
-	testCmd.Flags().StringVar(&outputFormat, "output", "console", "Output format (console|json|markdown)")
+	testCmd.Flags().IntVar(&concurrency, "concurrency", 1, "Number of concurrent workers")
+	testCmd.Flags().Float64Var(&rate, "rate", 0,
+		"Open-loop mode: fixed arrival rate in ops/s, latency measured from the intended send time (default: closed loop)")
+	testCmd.Flags().StringVar(&profileSpec, "profile", "",
+		"Open-loop rate profile based on --rate (e.g. shape=ramp,start_rate=100,ramp_time=2m; shapes: ramp|step|spike|sine)")
+	testCmd.Flags().StringArrayVar(&workloadSpecs, "workload", nil,
+		"Workload entry, repeatable (e.g. operation=get,weight=80,key_pattern=user:{zipf:10000})")
+	testCmd.Flags().StringVar(&outputFormat, "output", "console", "Output format (console|json|markdown|html|junit)")
 	testCmd.Flags().StringVar(&reportPath, "report-path", "", "Report output path (default: stdout)")
-	testCmd.Flags().StringVar(&configFile, "config", "", "Config file path")
-
-	testCmd.MarkFlagRequired("middleware")
+	testCmd.Flags().StringVar(&templateFile, "template", "",
+		"Render the console or markdown report with this text/template file instead of the built-in layout")
+	testCmd.Flags().StringVar(&reportLang, "lang", "",
+		"Language of the report and live progress (zh-CN|en) (default: $MCT_LANG or output.lang, zh-CN)")
+	testCmd.Flags().StringVar(&configFile, "config", "", "Config file path (YAML or JSON); flags override file values")
+	testCmd.Flags().StringVar(&proxyListen, "proxy", "",
+		"Route traffic through the built-in chaos proxy listening on this address (e.g. 127.0.0.1:0); redis only, kafka always proxies via the dialer")
+	testCmd.Flags().StringVar(&faultSpec, "fault", "",
+		"Network faults injected by the chaos proxy for the whole test (e.g. latency=50ms,jitter=10ms,bandwidth=1MB)")
+	testCmd.Flags().BoolVar(&verify, "verify", false,
+		"Verify data: read-after-write consistency for redis, end-to-end delivery (loss/duplicates/order) for kafka")
+	testCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "",
+		"Serve live Prometheus metrics at http://<addr>/metrics during the test (e.g. :9464)")
+	testCmd.Flags().StringVar(&progressMode, "progress", "auto",
+		"Live progress during the test: auto (dashboard on a terminal, log lines otherwise)|tui|plain|none")
+	testCmd.Flags().StringVar(&scenarioFile, "scenario", "",
+		"Chaos scenario file describing a timeline of phases and faults (default test duration: scenario length)")
+	testCmd.Flags().StringVar(&historyDir, "history-dir", "",
+		"Append this run to the history in this directory (default: $MCT_HISTORY_DIR or history.dir)")
+	testCmd.Flags().StringArrayVar(&labelSpecs, "label", nil, "Run label key=value recorded in the history, repeatable")
 
 	rootCmd.AddCommand(testCmd)
 }
 
+// loadConfig 加载配置：配置文件 < 环境变量 < 命令行参数
+// 命令行参数在显式指定或配置文件未设置该值时生效
+// 使用场景且未指定测试时长时，测试时长取场景总时长
+func loadConfig(cmd *cobra.Command, sc *scenario.Scenario) (*config.Config, error) {
+	cfg := config.New()
+	if configFile != "" {
+		var err error
+		if cfg, err = config.Load(configFile); err != nil {
+			return nil, err
+		}
+	}
+	cfg.ApplyEnv()
+
+	flags := cmd.Flags()
+	use := func(name string, unset bool) bool {
+		return flags.Changed(name) || unset
+	}
+
+	if use("middleware", cfg.Middleware == "") {
+		cfg.Middleware = middlewareType
+	}
+	if use("host", cfg.Connection.Host == "") {
+		cfg.Connection.Host = host
+	}
+	if use("port", cfg.Connection.Port == 0) {
+		cfg.Connection.Port = port
+	}
+	if use("brokers", len(cfg.Connection.Brokers) == 0) {
+		cfg.Connection.Brokers = brokers
+	}
+	// 配置文件已限定测试长度时，不再使用另一项的默认值
+	lengthUnset := cfg.Test.Duration == 0 && cfg.Test.Operations == 0
+	if use("duration", lengthUnset) {
+		cfg.Test.Duration = duration
+		if sc != nil && !flags.Changed("duration") {
+			cfg.Test.Duration = sc.Duration()
+		}
+	}
+	if use("operations", lengthUnset) {
+		cfg.Test.Operations = operations
+	}
+	if use("concurrency", cfg.Test.Concurrency == 0) {
+		cfg.Test.Concurrency = concurrency
+	}
+	if flags.Changed("rate") {
+		cfg.Test.Rate = rate
+	}
+	if flags.Changed("profile") {
+		p, err := workload.ParseProfileSpec(profileSpec)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Test.Profile = &config.ProfileSection{
+			Shape:     p.Shape,
+			StartRate: p.StartRate,
+			RampTime:  p.RampTime,
+			StepRate:  p.StepRate,
+			StepTime:  p.StepTime,
+			SpikeRate: p.SpikeRate,
+			SpikeAt:   p.SpikeAt,
+			SpikeTime: p.SpikeTime,
+			Amplitude: p.Amplitude,
+			Period:    p.Period,
+		}
+	}
+	// 开环模式由速率和时长决定操作数，不使用默认的操作数
+	if cfg.Test.Rate > 0 && lengthUnset && !flags.Changed("operations") {
+		cfg.Test.Operations = 0
+	}
+	if flags.Changed("verify") {
+		cfg.Test.Verify = verify
+	}
+	if flags.Changed("workload") {
+		cfg.Test.Workload = nil
+		for _, spec := range workloadSpecs {
+			wl, err := workload.ParseSpec(spec)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Test.Workload = append(cfg.Test.Workload, config.WorkloadSection{
+				Operation:  wl.Operation,
+				Weight:     wl.Weight,
+				KeyPattern: wl.KeyPattern,
+				ValueSize:  wl.ValueSize,
+			})
+		}
+	}
+	if use("output", cfg.Output.Format == "") {
+		cfg.Output.Format = outputFormat
+	}
+	if use("report-path", cfg.Output.Path == "") {
+		cfg.Output.Path = reportPath
+	}
+	if use("template", cfg.Output.Template == "") {
+		cfg.Output.Template = templateFile
+	}
+	if use("lang", cfg.Output.Lang == "") {
+		cfg.Output.Lang = reportLang
+	}
+	if flags.Changed("history-dir") {
+		cfg.History.Dir = historyDir
+	}
+	if len(labelSpecs) > 0 {
+		if cfg.Labels == nil {
+			cfg.Labels = make(map[string]string, len(labelSpecs))
+		}
+		for _, spec := range labelSpecs {
+			key, value, ok := strings.Cut(spec, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: label %q must be key=value", core.ErrInvalidConfig, spec)
+			}
+			cfg.Labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func runTest(cmd *cobra.Command, args []string) error {
-	// 设置默认端口
-	if port == 0 {
-		switch middlewareType {
-		case "redis":
-			port = 6379
-		case "kafka":
-			port = 9092
-		default:
-			return fmt.Errorf("unsupported middleware type: %s", middlewareType)
+	var sc *scenario.Scenario
+	if scenarioFile != "" {
+		var err error
+		if sc, err = scenario.Load(scenarioFile); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfig(cmd, sc)
+	if err != nil {
+		return err
+	}
+
+	conn := cfg.GetConnectionConfig()
+	testCfg := cfg.GetTestConfig()
+	outputCfg := cfg.GetOutputConfig()
+	middlewareType := cfg.GetMiddlewareType()
+
+	// 测试开始前加载报告模板，避免测试结束后才发现模板错误
+	var reportTemplate string
+	if outputCfg.Template != "" {
+		if reportTemplate, err = reporter.LoadTemplate(outputCfg.Template); err != nil {
+			return err
 		}
 	}
 
+	if cfg.Name != "" {
+		fmt.Printf("Test: %s\n", cfg.Name)
+	}
 	fmt.Printf("Starting %s stability test...\n", middlewareType)
-	fmt.Printf("Target: %s:%d\n", host, port)
-	fmt.Printf("Duration: %v\n", duration)
-	fmt.Printf("Operations: %d\n\n", operations)
+	if middlewareType == "kafka" {
+		fmt.Printf("Target: %s (topic %s)\n", strings.Join(conn.Brokers, ","), conn.Topic)
+	} else {
+		fmt.Printf("Target: %s:%d\n", conn.Host, conn.Port)
+	}
+	fmt.Printf("Duration: %v\n", testCfg.Duration)
+	fmt.Printf("Operations: %d\n", testCfg.Operations)
+	fmt.Printf("Concurrency: %d\n", testCfg.Concurrency)
+	if testCfg.Rate > 0 {
+		fmt.Printf("Rate: %g ops/s (open loop)\n", testCfg.Rate)
+		if testCfg.Profile != nil {
+			fmt.Printf("Profile: %s\n", testCfg.Profile.Shape)
+		}
+	}
+
+	if sc != nil {
+		fmt.Printf("Scenario: %s (%d phases, %v)\n", sc.Name, len(sc.Phases), sc.Duration())
+	}
+
+	proxy, err := startChaosProxy(cfg, sc != nil)
+	if err != nil {
+		return err
+	}
+	if proxy != nil {
+		defer proxy.Close()
+		target := proxy.Addr()
+		if target == "" {
+			target = "all broker connections"
+		}
+		fmt.Printf("Chaos proxy: %s (faults: %s)\n", target, proxy.Faults())
+	}
+	fmt.Println()
 
 	// 执行测试
-	ctx, cancel := context.WithTimeout(context.Background(), duration+30*time.Second)
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if testCfg.Duration > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), testCfg.Duration+30*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
 	defer cancel()
 
-	metrics, err := executeTest(ctx, middlewareType, host, port, duration, operations)
+	metrics, err := executeTest(ctx, cfg, proxy, sc)
 	if err != nil {
 		return fmt.Errorf("test execution failed: %w", err)
 	}
 
-	// 评分 - 根据中间件类型使用不同的阈值
-	var eval *evaluator.StabilityEvaluator
-	if middlewareType == "kafka" {
-		// Kafka使用专用阈值（符合业界最佳实践）
-		eval = evaluator.NewStabilityEvaluator(evaluator.KafkaThresholds())
-	} else {
-		// 其他中间件使用默认阈值
-		eval = evaluator.NewStabilityEvaluator(nil)
+	// 评分 - 根据中间件类型使用不同的阈值（Kafka使用专用阈值），配置文件中的阈值覆盖默认值
+	// JUnit报告固定为英文，问题描述也按英文生成
+	evalLang := cfg.ReportLang()
+	if outputCfg.Format == "junit" {
+		evalLang = i18n.En
 	}
+	result := evaluator.EvaluateMiddleware(middlewareType, cfg.GetThresholds(), evalLang, metrics)
 
-	var result *core.EvaluationResult
+	if !outputCfg.IncludeRecommendations {
+		result.Recommendations = nil
+	}
 
-	switch middlewareType {
-	case "redis":
-		result = eval.EvaluateRedis(metrics)
-	case "kafka":
-		result = eval.EvaluateKafka(metrics)
-	default:
-		result = eval.Evaluate(metrics)
+	// 记录运行历史，失败不影响测试结果
+	if cfg.History.Dir != "" {
+		if err := history.NewStore(cfg.History.Dir).Append(history.NewRecord(cfg, metrics, result)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record run history: %v\n", err)
+		}
 	}
 
 	// 生成报告
 	output := os.Stdout
-	if reportPath != "" {
-		f, err := os.Create(reportPath)
+	if outputCfg.Path != "" {
+		f, err := os.Create(outputCfg.Path)
 		if err != nil {
 			return fmt.Errorf("failed to create report file: %w", err)
 		}
@@ -125,7 +350,7 @@ func runTest(cmd *cobra.Command, args []string) error {
 		output = f
 	}
 
-	if err := generateReport(metrics, result, outputFormat, output); err != nil {
+	if err := generateReport(metrics, result, cfg, reportTemplate, output); err != nil {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
@@ -142,176 +367,216 @@ func runTest(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func executeTest(ctx context.Context, middlewareType, host string, port int, duration time.Duration, operations int) (*core.StabilityMetrics, error) {
-	coll := collector.NewMetricsCollector()
+// startChaosProxy 根据 --proxy/--fault/--scenario 启动故障注入代理，均未指定时返回nil
+// Redis客户端连接代理监听地址；Kafka客户端通过拨号函数接入，
+// 因为broker元数据返回的advertised listeners地址会绕过监听模式的代理
+func startChaosProxy(cfg core.Config, withScenario bool) (*chaos.Proxy, error) {
+	if proxyListen == "" && faultSpec == "" && !withScenario {
+		return nil, nil
+	}
 
-	switch middlewareType {
-	case "redis":
-		return executeRedisTest(ctx, host, port, duration, operations, coll)
-	case "kafka":
-		return executeKafkaTest(ctx, host, port, duration, operations, coll)
-	default:
-		return nil, fmt.Errorf("unsupported middleware type: %s", middlewareType)
+	faults, err := chaos.ParseFaults(faultSpec)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func executeRedisTest(ctx context.Context, host string, port int, duration time.Duration, operations int, coll *collector.MetricsCollector) (*core.StabilityMetrics, error) {
-	// 创建Redis客户端
-	cfg := &middleware.RedisConfig{
-		Host:     host,
-		Port:     port,
-		Password: "",
-		DB:       0,
-		Timeout:  5 * time.Second,
+	conn := cfg.GetConnectionConfig()
+	if cfg.GetMiddlewareType() == "kafka" {
+		if proxyListen != "" {
+			return nil, fmt.Errorf("%w: --proxy is not supported for kafka, use --fault or --scenario "+
+				"(broker connections are proxied through the client dialer)", core.ErrInvalidConfig)
+		}
+		proxy := chaos.NewProxy("")
+		proxy.SetFaults(faults)
+		return proxy, nil
 	}
 
-	client := middleware.NewRedisClient(cfg)
+	proxy := chaos.NewProxy(net.JoinHostPort(conn.Host, strconv.Itoa(conn.Port)))
+	proxy.SetFaults(faults)
 
-	// 连接
-	startConnect := time.Now()
-	if err := client.Connect(ctx); err != nil {
-		coll.RecordConnectionAttempt(false, time.Since(startConnect))
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	listen := proxyListen
+	if listen == "" {
+		listen = "127.0.0.1:0"
 	}
-	coll.RecordConnectionAttempt(true, time.Since(startConnect))
-	defer client.Disconnect(ctx)
-
-	// 运行测试
-	testCtx, cancel := context.WithTimeout(ctx, duration)
-	defer cancel()
+	if err := proxy.Start(listen); err != nil {
+		return nil, fmt.Errorf("failed to start chaos proxy: %w", err)
+	}
+	return proxy, nil
+}
 
-	opsPerformed := 0
-	ticker := time.NewTicker(duration / time.Duration(operations))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-testCtx.Done():
-			goto DONE
-		case <-ticker.C:
-			if opsPerformed >= operations {
-				goto DONE
-			}
+// startProgress 按 --progress 在测试期间显示实时进度，返回的函数停止显示并输出最终状态
+func startProgress(orch *orchestrator.Orchestrator, coll *collector.MetricsCollector, lang i18n.Lang) (func(), error) {
+	var interactive bool
+	switch progressMode {
+	case "auto":
+		interactive = reporter.IsTerminal(os.Stdout)
+	case "tui":
+		interactive = true
+	case "plain":
+	case "none":
+		return func() {}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown progress mode %q (want auto, tui, plain or none)",
+			core.ErrInvalidConfig, progressMode)
+	}
 
-			// 执行操作（SET + GET）
-			key := fmt.Sprintf("test:key:%d", opsPerformed)
-			value := fmt.Sprintf("value-%d", opsPerformed)
+	dashboard := reporter.NewDashboard(os.Stdout, orch, coll, interactive)
+	dashboard.SetLang(lang)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dashboard.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}, nil
+}
 
-			// SET操作
-			setResult, _ := client.Execute(testCtx, &middleware.RedisSetOperation{
-				OpKey:   key,
-				OpValue: []byte(value),
-			})
-			if setResult != nil {
-				coll.RecordOperation(setResult)
-			}
+func executeTest(
+	ctx context.Context,
+	cfg *config.Config,
+	proxy *chaos.Proxy,
+	sc *scenario.Scenario,
+) (*core.StabilityMetrics, error) {
+	coll := collector.NewMetricsCollector()
+	coll.SetErrorClassifier(middleware.ClassifyError)
+	coll.SetOutageDetection(cfg.GetOutageDetection())
+	if cfg.Test.LatencyPrecision > 0 {
+		coll.SetLatencyPrecision(cfg.Test.LatencyPrecision)
+	}
+	if cfg.Test.SeriesInterval > 0 {
+		coll.SetSeriesInterval(cfg.Test.SeriesInterval)
+	}
+	client, err := newClient(cfg, proxy)
+	if err != nil {
+		return nil, err
+	}
 
-			// GET操作
-			getResult, _ := client.Execute(testCtx, &middleware.RedisGetOperation{
-				OpKey: key,
-			})
-			if getResult != nil {
-				coll.RecordOperation(getResult)
-			}
+	generator, err := workload.NewGenerator(cfg.GetMiddlewareType(), cfg.GetTestConfig().Workload, time.Now().UnixNano())
+	if err != nil {
+		return nil, err
+	}
 
-			opsPerformed += 2
+	orch := orchestrator.NewOrchestrator(client, coll, generator)
+	if testCfg := cfg.GetTestConfig(); testCfg.Rate > 0 && testCfg.Profile != nil {
+		profile, err := workload.NewRateProfile(testCfg.Rate, testCfg.Profile, testCfg.Duration)
+		if err != nil {
+			return nil, err
 		}
+		orch.SetRateSchedule(profile)
+	}
+	if sc != nil {
+		runner := scenario.NewRunner(sc, proxy)
+		runner.SetClock(orch.Elapsed)
+		orch.SetScenario(runner)
 	}
 
-DONE:
-	return coll.GetMetrics(), nil
-}
-
-func executeKafkaTest(ctx context.Context, host string, port int, duration time.Duration, operations int, coll *collector.MetricsCollector) (*core.StabilityMetrics, error) {
-	// 创建Kafka客户端
-	brokers := []string{fmt.Sprintf("%s:%d", host, port)}
-	cfg := &middleware.KafkaConfig{
-		Brokers: brokers,
-		Topic:   "chaos-test-topic",
-		GroupID: "chaos-test-group",
-		Timeout: 5 * time.Second,
+	if metricsAddr != "" {
+		exp := exporter.NewExporter(cfg.GetMiddlewareType())
+		exp.SetPhaseSource(orch.Phase)
+		if err := exp.Start(metricsAddr); err != nil {
+			return nil, fmt.Errorf("failed to start metrics endpoint: %w", err)
+		}
+		defer exp.Close()
+		coll.SetObserver(exp)
+		fmt.Printf("Metrics: http://%s%s\n", exp.Addr(), exporter.MetricsPath)
 	}
 
-	client := middleware.NewKafkaClient(cfg)
+	// 收到中断信号时停止测试，仍然输出已收集的指标
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		if _, ok := <-sigCh; ok {
+			fmt.Println("\nInterrupted, stopping test...")
+			_ = orch.Stop()
+		}
+	}()
 
-	// 连接
-	startConnect := time.Now()
-	if err := client.Connect(ctx); err != nil {
-		coll.RecordConnectionAttempt(false, time.Since(startConnect))
-		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
+	stopProgress, err := startProgress(orch, coll, cfg.ReportLang())
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := orch.Run(ctx, cfg)
+	stopProgress()
+	if err != nil {
+		return nil, err
 	}
-	coll.RecordConnectionAttempt(true, time.Since(startConnect))
-	defer client.Disconnect(ctx)
 
-	// 运行测试
-	testCtx, cancel := context.WithTimeout(ctx, duration)
-	defer cancel()
+	// 添加Kafka特定指标
+	if kafkaClient, ok := client.(*middleware.KafkaClient); ok {
+		stats := kafkaClient.GetStats()
+		if lag, ok := stats["reader_lag"].(int64); ok {
+			metrics.MessageLag = lag
+		}
+	}
 
-	opsPerformed := 0
-	ticker := time.NewTicker(duration / time.Duration(operations))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-testCtx.Done():
-			goto DONE
-		case <-ticker.C:
-			if opsPerformed >= operations {
-				goto DONE
-			}
+	return metrics, nil
+}
 
-			// 执行操作（Produce + Consume）
-			key := fmt.Sprintf("test-key-%d", opsPerformed)
-			value := fmt.Sprintf("test-value-%d-%d", opsPerformed, time.Now().Unix())
+// newClient 根据配置创建中间件客户端，proxy 不为nil时经由故障注入代理连接
+func newClient(cfg *config.Config, proxy *chaos.Proxy) (core.MiddlewareClient, error) {
+	conn := cfg.GetConnectionConfig()
+	reconnect := cfg.GetReconnectPolicy()
 
-			// Produce操作
-			produceOp := &middleware.KafkaProduceOperation{
-				OpKey:   key,
-				OpValue: []byte(value),
-			}
-			produceResult, _ := client.Execute(testCtx, produceOp)
-			if produceResult != nil {
-				coll.RecordOperation(produceResult)
-			}
+	var client core.MiddlewareClient
 
-			// Consume操作（尝试读取）
-			consumeOp := &middleware.KafkaConsumeOperation{
-				MaxWait: 100 * time.Millisecond,
-			}
-			consumeResult, _ := client.Execute(testCtx, consumeOp)
-			if consumeResult != nil {
-				coll.RecordOperation(consumeResult)
+	switch cfg.GetMiddlewareType() {
+	case "redis":
+		redisConfig := &middleware.RedisConfig{
+			Host:      conn.Host,
+			Port:      conn.Port,
+			Password:  conn.Password,
+			DB:        conn.Database,
+			Timeout:   conn.Timeout,
+			Reconnect: &reconnect,
+			Verify:    cfg.Test.Verify,
+		}
+		if proxy != nil {
+			// 经由代理连接
+			addr, err := net.ResolveTCPAddr("tcp", proxy.Addr())
+			if err != nil {
+				return nil, err
 			}
-
-			opsPerformed += 2
+			redisConfig.Host, redisConfig.Port = addr.IP.String(), addr.Port
 		}
+		client = middleware.NewRedisClient(redisConfig)
+	case "kafka":
+		kafkaConfig := &middleware.KafkaConfig{
+			Brokers:     conn.Brokers,
+			Topic:       conn.Topic,
+			GroupID:     conn.GroupID,
+			Timeout:     conn.Timeout,
+			Reconnect:   &reconnect,
+			Verify:      cfg.Test.Verify,
+			VerifyGrace: cfg.Test.VerifyGrace,
+		}
+		if proxy != nil {
+			kafkaConfig.Dial = proxy.DialContext
+		}
+		client = middleware.NewKafkaClient(kafkaConfig)
+	default:
+		return nil, fmt.Errorf("unsupported middleware type: %s", cfg.GetMiddlewareType())
 	}
-
-DONE:
-	// 获取Kafka统计信息
-	stats := client.GetStats()
-	metrics := coll.GetMetrics()
-
-	// 添加Kafka特定指标
-	if lag, ok := stats["reader_lag"].(int64); ok {
-		metrics.MessageLag = lag
-	}
-
-	return metrics, nil
+	return client, nil
 }
 
-func generateReport(metrics *core.StabilityMetrics, evaluation *core.EvaluationResult, format string, output *os.File) error {
-	switch format {
-	case "json":
-		rep := reporter.NewJSONReporter()
-		return rep.GenerateReport(metrics, evaluation, output)
-	case "markdown", "md":
-		rep := reporter.NewMarkdownReporter()
-		return rep.GenerateReport(metrics, evaluation, output)
-	case "console", "":
-		rep := reporter.NewConsoleReporter()
-		return rep.GenerateReport(metrics, evaluation, output)
-	default:
-		return fmt.Errorf("unsupported output format: %s", format)
+func generateReport(
+	metrics *core.StabilityMetrics,
+	evaluation *core.EvaluationResult,
+	cfg *config.Config,
+	template string,
+	output *os.File,
+) error {
+	rep, err := reporter.NewReporter(cfg.Output.Format, cfg.ReportLang())
+	if err != nil {
+		return err
+	}
+	if tr, ok := rep.(reporter.TemplateReporter); ok && template != "" {
+		tr.SetTemplate(template)
+		tr.SetConfig(cfg)
 	}
+	return rep.GenerateReport(metrics, evaluation, output)
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/history"
+	"middleware-chaos-testing/internal/server"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a REST API to submit and control test runs",
+	Long: `Serve a REST API under /api/v1 to submit test definitions (the same YAML/JSON as --config),
+watch live status, pause/resume/stop runs and fetch metrics, evaluation and reports in any output format.
+API runs only apply load: the chaos proxy, --fault and --scenario are not available, inject faults externally.
+The API has no authentication; bind it to a trusted interface.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var (
+	serveAddr       string
+	serveHistoryDir string
+	serveMaxActive  int
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Listen address")
+	serveCmd.Flags().StringVar(&serveHistoryDir, "history-dir", "",
+		"Append every run to the history in this directory (default: $MCT_HISTORY_DIR)")
+	serveCmd.Flags().IntVar(&serveMaxActive, "max-active", 1, "Maximum number of concurrent runs (0: unlimited)")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveMaxActive < 0 {
+		return fmt.Errorf("%w: --max-active must not be negative", core.ErrInvalidConfig)
+	}
+
+	srv := server.NewServer(func(cfg *config.Config) (core.MiddlewareClient, error) {
+		return newClient(cfg, nil)
+	})
+	srv.SetMaxActive(serveMaxActive)
+
+	dir := serveHistoryDir
+	if !cmd.Flags().Changed("history-dir") {
+		dir = os.Getenv(config.EnvHistoryDir)
+	}
+	if dir != "" {
+		srv.SetHistory(history.NewStore(dir))
+	}
+
+	ln, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: srv, ReadHeaderTimeout: 5 * time.Second}
+	fmt.Printf("Serving API at http://%s%s\n", ln.Addr(), server.APIPrefix)
+	if dir != "" {
+		fmt.Printf("History: %s\n", dir)
+	}
+
+	// 收到中断信号时停止接受请求，并停止进行中的运行
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		if _, ok := <-sigCh; ok {
+			fmt.Println("\nShutting down...")
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = httpServer.Shutdown(ctx)
+		}
+	}()
+
+	err = httpServer.Serve(ln)
+	srv.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
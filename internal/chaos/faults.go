@@ -0,0 +1,165 @@
+package chaos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// Faults 网络故障配置，零值表示无故障
+// 延迟、停顿和带宽作用于每个连接的每个方向
+type Faults struct {
+	Latency time.Duration // 附加延迟
+	Jitter  time.Duration // 延迟抖动，实际延迟在 [Latency-Jitter, Latency+Jitter] 内
+
+	StallProbability float64       // 每个数据块触发停顿的概率（0-1）
+	StallDuration    time.Duration // 停顿时长
+
+	Bandwidth int64 // 带宽上限（字节/秒），0表示不限制
+
+	Reset     bool // 重置所有现有连接，并立即重置新连接
+	HalfOpen  bool // 断开上游但保持客户端连接，客户端数据被丢弃且收不到响应
+	Blackhole bool // 停止转发所有数据，解除后继续转发（类似丢包后TCP重传）
+}
+
+// IsZero 是否无故障
+func (f Faults) IsZero() bool {
+	return f == Faults{}
+}
+
+// Overlay 在当前故障上叠加另一组故障：overlay 中设置的延迟（含抖动）、停顿和带宽覆盖当前值，
+// 重置、半开和黑洞任一方设置即生效
+func (f Faults) Overlay(overlay Faults) Faults {
+	if overlay.Latency > 0 || overlay.Jitter > 0 {
+		f.Latency, f.Jitter = overlay.Latency, overlay.Jitter
+	}
+	if overlay.StallProbability > 0 {
+		f.StallProbability, f.StallDuration = overlay.StallProbability, overlay.StallDuration
+	}
+	if overlay.Bandwidth > 0 {
+		f.Bandwidth = overlay.Bandwidth
+	}
+	f.Reset = f.Reset || overlay.Reset
+	f.HalfOpen = f.HalfOpen || overlay.HalfOpen
+	f.Blackhole = f.Blackhole || overlay.Blackhole
+	return f
+}
+
+// String 返回故障描述，与 ParseFaults 的格式一致
+func (f Faults) String() string {
+	if f.IsZero() {
+		return "none"
+	}
+
+	var parts []string
+	if f.Latency > 0 {
+		parts = append(parts, "latency="+f.Latency.String())
+	}
+	if f.Jitter > 0 {
+		parts = append(parts, "jitter="+f.Jitter.String())
+	}
+	if f.StallProbability > 0 {
+		parts = append(parts, fmt.Sprintf("stall=%g:%s", f.StallProbability, f.StallDuration))
+	}
+	if f.Bandwidth > 0 {
+		parts = append(parts, fmt.Sprintf("bandwidth=%d", f.Bandwidth))
+	}
+	if f.Reset {
+		parts = append(parts, "reset")
+	}
+	if f.HalfOpen {
+		parts = append(parts, "half_open")
+	}
+	if f.Blackhole {
+		parts = append(parts, "blackhole")
+	}
+	return strings.Join(parts, ",")
+}
+
+// Validate 验证故障配置
+func (f Faults) Validate() error {
+	if f.Latency < 0 || f.Jitter < 0 || f.StallDuration < 0 {
+		return fmt.Errorf("%w: fault durations must not be negative", core.ErrInvalidConfig)
+	}
+	if f.StallProbability < 0 || f.StallProbability > 1 {
+		return fmt.Errorf("%w: stall probability must be between 0 and 1, got %g",
+			core.ErrInvalidConfig, f.StallProbability)
+	}
+	if f.StallProbability > 0 && f.StallDuration == 0 {
+		return fmt.Errorf("%w: stall duration is required when stall probability is set", core.ErrInvalidConfig)
+	}
+	if f.Bandwidth < 0 {
+		return fmt.Errorf("%w: bandwidth must not be negative", core.ErrInvalidConfig)
+	}
+	return nil
+}
+
+// ParseFaults 解析故障描述
+// 格式: latency=100ms,jitter=20ms,stall=0.01:500ms,bandwidth=64KB,reset,half_open,blackhole
+// "none" 或空字符串表示无故障；带宽单位为每秒字节数，支持 B/KB/MB 后缀
+func ParseFaults(spec string) (Faults, error) {
+	var f Faults
+
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "none" {
+		return f, nil
+	}
+
+	for _, field := range strings.Split(spec, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+
+		var err error
+		switch name {
+		case "latency":
+			f.Latency, err = time.ParseDuration(value)
+		case "jitter":
+			f.Jitter, err = time.ParseDuration(value)
+		case "stall":
+			prob, dur, ok := strings.Cut(value, ":")
+			if !ok {
+				return f, fmt.Errorf("%w: expected stall=PROBABILITY:DURATION, got %q", core.ErrInvalidConfig, field)
+			}
+			if f.StallProbability, err = strconv.ParseFloat(prob, 64); err == nil {
+				f.StallDuration, err = time.ParseDuration(dur)
+			}
+		case "bandwidth":
+			f.Bandwidth, err = ParseBandwidth(value)
+		case "reset":
+			f.Reset = true
+		case "half_open", "half-open":
+			f.HalfOpen = true
+		case "blackhole":
+			f.Blackhole = true
+		default:
+			return f, fmt.Errorf("%w: unknown fault %q", core.ErrInvalidConfig, name)
+		}
+		if err != nil {
+			return f, fmt.Errorf("%w: fault %s: %v", core.ErrInvalidConfig, name, err)
+		}
+	}
+
+	return f, f.Validate()
+}
+
+// ParseBandwidth 解析带宽（字节/秒），如 "512", "64KB", "1MB"
+func ParseBandwidth(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	switch {
+	case strings.HasSuffix(upper, "MB"):
+		unit, upper = 1<<20, strings.TrimSuffix(upper, "MB")
+	case strings.HasSuffix(upper, "KB"):
+		unit, upper = 1<<10, strings.TrimSuffix(upper, "KB")
+	case strings.HasSuffix(upper, "B"):
+		upper = strings.TrimSuffix(upper, "B")
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(upper), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q", s)
+	}
+	return n * unit, nil
+}
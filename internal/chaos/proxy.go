@@ -0,0 +1,568 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultDialTimeout 连接上游的超时时间
+const DefaultDialTimeout = 5 * time.Second
+
+// chunkQueueSize 每个方向排队等待投递的数据块上限
+const chunkQueueSize = 64
+
+// ErrProxyClosed 代理已关闭
+var ErrProxyClosed = errors.New("chaos proxy closed")
+
+// Stats 代理统计
+type Stats struct {
+	ActiveConnections int   // 当前连接数
+	TotalConnections  int64 // 累计连接数
+	ResetConnections  int64 // 被重置的连接数
+	BytesUpstream     int64 // 客户端→服务端字节数
+	BytesDownstream   int64 // 服务端→客户端字节数
+}
+
+// Proxy TCP故障注入代理
+//
+// 代理位于客户端和中间件之间，故障可在运行时通过 SetFaults 切换，
+// 无需root权限或tc/netem。两种接入方式：
+//
+//   - Start 监听本地地址，客户端连接该地址（适用于Redis）
+//   - DialContext 作为客户端的拨号函数（适用于Kafka：broker在元数据中
+//     返回advertised listeners地址，监听模式无法拦截后续连接）
+type Proxy struct {
+	upstream    string
+	dialTimeout time.Duration
+
+	mu       sync.Mutex
+	listener net.Listener
+	faults   Faults
+	changed  chan struct{} // 故障变更时关闭并替换，用于唤醒等待者
+	conns    map[*proxyConn]struct{}
+	closed   bool
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	wg sync.WaitGroup
+
+	totalConns      atomic.Int64
+	resetConns      atomic.Int64
+	bytesUpstream   atomic.Int64
+	bytesDownstream atomic.Int64
+}
+
+// NewProxy 创建代理，upstream 为真实服务地址（host:port）
+func NewProxy(upstream string) *Proxy {
+	return &Proxy{
+		upstream:    upstream,
+		dialTimeout: DefaultDialTimeout,
+		changed:     make(chan struct{}),
+		conns:       make(map[*proxyConn]struct{}),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Start 在 listenAddr 上监听并开始转发，listenAddr 可使用 127.0.0.1:0 自动分配端口
+func (p *Proxy) Start(listenAddr string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrProxyClosed
+	}
+	if p.listener != nil {
+		return fmt.Errorf("chaos proxy already listening on %s", p.listener.Addr())
+	}
+
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+	p.listener = ln
+
+	p.wg.Add(1)
+	go p.acceptLoop(ln)
+	return nil
+}
+
+// Addr 返回监听地址，未监听时返回空字符串
+func (p *Proxy) Addr() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.listener == nil {
+		return ""
+	}
+	return p.listener.Addr().String()
+}
+
+// Upstream 返回上游地址
+func (p *Proxy) Upstream() string {
+	return p.upstream
+}
+
+// DialContext 建立经过代理的连接，address 为空时使用上游地址
+// 返回的连接是内存管道，上游连接失败表现为连接被关闭
+func (p *Proxy) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if address == "" {
+		address = p.upstream
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrProxyClosed
+	}
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	local, remote := net.Pipe()
+	go func() {
+		defer p.wg.Done()
+		p.handle(remote, address)
+	}()
+	return local, nil
+}
+
+// Close 停止监听并关闭所有连接
+func (p *Proxy) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	ln := p.listener
+	conns := p.snapshotLocked()
+	p.mu.Unlock()
+
+	var err error
+	if ln != nil {
+		err = ln.Close()
+	}
+	for _, c := range conns {
+		c.close()
+	}
+	p.wg.Wait()
+	return err
+}
+
+// Faults 返回当前故障配置
+func (p *Proxy) Faults() Faults {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.faults
+}
+
+// SetFaults 切换故障配置，立即作用于现有连接
+func (p *Proxy) SetFaults(f Faults) {
+	p.mu.Lock()
+	prev := p.faults
+	p.faults = f
+	close(p.changed)
+	p.changed = make(chan struct{})
+	conns := p.snapshotLocked()
+	p.mu.Unlock()
+
+	if f.Reset && !prev.Reset {
+		for _, c := range conns {
+			p.reset(c)
+		}
+	}
+	if f.HalfOpen && !prev.HalfOpen {
+		for _, c := range conns {
+			c.setHalfOpen()
+		}
+	}
+}
+
+// UpdateFaults 在当前故障配置上修改，用于叠加多个故障
+func (p *Proxy) UpdateFaults(fn func(f *Faults)) {
+	f := p.Faults()
+	fn(&f)
+	p.SetFaults(f)
+}
+
+// Heal 清除所有故障
+// 已处于半开状态的连接不会恢复，需由客户端重连
+func (p *Proxy) Heal() {
+	p.SetFaults(Faults{})
+}
+
+// ResetConnections 立即重置所有现有连接，返回重置的连接数
+func (p *Proxy) ResetConnections() int {
+	p.mu.Lock()
+	conns := p.snapshotLocked()
+	p.mu.Unlock()
+
+	for _, c := range conns {
+		p.reset(c)
+	}
+	return len(conns)
+}
+
+// Stats 返回代理统计
+func (p *Proxy) Stats() Stats {
+	p.mu.Lock()
+	active := len(p.conns)
+	p.mu.Unlock()
+
+	return Stats{
+		ActiveConnections: active,
+		TotalConnections:  p.totalConns.Load(),
+		ResetConnections:  p.resetConns.Load(),
+		BytesUpstream:     p.bytesUpstream.Load(),
+		BytesDownstream:   p.bytesDownstream.Load(),
+	}
+}
+
+func (p *Proxy) acceptLoop(ln net.Listener) {
+	defer p.wg.Done()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.handle(conn, p.upstream)
+		}()
+	}
+}
+
+// handle 处理单个客户端连接直到关闭
+func (p *Proxy) handle(client net.Conn, upstream string) {
+	c := newProxyConn(client)
+	if !p.track(c) {
+		client.Close()
+		return
+	}
+	defer p.untrack(c)
+	p.totalConns.Add(1)
+
+	if p.Faults().Reset {
+		p.reset(c)
+		return
+	}
+
+	// 黑洞期间不连接上游，客户端连接挂起
+	if !p.waitWhileBlackhole(c) {
+		return
+	}
+
+	if p.Faults().HalfOpen {
+		c.setHalfOpen()
+	} else {
+		server, err := net.DialTimeout("tcp", upstream, p.dialTimeout)
+		if err != nil {
+			c.close()
+			return
+		}
+		c.setServer(server)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.pipe(c, c.client, &p.bytesUpstream, true)
+	}()
+	if server := c.getServer(); server != nil {
+		p.pipe(c, server, &p.bytesDownstream, false)
+	}
+	<-done
+}
+
+// chunk 等待投递的数据块
+type chunk struct {
+	data []byte
+	due  time.Time
+}
+
+// pipe 从 src 读取并投递到对端
+// fromClient 为 true 时方向为客户端→服务端
+func (p *Proxy) pipe(c *proxyConn, src net.Conn, counter *atomic.Int64, fromClient bool) {
+	queue := make(chan chunk, chunkQueueSize)
+
+	go func() {
+		defer close(queue)
+
+		buf := make([]byte, 32*1024)
+		var lastDue time.Time
+		for {
+			if !p.waitWhileBlackhole(c) {
+				return
+			}
+			n, err := src.Read(buf)
+			if n > 0 && !c.isHalfOpen() {
+				// 保证同一方向的数据按序投递
+				due := time.Now().Add(p.delay(p.Faults()))
+				if due.Before(lastDue) {
+					due = lastDue
+				}
+				lastDue = due
+
+				select {
+				case queue <- chunk{data: append([]byte(nil), buf[:n]...), due: due}:
+				case <-c.done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	failed := false
+	for ch := range queue {
+		if failed || c.isHalfOpen() {
+			continue
+		}
+		if err := p.deliver(c, ch, counter, fromClient); err != nil {
+			failed = true
+			if !c.isHalfOpen() {
+				c.close()
+			}
+		}
+	}
+
+	// 半开状态下上游关闭不影响客户端连接
+	if !fromClient && c.isHalfOpen() {
+		return
+	}
+	c.close()
+}
+
+// deliver 按故障配置投递数据块
+func (p *Proxy) deliver(c *proxyConn, ch chunk, counter *atomic.Int64, fromClient bool) error {
+	if !c.sleep(time.Until(ch.due)) {
+		return ErrProxyClosed
+	}
+
+	f := p.Faults()
+	if f.StallProbability > 0 && p.chance(f.StallProbability) {
+		if !c.sleep(f.StallDuration) {
+			return ErrProxyClosed
+		}
+	}
+	if !p.waitWhileBlackhole(c) {
+		return ErrProxyClosed
+	}
+
+	dst := c.client
+	if fromClient {
+		dst = c.getServer()
+	}
+	if dst == nil || c.isHalfOpen() {
+		return nil
+	}
+
+	data := ch.data
+	for len(data) > 0 {
+		n := len(data)
+		bandwidth := p.Faults().Bandwidth
+		if bandwidth > 0 {
+			// 每次最多写入100ms的配额，使带宽切换及时生效
+			if limit := int(bandwidth / 10); n > limit {
+				n = max(limit, 1)
+			}
+
+			// 先等待再写入，保证任意时刻已投递字节数不超过配额
+			if !c.sleep(time.Duration(n) * time.Second / time.Duration(bandwidth)) {
+				return ErrProxyClosed
+			}
+		}
+
+		if _, err := dst.Write(data[:n]); err != nil {
+			return err
+		}
+		counter.Add(int64(n))
+		data = data[n:]
+	}
+	return nil
+}
+
+// delay 计算本次附加延迟
+func (p *Proxy) delay(f Faults) time.Duration {
+	d := f.Latency
+	if f.Jitter > 0 {
+		p.rngMu.Lock()
+		d += time.Duration(p.rng.Int63n(int64(2*f.Jitter)+1)) - f.Jitter
+		p.rngMu.Unlock()
+	}
+	return max(d, 0)
+}
+
+func (p *Proxy) chance(probability float64) bool {
+	p.rngMu.Lock()
+	defer p.rngMu.Unlock()
+	return p.rng.Float64() < probability
+}
+
+// waitWhileBlackhole 黑洞期间阻塞，连接关闭时返回false
+func (p *Proxy) waitWhileBlackhole(c *proxyConn) bool {
+	for {
+		p.mu.Lock()
+		blackhole, changed := p.faults.Blackhole, p.changed
+		p.mu.Unlock()
+
+		if !blackhole {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-c.done:
+			return false
+		}
+	}
+}
+
+func (p *Proxy) reset(c *proxyConn) {
+	if c.reset() {
+		p.resetConns.Add(1)
+	}
+}
+
+func (p *Proxy) track(c *proxyConn) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return false
+	}
+	p.conns[c] = struct{}{}
+	return true
+}
+
+func (p *Proxy) untrack(c *proxyConn) {
+	p.mu.Lock()
+	delete(p.conns, c)
+	p.mu.Unlock()
+}
+
+func (p *Proxy) snapshotLocked() []*proxyConn {
+	conns := make([]*proxyConn, 0, len(p.conns))
+	for c := range p.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+// proxyConn 一对客户端/上游连接
+type proxyConn struct {
+	client net.Conn
+
+	mu       sync.Mutex
+	server   net.Conn
+	halfOpen bool
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newProxyConn(client net.Conn) *proxyConn {
+	return &proxyConn{client: client, done: make(chan struct{})}
+}
+
+func (c *proxyConn) setServer(server net.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 拨号期间已进入半开或已关闭
+	if c.halfOpen || c.isClosed() {
+		server.Close()
+		return
+	}
+	c.server = server
+}
+
+func (c *proxyConn) getServer() net.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.server
+}
+
+// setHalfOpen 断开上游，保持客户端连接
+func (c *proxyConn) setHalfOpen() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.halfOpen = true
+	if c.server != nil {
+		c.server.Close()
+	}
+}
+
+func (c *proxyConn) isHalfOpen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.halfOpen
+}
+
+func (c *proxyConn) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// reset 以RST关闭客户端连接，返回是否由本次调用关闭
+func (c *proxyConn) reset() bool {
+	if c.isClosed() {
+		return false
+	}
+	if tcp, ok := c.client.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+	return c.close()
+}
+
+// close 关闭两端连接，返回是否由本次调用关闭
+func (c *proxyConn) close() bool {
+	closed := false
+	c.closeOnce.Do(func() {
+		closed = true
+		close(c.done)
+		c.client.Close()
+		if server := c.getServer(); server != nil {
+			server.Close()
+		}
+	})
+	return closed
+}
+
+// sleep 等待指定时长，连接关闭时返回false
+func (c *proxyConn) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return !c.isClosed()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.done:
+		return false
+	}
+}
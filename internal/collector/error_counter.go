@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// errorCounter 按错误类型计数，可被多个goroutine并发累加
+type errorCounter struct {
+	counts sync.Map // core.ErrorType -> *atomic.Int64
+}
+
+// add 为错误类型累加n次
+func (c *errorCounter) add(errType core.ErrorType, n int64) {
+	v, ok := c.counts.Load(errType)
+	if !ok {
+		v, _ = c.counts.LoadOrStore(errType, new(atomic.Int64))
+	}
+	v.(*atomic.Int64).Add(n)
+}
+
+// snapshot 返回各错误类型的计数，没有错误时返回nil
+func (c *errorCounter) snapshot() map[core.ErrorType]int64 {
+	var counts map[core.ErrorType]int64
+	c.counts.Range(func(key, value any) bool {
+		if counts == nil {
+			counts = make(map[core.ErrorType]int64)
+		}
+		counts[key.(core.ErrorType)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return counts
+}
+
+// clear 清空计数
+func (c *errorCounter) clear() {
+	c.counts.Clear()
+}
@@ -0,0 +1,200 @@
+package collector
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLatencyPrecision 默认延迟直方图精度（有效十进制位数）
+const DefaultLatencyPrecision = 2
+
+// maxTrackableLatency 直方图可区分的最大延迟（约73分钟），更大的值计入最后一个桶，Max仍精确记录
+const maxTrackableLatency = int64(1)<<42 - 1
+
+// precisionBits 各精度（有效十进制位数1-3）对应的桶内线性分段位数
+var precisionBits = [...]int{1: 4, 2: 7, 3: 10}
+
+// Histogram 固定内存的对数分桶延迟直方图（HDR风格），记录无锁
+//
+// 纳秒值按最高有效位分组，每组再线性划分为 2^bits 个桶，相对误差不超过 2^-bits
+// （精度1/2/3分别约为6%/0.8%/0.1%）；小于 2^bits 纳秒的值精确记录。
+// 精度2时占用约37KB，精度3时约270KB，与记录次数无关。
+type Histogram struct {
+	bits   int
+	counts []atomic.Int64
+	count  atomic.Int64
+	sum    atomic.Int64
+	min    atomic.Int64
+	max    atomic.Int64
+}
+
+// NewHistogram 创建延迟直方图，precision为有效十进制位数（1-3），超出范围时取最近的有效值
+func NewHistogram(precision int) *Histogram {
+	precision = min(max(precision, 1), len(precisionBits)-1)
+	h := &Histogram{bits: precisionBits[precision]}
+	h.counts = make([]atomic.Int64, bucketIndex(maxTrackableLatency, h.bits)+1)
+	h.min.Store(math.MaxInt64)
+	return h
+}
+
+// bucketIndex 返回值v（纳秒，非负）所在的桶
+func bucketIndex(v int64, subBits int) int {
+	if v < int64(1)<<subBits {
+		return int(v)
+	}
+	shift := bits.Len64(uint64(v)) - subBits - 1
+	return (shift+1)<<subBits + int(v>>shift) - 1<<subBits
+}
+
+// bucketRange 返回桶i覆盖的值范围 [lower, upper]
+func bucketRange(i, subBits int) (lower, upper int64) {
+	if i < 1<<subBits {
+		return int64(i), int64(i)
+	}
+	shift := i>>subBits - 1
+	mantissa := int64(i&(1<<subBits-1) + 1<<subBits)
+	lower = mantissa << shift
+	return lower, lower + int64(1)<<shift - 1
+}
+
+// Record 记录一次延迟，可被多个goroutine并发调用
+func (h *Histogram) Record(d time.Duration) {
+	v := int64(d)
+	if v < 0 {
+		v = 0
+	}
+	h.counts[bucketIndex(min(v, maxTrackableLatency), h.bits)].Add(1)
+	h.count.Add(1)
+	h.sum.Add(v)
+
+	for {
+		cur := h.min.Load()
+		if v >= cur || h.min.CompareAndSwap(cur, v) {
+			break
+		}
+	}
+	for {
+		cur := h.max.Load()
+		if v <= cur || h.max.CompareAndSwap(cur, v) {
+			break
+		}
+	}
+}
+
+// Snapshot 返回当前分布的快照
+// 与并发的 Record 之间不保证原子性，快照中的总数以各桶计数之和为准
+func (h *Histogram) Snapshot() *HistogramSnapshot {
+	s := &HistogramSnapshot{
+		bits:   h.bits,
+		counts: make([]int64, len(h.counts)),
+		sum:    h.sum.Load(),
+		min:    h.min.Load(),
+		max:    h.max.Load(),
+	}
+	for i := range h.counts {
+		n := h.counts[i].Load()
+		s.counts[i] = n
+		s.count += n
+	}
+	return s
+}
+
+// HistogramSnapshot 延迟直方图快照，可合并
+type HistogramSnapshot struct {
+	bits   int
+	counts []int64
+	count  int64
+	sum    int64
+	min    int64
+	max    int64
+}
+
+// Merge 合并另一个相同精度的快照
+func (s *HistogramSnapshot) Merge(other *HistogramSnapshot) error {
+	if other.bits != s.bits {
+		return fmt.Errorf("cannot merge histograms with different precision")
+	}
+	for i, n := range other.counts {
+		s.counts[i] += n
+	}
+	s.count += other.count
+	s.sum += other.sum
+	s.min = min(s.min, other.min)
+	s.max = max(s.max, other.max)
+	return nil
+}
+
+// Count 记录次数
+func (s *HistogramSnapshot) Count() int64 {
+	return s.count
+}
+
+// Min 最小延迟（精确值）
+func (s *HistogramSnapshot) Min() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return time.Duration(s.min)
+}
+
+// Max 最大延迟（精确值）
+func (s *HistogramSnapshot) Max() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return time.Duration(s.max)
+}
+
+// Mean 平均延迟（精确值）
+func (s *HistogramSnapshot) Mean() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return time.Duration(s.sum / s.count)
+}
+
+// Percentile 返回百分位延迟（q取0-100），即第 floor(count*q/100)+1 小的值所在桶的代表值
+func (s *HistogramSnapshot) Percentile(q float64) time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	rank := min(int64(float64(s.count)*q/100)+1, s.count)
+
+	var seen int64
+	for i, n := range s.counts {
+		seen += n
+		if seen >= rank {
+			return time.Duration(s.value(i))
+		}
+	}
+	return time.Duration(s.max)
+}
+
+// StdDev 延迟标准差（按各桶代表值估算）
+func (s *HistogramSnapshot) StdDev() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	mean := float64(s.sum) / float64(s.count)
+	var variance float64
+	for i, n := range s.counts {
+		if n > 0 {
+			diff := float64(s.value(i)) - mean
+			variance += diff * diff * float64(n)
+		}
+	}
+	return time.Duration(math.Sqrt(variance / float64(s.count)))
+}
+
+// value 桶的代表值：桶范围的中点，并限制在实际的最小/最大值之间；
+// 最后一个桶还包含超出可区分范围的值，取最大值
+func (s *HistogramSnapshot) value(i int) int64 {
+	if i == len(s.counts)-1 {
+		return s.max
+	}
+	lower, upper := bucketRange(i, s.bits)
+	return min(max(lower+(upper-lower)/2, s.min), s.max)
+}
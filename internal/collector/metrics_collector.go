@@ -1,23 +1,50 @@
 package collector
 
 import (
-	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"middleware-chaos-testing/internal/core"
 )
 
+// ErrorClassifier 将失败操作的错误归类为 core.ErrorType
+type ErrorClassifier func(err error) core.ErrorType
+
+// OperationObserver 操作结果观察者（例如实时导出指标），在每次记录操作后调用
+// errType 为失败操作的错误分类，成功时为空；会被多个worker并发调用
+type OperationObserver interface {
+	ObserveOperation(result *core.Result, errType core.ErrorType)
+}
+
+// observerHolder 使不同类型的观察者可以存入同一个 atomic.Pointer
+type observerHolder struct {
+	OperationObserver
+}
+
 // MetricsCollector 指标收集器实现
+//
+// 操作计数、错误分类计数、延迟直方图和时间序列均无锁（原子）记录，并发worker不会在 mu 上争用。
+// 故障检测（连续失败数、错误率滑动窗口）需要逐个按顺序处理操作结果，
+// 因此 RecordOperation 每次都会短暂持有独立的 outageMu，这是记录路径上唯一的锁
 type MetricsCollector struct {
 	mu sync.RWMutex
 
-	// 操作统计
-	totalOps      int64
-	successOps    int64
-	failedOps     int64
-	latencies     []time.Duration
-	errors        map[core.ErrorType]int64
+	// 操作统计（无锁）
+	totalOps   atomic.Int64
+	successOps atomic.Int64
+	failedOps  atomic.Int64
+	latencies  atomic.Pointer[Histogram]
+	precision  int
+
+	// 错误统计（无锁）
+	errors   errorCounter
+	classify atomic.Pointer[ErrorClassifier]
+
+	// 按操作分类的统计，string -> *operationStats
+	operations sync.Map
+
+	observer atomic.Pointer[observerHolder]
 
 	// 连接统计
 	totalConnAttempts      int64
@@ -27,9 +54,14 @@ type MetricsCollector struct {
 	totalReconnectAttempts int64
 	successfulReconnects   int64
 
-	// 故障恢复统计
-	failureStartTimes []time.Time
-	recoveryTimes     []time.Duration
+	// 故障检测需要按顺序处理操作结果，每次记录操作都持有 outageMu
+	outageMu   sync.Mutex
+	outageRule OutageDetection
+	outages    *outageDetector
+
+	// 指标时间序列
+	seriesInterval time.Duration
+	series         atomic.Pointer[seriesRecorder]
 
 	// 时间统计
 	startTime time.Time
@@ -38,26 +70,136 @@ type MetricsCollector struct {
 
 // NewMetricsCollector 创建新的指标收集器
 func NewMetricsCollector() *MetricsCollector {
-	return &MetricsCollector{
-		latencies: make([]time.Duration, 0, 10000),
-		errors:    make(map[core.ErrorType]int64),
-		startTime: time.Now(),
+	mc := &MetricsCollector{
+		precision:  DefaultLatencyPrecision,
+		startTime:  time.Now(),
+		outageRule: DefaultOutageDetection(),
+		outages:    newOutageDetector(DefaultOutageDetection()),
+
+		seriesInterval: DefaultSeriesInterval,
 	}
+	mc.latencies.Store(NewHistogram(mc.precision))
+	mc.series.Store(newSeriesRecorder(mc.startTime, mc.seriesInterval, mc.precision))
+	return mc
 }
 
-// RecordOperation 记录一次操作
-func (mc *MetricsCollector) RecordOperation(result *core.Result) {
+// SetOutageDetection 设置故障窗口检测规则，需在记录操作之前调用
+func (mc *MetricsCollector) SetOutageDetection(rule OutageDetection) {
+	mc.outageMu.Lock()
+	defer mc.outageMu.Unlock()
+
+	mc.outageRule = rule
+	mc.outages = newOutageDetector(rule)
+}
+
+// SetLatencyPrecision 设置延迟直方图精度（有效十进制位数1-3），需在记录操作之前调用
+func (mc *MetricsCollector) SetLatencyPrecision(precision int) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	mc.totalOps++
-	mc.latencies = append(mc.latencies, result.Duration)
+	mc.precision = precision
+	mc.latencies.Store(NewHistogram(precision))
+	mc.series.Store(newSeriesRecorder(mc.startTime, mc.seriesInterval, precision))
+}
 
+// SetSeriesInterval 设置指标时间序列的区间长度，需在记录操作之前调用
+func (mc *MetricsCollector) SetSeriesInterval(interval time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.seriesInterval = interval
+	mc.series.Store(newSeriesRecorder(mc.startTime, interval, mc.precision))
+}
+
+// LatencySnapshot 返回当前延迟分布的快照
+func (mc *MetricsCollector) LatencySnapshot() *HistogramSnapshot {
+	return mc.latencies.Load().Snapshot()
+}
+
+// SetErrorClassifier 设置错误分类器（如 middleware.ClassifyError），未设置时失败操作均计为其他错误
+func (mc *MetricsCollector) SetErrorClassifier(classify ErrorClassifier) {
+	if classify == nil {
+		mc.classify.Store(nil)
+		return
+	}
+	mc.classify.Store(&classify)
+}
+
+// SetObserver 设置操作结果观察者，nil表示取消
+func (mc *MetricsCollector) SetObserver(observer OperationObserver) {
+	if observer == nil {
+		mc.observer.Store(nil)
+		return
+	}
+	mc.observer.Store(&observerHolder{observer})
+}
+
+// RecordOperation 记录一次操作
+// 失败操作按错误分类器归类计入 ErrorsByType 和所在区间；除故障检测持有 outageMu 外均无锁
+func (mc *MetricsCollector) RecordOperation(result *core.Result) {
+	mc.latencies.Load().Record(result.Duration)
+	mc.totalOps.Add(1)
+
+	op := mc.operationStats(result)
+	if op != nil {
+		op.latencies.Record(result.Duration)
+		op.total.Add(1)
+	}
+
+	var errType core.ErrorType
 	if result.Success {
-		mc.successOps++
+		mc.successOps.Add(1)
+		if op != nil {
+			op.successes.Add(1)
+		}
 	} else {
-		mc.failedOps++
+		mc.failedOps.Add(1)
+		errType = mc.classifyError(result.Error)
+		mc.errors.add(errType, 1)
+		if op != nil {
+			op.failures.Add(1)
+			op.errors.add(errType, 1)
+		}
+	}
+
+	mc.series.Load().observe(result, errType)
+
+	mc.outageMu.Lock()
+	mc.outages.observe(result)
+	mc.outageMu.Unlock()
+
+	if h := mc.observer.Load(); h != nil {
+		h.ObserveOperation(result, errType)
+	}
+}
+
+// operationStats 返回结果所属操作的统计，结果未标注操作时返回nil
+func (mc *MetricsCollector) operationStats(result *core.Result) *operationStats {
+	key := operationKey(result)
+	if key == "" {
+		return nil
+	}
+	if op, ok := mc.operations.Load(key); ok {
+		return op.(*operationStats)
+	}
+
+	mc.mu.RLock()
+	precision := mc.precision
+	mc.mu.RUnlock()
+	op, _ := mc.operations.LoadOrStore(key, newOperationStats(result.OpType, precision))
+	return op.(*operationStats)
+}
+
+// classifyError 归类失败操作的错误，无法归类时视为其他错误
+func (mc *MetricsCollector) classifyError(err error) core.ErrorType {
+	classify := mc.classify.Load()
+	if err == nil || classify == nil {
+		return core.ErrorTypeOther
 	}
+	if errorType := (*classify)(err); errorType != "" {
+		return errorType
+	}
+	return core.ErrorTypeOther
 }
 
 // RecordConnectionAttempt 记录连接尝试
@@ -71,25 +213,34 @@ func (mc *MetricsCollector) RecordConnectionAttempt(success bool, duration time.
 	}
 }
 
-// RecordError 记录错误
-func (mc *MetricsCollector) RecordError(err error, errorType core.ErrorType) {
+// RecordReconnectAttempt 记录客户端的一次自动重连尝试
+func (mc *MetricsCollector) RecordReconnectAttempt(success bool, duration time.Duration) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	mc.errors[errorType]++
+	mc.totalReconnectAttempts++
+	if success {
+		mc.successfulReconnects++
+	}
+}
+
+// RecordError 记录错误
+func (mc *MetricsCollector) RecordError(err error, errorType core.ErrorType) {
+	mc.errors.add(errorType, 1)
 }
 
 // GetMetrics 获取当前聚合的指标
 func (mc *MetricsCollector) GetMetrics() *core.StabilityMetrics {
-	mc.mu.RLock()
-	defer mc.mu.RUnlock()
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
 
 	mc.endTime = time.Now()
+	totalOps, successOps, failedOps := mc.totalOps.Load(), mc.successOps.Load(), mc.failedOps.Load()
 
 	metrics := &core.StabilityMetrics{
-		TotalOperations:      mc.totalOps,
-		SuccessfulOperations: mc.successOps,
-		FailedOperations:     mc.failedOps,
+		TotalOperations:      totalOps,
+		SuccessfulOperations: successOps,
+		FailedOperations:     failedOps,
 
 		TotalConnectionAttempts:      mc.totalConnAttempts,
 		SuccessfulConnectionAttempts: mc.successfulConnAttempts,
@@ -105,14 +256,14 @@ func (mc *MetricsCollector) GetMetrics() *core.StabilityMetrics {
 	}
 
 	// 复制错误统计
-	for k, v := range mc.errors {
+	for k, v := range mc.errors.snapshot() {
 		metrics.ErrorsByType[k] = v
 	}
 
 	// 计算可用性
-	if mc.totalOps > 0 {
-		metrics.Availability = float64(mc.successOps) / float64(mc.totalOps)
-		metrics.ErrorRate = float64(mc.failedOps) / float64(mc.totalOps)
+	if totalOps > 0 {
+		metrics.Availability = float64(successOps) / float64(totalOps)
+		metrics.ErrorRate = float64(failedOps) / float64(totalOps)
 	}
 
 	// 计算连接成功率
@@ -129,40 +280,41 @@ func (mc *MetricsCollector) GetMetrics() *core.StabilityMetrics {
 	}
 
 	// 计算延迟指标
-	if len(mc.latencies) > 0 {
-		sortedLatencies := make([]time.Duration, len(mc.latencies))
-		copy(sortedLatencies, mc.latencies)
-		sort.Slice(sortedLatencies, func(i, j int) bool {
-			return sortedLatencies[i] < sortedLatencies[j]
-		})
-
-		metrics.P50Latency = sortedLatencies[len(sortedLatencies)*50/100]
-		metrics.P95Latency = sortedLatencies[len(sortedLatencies)*95/100]
-		metrics.P99Latency = sortedLatencies[len(sortedLatencies)*99/100]
-		metrics.MinLatency = sortedLatencies[0]
-		metrics.MaxLatency = sortedLatencies[len(sortedLatencies)-1]
-
-		// 计算平均延迟
-		var totalLatency time.Duration
-		for _, lat := range mc.latencies {
-			totalLatency += lat
-		}
-		metrics.AvgLatency = totalLatency / time.Duration(len(mc.latencies))
+	if latencies := mc.latencies.Load().Snapshot(); latencies.Count() > 0 {
+		metrics.P50Latency = latencies.Percentile(50)
+		metrics.P95Latency = latencies.Percentile(95)
+		metrics.P99Latency = latencies.Percentile(99)
+		metrics.P999Latency = latencies.Percentile(99.9)
+		metrics.P9999Latency = latencies.Percentile(99.99)
+		metrics.MinLatency = latencies.Min()
+		metrics.MaxLatency = latencies.Max()
+		metrics.AvgLatency = latencies.Mean()
+		metrics.StdDevLatency = latencies.StdDev()
 	}
 
 	// 计算吞吐量
 	if metrics.Duration > 0 {
-		metrics.Throughput = float64(mc.totalOps) / metrics.Duration.Seconds()
+		metrics.Throughput = float64(totalOps) / metrics.Duration.Seconds()
 	}
 
-	// 计算MTTR（如果有恢复数据）
-	if len(mc.recoveryTimes) > 0 {
-		var totalRecovery time.Duration
-		for _, rt := range mc.recoveryTimes {
-			totalRecovery += rt
+	// 按操作分类的指标
+	mc.operations.Range(func(key, value any) bool {
+		if metrics.Operations == nil {
+			metrics.Operations = make(map[string]*core.OperationMetrics)
 		}
-		metrics.MTTR = totalRecovery / time.Duration(len(mc.recoveryTimes))
-	}
+		metrics.Operations[key.(string)] = value.(*operationStats).metrics(key.(string), metrics.Duration)
+		return true
+	})
+
+	// 根据检测到的故障窗口计算MTTR/MTBF
+	mc.outageMu.Lock()
+	outages := mc.outages.snapshot(mc.endTime)
+	mc.outageMu.Unlock()
+	applyOutageMetrics(metrics, outages)
+
+	series := mc.series.Load()
+	metrics.Series = series.snapshot(mc.endTime)
+	metrics.SeriesInterval = series.interval
 
 	return metrics
 }
@@ -172,17 +324,20 @@ func (mc *MetricsCollector) Reset() {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	mc.totalOps = 0
-	mc.successOps = 0
-	mc.failedOps = 0
-	mc.latencies = make([]time.Duration, 0, 10000)
-	mc.errors = make(map[core.ErrorType]int64)
+	mc.totalOps.Store(0)
+	mc.successOps.Store(0)
+	mc.failedOps.Store(0)
+	mc.latencies.Store(NewHistogram(mc.precision))
+	mc.errors.clear()
+	mc.operations.Clear()
 	mc.totalConnAttempts = 0
 	mc.successfulConnAttempts = 0
 	mc.totalReconnectAttempts = 0
 	mc.successfulReconnects = 0
-	mc.failureStartTimes = nil
-	mc.recoveryTimes = nil
 	mc.startTime = time.Now()
+	mc.outageMu.Lock()
+	mc.outages = newOutageDetector(mc.outageRule)
+	mc.outageMu.Unlock()
+	mc.series.Store(newSeriesRecorder(mc.startTime, mc.seriesInterval, mc.precision))
 	mc.endTime = time.Time{}
 }
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"sync/atomic"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// operationStats 单一操作的统计，计数、错误分类和延迟均无锁记录
+type operationStats struct {
+	opType    core.OperationType
+	total     atomic.Int64
+	successes atomic.Int64
+	failures  atomic.Int64
+	latencies *Histogram
+	errors    errorCounter
+}
+
+func newOperationStats(opType core.OperationType, precision int) *operationStats {
+	return &operationStats{
+		opType:    opType,
+		latencies: NewHistogram(precision),
+	}
+}
+
+// operationKey 返回结果所属操作的统计键，未标注操作的结果返回空字符串
+func operationKey(result *core.Result) string {
+	if result.Operation != "" {
+		return result.Operation
+	}
+	return string(result.OpType)
+}
+
+// metrics 汇总为操作指标
+func (s *operationStats) metrics(name string, duration time.Duration) *core.OperationMetrics {
+	om := &core.OperationMetrics{
+		Name:       name,
+		Type:       s.opType,
+		Operations: s.total.Load(),
+		Successes:  s.successes.Load(),
+		Failures:   s.failures.Load(),
+	}
+	if om.Operations > 0 {
+		om.Availability = float64(om.Successes) / float64(om.Operations)
+		om.ErrorRate = float64(om.Failures) / float64(om.Operations)
+	}
+	if duration > 0 {
+		om.Throughput = float64(om.Operations) / duration.Seconds()
+	}
+	om.ErrorsByType = s.errors.snapshot()
+	if latencies := s.latencies.Snapshot(); latencies.Count() > 0 {
+		om.P50Latency = latencies.Percentile(50)
+		om.P95Latency = latencies.Percentile(95)
+		om.P99Latency = latencies.Percentile(99)
+		om.P999Latency = latencies.Percentile(99.9)
+		om.AvgLatency = latencies.Mean()
+		om.MaxLatency = latencies.Max()
+	}
+	return om
+}
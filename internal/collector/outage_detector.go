@@ -0,0 +1,189 @@
+package collector
+
+import (
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// OutageDetection 故障窗口检测规则，满足任一规则即判定进入故障
+type OutageDetection struct {
+	// ConsecutiveFailures 连续失败次数达到该值时判定故障，0表示不启用
+	ConsecutiveFailures int
+
+	// ErrorRate 滑动窗口内错误率达到该值（0-1）时判定故障，0表示不启用
+	ErrorRate float64
+
+	// Window 错误率滑动窗口大小
+	Window time.Duration
+
+	// MinWindowOps 窗口内最少操作数，避免低流量时单次失败即判定故障
+	MinWindowOps int
+}
+
+// DefaultOutageDetection 默认故障检测规则：连续5次失败，或5s内错误率达到50%
+func DefaultOutageDetection() OutageDetection {
+	return OutageDetection{
+		ConsecutiveFailures: 5,
+		ErrorRate:           0.5,
+		Window:              5 * time.Second,
+		MinWindowOps:        10,
+	}
+}
+
+// sample 滑动窗口中的一次操作
+type sample struct {
+	at      time.Time
+	success bool
+}
+
+// outageDetector 从操作结果流中检测故障窗口，非并发安全
+//
+// 进入故障：满足任一检测规则，故障开始时间为当前连续失败中首个操作的开始时间；
+// 恢复：收到成功操作且窗口错误率回落到阈值以下，恢复时间为当前连续成功中
+// 首个操作的完成时间，避免滑动窗口的滞后计入恢复时间
+type outageDetector struct {
+	rule OutageDetection
+
+	consecutive  int
+	failureStart time.Time // 当前连续失败的开始时间
+	successStart time.Time // 当前连续成功的开始时间
+
+	window   []sample
+	failures int // 窗口内失败数
+
+	current *core.Outage
+	outages []core.Outage
+}
+
+func newOutageDetector(rule OutageDetection) *outageDetector {
+	return &outageDetector{rule: rule}
+}
+
+// observe 处理一次操作结果
+func (d *outageDetector) observe(result *core.Result) {
+	completed := result.Timestamp
+	if completed.IsZero() {
+		completed = time.Now()
+	}
+	started := completed.Add(-result.Duration)
+
+	d.slide(completed, result.Success)
+
+	if result.Success {
+		if d.consecutive > 0 || d.successStart.IsZero() {
+			d.successStart = completed
+		}
+		d.consecutive = 0
+
+		if d.current != nil && !d.errorRateExceeded() {
+			d.recover()
+		}
+		return
+	}
+
+	if d.consecutive == 0 {
+		d.failureStart = started
+	}
+	d.consecutive++
+
+	if d.current != nil {
+		d.current.Failures++
+		return
+	}
+
+	consecutiveExceeded := d.rule.ConsecutiveFailures > 0 && d.consecutive >= d.rule.ConsecutiveFailures
+	if consecutiveExceeded || d.errorRateExceeded() {
+		d.current = &core.Outage{
+			Start:    d.failureStart,
+			Failures: int64(d.consecutive),
+		}
+	}
+}
+
+// slide 加入新样本并移出窗口外的旧样本
+func (d *outageDetector) slide(at time.Time, success bool) {
+	if d.rule.ErrorRate <= 0 || d.rule.Window <= 0 {
+		return
+	}
+
+	d.window = append(d.window, sample{at: at, success: success})
+	if !success {
+		d.failures++
+	}
+
+	cutoff := at.Add(-d.rule.Window)
+	drop := 0
+	for drop < len(d.window) && d.window[drop].at.Before(cutoff) {
+		if !d.window[drop].success {
+			d.failures--
+		}
+		drop++
+	}
+	if drop > 0 {
+		d.window = append(d.window[:0], d.window[drop:]...)
+	}
+}
+
+func (d *outageDetector) errorRateExceeded() bool {
+	if d.rule.ErrorRate <= 0 || d.rule.Window <= 0 || len(d.window) < d.rule.MinWindowOps || len(d.window) == 0 {
+		return false
+	}
+	return float64(d.failures)/float64(len(d.window)) >= d.rule.ErrorRate
+}
+
+// recover 结束当前故障
+func (d *outageDetector) recover() {
+	outage := *d.current
+	outage.End = d.successStart
+	if outage.End.Before(outage.Start) {
+		outage.End = outage.Start
+	}
+	outage.Duration = outage.End.Sub(outage.Start)
+	outage.Recovered = true
+
+	d.outages = append(d.outages, outage)
+	d.current = nil
+}
+
+// snapshot 返回所有故障窗口，进行中的故障以 now 作为结束时间
+func (d *outageDetector) snapshot(now time.Time) []core.Outage {
+	outages := append([]core.Outage(nil), d.outages...)
+	if d.current != nil {
+		outage := *d.current
+		outage.End = now
+		outage.Duration = now.Sub(outage.Start)
+		outages = append(outages, outage)
+	}
+	return outages
+}
+
+// applyOutageMetrics 根据故障窗口计算恢复性指标
+func applyOutageMetrics(metrics *core.StabilityMetrics, outages []core.Outage) {
+	if len(outages) == 0 {
+		return
+	}
+
+	metrics.Outages = outages
+	metrics.OutageCount = int64(len(outages))
+
+	var recovered int64
+	var recoveryTotal time.Duration
+	for _, o := range outages {
+		metrics.TotalDowntime += o.Duration
+		if o.Duration > metrics.LongestOutage {
+			metrics.LongestOutage = o.Duration
+		}
+		if o.Recovered {
+			recovered++
+			recoveryTotal += o.Duration
+		}
+	}
+
+	if recovered > 0 {
+		metrics.MTTR = recoveryTotal / time.Duration(recovered)
+	}
+	if uptime := metrics.Duration - metrics.TotalDowntime; uptime > 0 {
+		metrics.MTBF = uptime / time.Duration(metrics.OutageCount)
+	}
+}
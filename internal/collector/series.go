@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// DefaultSeriesInterval 默认时间序列区间长度
+const DefaultSeriesInterval = time.Second
+
+// openInterval 仍在接收操作结果的区间，计数无锁记录
+type openInterval struct {
+	index     int
+	ops       atomic.Int64
+	successes atomic.Int64
+	failures  atomic.Int64
+	errors    errorCounter
+	latencies *Histogram
+}
+
+// record 记录一次操作结果
+func (b *openInterval) record(result *core.Result, errType core.ErrorType) {
+	b.ops.Add(1)
+	b.latencies.Record(result.Duration)
+	if result.Success {
+		b.successes.Add(1)
+		return
+	}
+	b.failures.Add(1)
+	b.errors.add(errType, 1)
+}
+
+// seriesRecorder 按操作完成时间把结果划分到固定长度的区间，可并发调用
+//
+// 并发worker的结果到达顺序与完成时间不完全一致，因此最近的两个区间保持打开；
+// 更早的区间在新区间出现时汇总为 core.IntervalMetrics 并释放直方图，
+// 内存占用与区间数成正比而与操作数无关。晚于此到达的结果计入最早的打开区间。
+// 落入已打开区间的结果只持有读锁并原子累加，仅打开新区间时持有写锁。
+type seriesRecorder struct {
+	start     time.Time
+	interval  time.Duration
+	precision int
+
+	mu     sync.RWMutex
+	closed []core.IntervalMetrics
+	open   []*openInterval // 按index升序，最多两个
+}
+
+func newSeriesRecorder(start time.Time, interval time.Duration, precision int) *seriesRecorder {
+	if interval <= 0 {
+		interval = DefaultSeriesInterval
+	}
+	return &seriesRecorder{start: start, interval: interval, precision: precision}
+}
+
+// observe 记录一次操作结果，errType为失败操作的错误分类
+func (s *seriesRecorder) observe(result *core.Result, errType core.ErrorType) {
+	completed := result.Timestamp
+	if completed.IsZero() {
+		completed = time.Now()
+	}
+
+	index := s.indexOf(completed)
+
+	s.mu.RLock()
+	if b := s.find(index); b != nil {
+		b.record(result, errType)
+		s.mu.RUnlock()
+		return
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	s.bucket(index).record(result, errType)
+	s.mu.Unlock()
+}
+
+// indexOf 返回时间点所在区间的序号，早于开始时间的计入第一个区间
+func (s *seriesRecorder) indexOf(at time.Time) int {
+	return max(int(at.Sub(s.start)/s.interval), 0)
+}
+
+// find 返回结果应计入的已打开区间，需要打开新区间时返回nil，需持有读锁
+func (s *seriesRecorder) find(index int) *openInterval {
+	for _, b := range s.open {
+		if b.index == index {
+			return b
+		}
+	}
+	if len(s.open) > 0 && index < s.open[0].index {
+		return s.open[0]
+	}
+	return nil
+}
+
+// bucket 返回序号为index的打开区间，必要时关闭更早的区间，需持有写锁
+func (s *seriesRecorder) bucket(index int) *openInterval {
+	if b := s.find(index); b != nil {
+		return b
+	}
+
+	// 只保留index-1及之后的区间
+	keep := 0
+	for keep < len(s.open) && s.open[keep].index < index-1 {
+		s.close(s.open[keep])
+		keep++
+	}
+	s.open = append(s.open[:0], s.open[keep:]...)
+	if len(s.open) == 0 {
+		s.fill(index)
+	}
+
+	b := &openInterval{
+		index:     index,
+		latencies: NewHistogram(s.precision),
+	}
+	s.open = append(s.open, b)
+	return b
+}
+
+// close 汇总区间
+func (s *seriesRecorder) close(b *openInterval) {
+	s.fill(b.index)
+	s.closed = append(s.closed, s.summarize(b))
+}
+
+// fill 为已关闭区间之后、序号to之前没有操作的区间补充空记录
+func (s *seriesRecorder) fill(to int) {
+	for i := len(s.closed); i < to; i++ {
+		s.closed = append(s.closed, s.empty(i))
+	}
+}
+
+func (s *seriesRecorder) empty(index int) core.IntervalMetrics {
+	return core.IntervalMetrics{Start: s.start.Add(time.Duration(index) * s.interval), Duration: s.interval}
+}
+
+func (s *seriesRecorder) summarize(b *openInterval) core.IntervalMetrics {
+	m := s.empty(b.index)
+	m.Operations = b.ops.Load()
+	m.Successes = b.successes.Load()
+	m.Failures = b.failures.Load()
+	m.ErrorsByType = b.errors.snapshot()
+	if latencies := b.latencies.Snapshot(); latencies.Count() > 0 {
+		m.P50Latency = latencies.Percentile(50)
+		m.P95Latency = latencies.Percentile(95)
+		m.P99Latency = latencies.Percentile(99)
+		m.MaxLatency = latencies.Max()
+	}
+	return m
+}
+
+// snapshot 返回从开始到 now 的完整序列（包括打开的区间和末尾没有操作的区间）
+func (s *seriesRecorder) snapshot(now time.Time) []core.IntervalMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	series := append([]core.IntervalMetrics(nil), s.closed...)
+	for _, b := range s.open {
+		for i := len(series); i < b.index; i++ {
+			series = append(series, s.empty(i))
+		}
+		series = append(series, s.summarize(b))
+	}
+	if now.After(s.start) {
+		for i := len(series); i <= s.indexOf(now); i++ {
+			series = append(series, s.empty(i))
+		}
+	}
+	return series
+}
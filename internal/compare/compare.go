@@ -0,0 +1,272 @@
+package compare
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MinSamples 延迟显著性检验每份报告至少需要的区间数，不足时只按容差判断
+const MinSamples = 5
+
+// Unit 指标的单位，决定显示格式
+type Unit string
+
+const (
+	// UnitRatio 比例（0-1）
+	UnitRatio Unit = "ratio"
+	// UnitDuration 时长（纳秒）
+	UnitDuration Unit = "duration"
+	// UnitRate 速率 (ops/s)
+	UnitRate Unit = "ops/s"
+	// UnitScore 评分
+	UnitScore Unit = "score"
+)
+
+// Verdict 单个指标的对比结论
+type Verdict string
+
+const (
+	// VerdictUnchanged 变化在容差内或不显著
+	VerdictUnchanged Verdict = "unchanged"
+	// VerdictRegression 显著变差
+	VerdictRegression Verdict = "regression"
+	// VerdictImprovement 显著变好
+	VerdictImprovement Verdict = "improvement"
+	// VerdictInfo 仅供参考，不判断回归
+	VerdictInfo Verdict = "info"
+)
+
+// Options 回归判定参数
+type Options struct {
+	LatencyTolerance      float64       // 延迟允许的相对增加
+	MinLatencyChange      time.Duration // 延迟增加小于该值时不视为回归
+	AvailabilityTolerance float64       // 可用性允许的绝对下降
+	ErrorRateTolerance    float64       // 错误率允许的绝对增加
+	Significance          float64       // 显著性水平（单侧检验）
+}
+
+// DefaultOptions 默认参数：延迟增加超过10%且超过100µs，可用性下降或错误率增加超过0.1个百分点，
+// 且在0.05水平上显著
+func DefaultOptions() Options {
+	return Options{
+		LatencyTolerance:      0.10,
+		MinLatencyChange:      100 * time.Microsecond,
+		AvailabilityTolerance: 0.001,
+		ErrorRateTolerance:    0.001,
+		Significance:          0.05,
+	}
+}
+
+// Delta 单个指标的变化
+type Delta struct {
+	Metric    string  // 指标名称，如 availability、p99_latency
+	Unit      Unit    // 单位
+	Baseline  float64 // 基线值
+	Candidate float64 // 对比值
+	Change    float64 // Candidate - Baseline
+	Relative  float64 // Change / Baseline，基线为0时为0
+	PValue    float64 // 单侧检验的p值（检验变化方向上的差异），未检验时为NaN
+	Verdict   Verdict
+}
+
+// Tested 是否进行了显著性检验
+func (d Delta) Tested() bool {
+	return !math.IsNaN(d.PValue)
+}
+
+// Comparison 一份报告与基线的对比
+type Comparison struct {
+	Baseline  *Report
+	Candidate *Report
+	Deltas    []Delta
+}
+
+// Regressions 判定为回归的指标
+func (c *Comparison) Regressions() []Delta {
+	var regressions []Delta
+	for _, d := range c.Deltas {
+		if d.Verdict == VerdictRegression {
+			regressions = append(regressions, d)
+		}
+	}
+	return regressions
+}
+
+// HasRegression 任一对比中是否存在回归
+func HasRegression(comparisons []*Comparison) bool {
+	for _, c := range comparisons {
+		if len(c.Regressions()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare 将 candidate 与 baseline 对比
+//
+// 可用性和错误率使用双比例z检验（基于操作数和失败数），变化超过容差且显著时判定；
+// 延迟百分位使用两份报告各区间的百分位做Mann-Whitney U检验，区间数不足 MinSamples
+// （如P99.9、P99.99或没有时间序列）时只按容差判断。吞吐量和总分仅列出变化。
+func Compare(baseline, candidate *Report, opts Options) *Comparison {
+	c := &Comparison{Baseline: baseline, Candidate: candidate}
+
+	c.Deltas = append(c.Deltas, ratioDelta("availability", baseline.Availability, candidate.Availability,
+		opts.AvailabilityTolerance, false, opts.Significance,
+		baseline.Operations-baseline.Successes, baseline.Operations,
+		candidate.Operations-candidate.Successes, candidate.Operations))
+	c.Deltas = append(c.Deltas, ratioDelta("error_rate", baseline.ErrorRate, candidate.ErrorRate,
+		opts.ErrorRateTolerance, true, opts.Significance,
+		baseline.Failures, baseline.Operations, candidate.Failures, candidate.Operations))
+
+	for _, p := range Percentiles {
+		c.Deltas = append(c.Deltas, latencyDelta(p+"_latency", baseline.Latency[p], candidate.Latency[p],
+			baseline.Intervals[p], candidate.Intervals[p], opts))
+	}
+
+	c.Deltas = append(c.Deltas,
+		newDelta("throughput", UnitRate, baseline.Throughput, candidate.Throughput, VerdictInfo),
+		newDelta("score", UnitScore, baseline.Score, candidate.Score, VerdictInfo))
+	return c
+}
+
+// newDelta 计算指标变化，不做显著性检验
+func newDelta(metric string, unit Unit, baseline, candidate float64, verdict Verdict) Delta {
+	d := Delta{
+		Metric:    metric,
+		Unit:      unit,
+		Baseline:  baseline,
+		Candidate: candidate,
+		Change:    candidate - baseline,
+		PValue:    math.NaN(),
+		Verdict:   verdict,
+	}
+	if baseline != 0 {
+		d.Relative = d.Change / baseline
+	}
+	return d
+}
+
+// ratioDelta 比例指标的变化，higherIsWorse 表示增加为变差（错误率）还是下降为变差（可用性）
+// 变化超过 tolerance 时，用双比例z检验失败比例 bad/total 的变化是否显著
+func ratioDelta(metric string, baseline, candidate, tolerance float64, higherIsWorse bool, alpha float64,
+	badBase, totalBase, badCand, totalCand int64) Delta {
+	d := newDelta(metric, UnitRatio, baseline, candidate, VerdictUnchanged)
+
+	worsening := d.Change
+	if !higherIsWorse {
+		worsening = -worsening
+	}
+	if math.Abs(worsening) <= tolerance {
+		return d
+	}
+	worse := worsening > 0
+	if totalBase > 0 && totalCand > 0 {
+		d.PValue = twoProportionPValue(badBase, totalBase, badCand, totalCand, worse)
+	}
+	if d.Tested() && d.PValue >= alpha {
+		return d
+	}
+	if worse {
+		d.Verdict = VerdictRegression
+	} else {
+		d.Verdict = VerdictImprovement
+	}
+	return d
+}
+
+// latencyDelta 延迟百分位的变化
+func latencyDelta(metric string, baseline, candidate time.Duration, baseSamples, candSamples []time.Duration,
+	opts Options) Delta {
+	d := newDelta(metric, UnitDuration, float64(baseline), float64(candidate), VerdictUnchanged)
+
+	change := candidate - baseline
+	abs := max(change, -change)
+	beyond := abs > opts.MinLatencyChange && (baseline == 0 || math.Abs(d.Relative) > opts.LatencyTolerance)
+	if !beyond {
+		return d
+	}
+	worse := change > 0
+	if len(baseSamples) >= MinSamples && len(candSamples) >= MinSamples {
+		d.PValue = mannWhitneyPValue(baseSamples, candSamples, worse)
+	}
+	if d.Tested() && d.PValue >= opts.Significance {
+		return d
+	}
+	if worse {
+		d.Verdict = VerdictRegression
+	} else {
+		d.Verdict = VerdictImprovement
+	}
+	return d
+}
+
+// twoProportionPValue 双比例z检验的单侧p值，greater 为true时检验对比组比例更大
+func twoProportionPValue(x1, n1, x2, n2 int64, greater bool) float64 {
+	p1 := float64(x1) / float64(n1)
+	p2 := float64(x2) / float64(n2)
+	pooled := float64(x1+x2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 1
+	}
+	z := (p2 - p1) / se
+	if !greater {
+		z = -z
+	}
+	return 1 - normalCDF(z)
+}
+
+// mannWhitneyPValue Mann-Whitney U检验的单侧p值（正态近似，含结校正和连续性校正），
+// greater 为true时检验对比组倾向于更大
+func mannWhitneyPValue(base, cand []time.Duration, greater bool) float64 {
+	type sample struct {
+		value time.Duration
+		cand  bool
+	}
+	samples := make([]sample, 0, len(base)+len(cand))
+	for _, v := range base {
+		samples = append(samples, sample{value: v})
+	}
+	for _, v := range cand {
+		samples = append(samples, sample{value: v, cand: true})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].value < samples[j].value })
+
+	// 相同值取平均秩
+	n := float64(len(samples))
+	var candRanks, ties float64
+	for i := 0; i < len(samples); {
+		j := i
+		for j < len(samples) && samples[j].value == samples[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if samples[k].cand {
+				candRanks += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	n1, n2 := float64(len(base)), float64(len(cand))
+	u := candRanks - n2*(n2+1)/2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (u - mean - 0.5) / math.Sqrt(variance)
+	if !greater {
+		z = (mean - u - 0.5) / math.Sqrt(variance)
+	}
+	return 1 - normalCDF(z)
+}
+
+// normalCDF 标准正态分布的累积分布函数
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
@@ -0,0 +1,211 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// metricLabel 指标的显示名称
+func metricLabel(metric string) string {
+	switch metric {
+	case "availability":
+		return "可用性"
+	case "error_rate":
+		return "错误率"
+	case "p50_latency":
+		return "P50延迟"
+	case "p95_latency":
+		return "P95延迟"
+	case "p99_latency":
+		return "P99延迟"
+	case "p999_latency":
+		return "P99.9延迟"
+	case "p9999_latency":
+		return "P99.99延迟"
+	case "throughput":
+		return "吞吐量"
+	case "score":
+		return "总分"
+	default:
+		return metric
+	}
+}
+
+// verdictLabel 结论的显示名称
+func verdictLabel(v Verdict) string {
+	switch v {
+	case VerdictRegression:
+		return "✗ 回归"
+	case VerdictImprovement:
+		return "✓ 改善"
+	case VerdictUnchanged:
+		return "-"
+	default:
+		return ""
+	}
+}
+
+// formatValue 按单位格式化指标值
+func formatValue(unit Unit, v float64) string {
+	switch unit {
+	case UnitRatio:
+		return fmt.Sprintf("%.3f%%", v*100)
+	case UnitDuration:
+		return time.Duration(v).Round(time.Microsecond).String()
+	case UnitRate:
+		return fmt.Sprintf("%.0f ops/s", v)
+	default:
+		return fmt.Sprintf("%.1f", v)
+	}
+}
+
+// formatChange 按单位格式化变化量，比例以百分点表示，其他附带相对变化
+func formatChange(d Delta) string {
+	var change string
+	switch d.Unit {
+	case UnitRatio:
+		return fmt.Sprintf("%+.3fpp", d.Change*100)
+	case UnitDuration:
+		change = time.Duration(d.Change).Round(time.Microsecond).String()
+		if d.Change >= 0 {
+			change = "+" + change
+		}
+	case UnitRate:
+		change = fmt.Sprintf("%+.0f", d.Change)
+	default:
+		change = fmt.Sprintf("%+.1f", d.Change)
+	}
+	if d.Baseline != 0 {
+		change += fmt.Sprintf(" (%+.1f%%)", d.Relative*100)
+	}
+	return change
+}
+
+// formatPValue 格式化p值，未检验时为 "-"
+func formatPValue(d Delta) string {
+	switch {
+	case !d.Tested():
+		return "-"
+	case d.PValue < 0.001:
+		return "<0.001"
+	default:
+		return fmt.Sprintf("%.3f", d.PValue)
+	}
+}
+
+// reportSummary 报告的一行摘要
+func reportSummary(r *Report) string {
+	summary := fmt.Sprintf("%s (%.1f %s, %s", filepath.Base(r.Name), r.Score, r.Grade, r.Status)
+	if !r.CompletedAt.IsZero() {
+		summary += ", " + r.CompletedAt.Format("2006-01-02 15:04")
+	}
+	return summary + ")"
+}
+
+// regressionSummary 回归结论
+func regressionSummary(c *Comparison) string {
+	regressions := c.Regressions()
+	if len(regressions) == 0 {
+		return "未发现回归"
+	}
+	names := make([]string, len(regressions))
+	for i, d := range regressions {
+		names[i] = metricLabel(d.Metric)
+	}
+	return fmt.Sprintf("发现 %d 项回归: %s", len(regressions), strings.Join(names, ", "))
+}
+
+// displayWidth 终端显示宽度，中文等全角字符占两列
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || (r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// padRight 按显示宽度右侧补空格
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-displayWidth(s), 0))
+}
+
+// rows 对比表格的各行：指标、基线、对比、变化、p值、结论
+func rows(c *Comparison) [][]string {
+	table := make([][]string, 0, len(c.Deltas))
+	for _, d := range c.Deltas {
+		table = append(table, []string{
+			metricLabel(d.Metric),
+			formatValue(d.Unit, d.Baseline),
+			formatValue(d.Unit, d.Candidate),
+			formatChange(d),
+			formatPValue(d),
+			verdictLabel(d.Verdict),
+		})
+	}
+	return table
+}
+
+// tableHeader 对比表格的表头
+var tableHeader = []string{"指标", "基线", "对比", "变化", "p值", "结论"}
+
+// WriteConsole 以控制台格式输出对比结果
+func WriteConsole(w io.Writer, comparisons []*Comparison) error {
+	var sb strings.Builder
+	sb.WriteString("==========================================\n")
+	sb.WriteString("   基线对比\n")
+	sb.WriteString("==========================================\n")
+
+	for _, c := range comparisons {
+		sb.WriteString(fmt.Sprintf("\n基线: %s\n", reportSummary(c.Baseline)))
+		sb.WriteString(fmt.Sprintf("对比: %s\n\n", reportSummary(c.Candidate)))
+
+		table := append([][]string{tableHeader}, rows(c)...)
+		widths := make([]int, len(tableHeader))
+		for _, row := range table {
+			for i, cell := range row {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+		for _, row := range table {
+			line := make([]string, len(row))
+			for i, cell := range row {
+				line[i] = padRight(cell, widths[i])
+			}
+			sb.WriteString("  " + strings.TrimRight(strings.Join(line, "  "), " ") + "\n")
+		}
+		sb.WriteString(fmt.Sprintf("\n结论: %s\n", regressionSummary(c)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMarkdown 以Markdown格式输出对比结果
+func WriteMarkdown(w io.Writer, comparisons []*Comparison) error {
+	var sb strings.Builder
+	sb.WriteString("# 基线对比\n\n")
+
+	for _, c := range comparisons {
+		sb.WriteString(fmt.Sprintf("## %s vs %s\n\n", filepath.Base(c.Baseline.Name), filepath.Base(c.Candidate.Name)))
+		sb.WriteString(fmt.Sprintf("- **基线**: %s\n", reportSummary(c.Baseline)))
+		sb.WriteString(fmt.Sprintf("- **对比**: %s\n\n", reportSummary(c.Candidate)))
+
+		sb.WriteString("| " + strings.Join(tableHeader, " | ") + " |\n")
+		sb.WriteString("|------|------|------|------|-----|------|\n")
+		for _, row := range rows(c) {
+			sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		}
+		sb.WriteString(fmt.Sprintf("\n**结论**: %s\n\n", regressionSummary(c)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,156 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// Percentiles 参与对比的延迟百分位，按显示顺序
+var Percentiles = []string{"p50", "p95", "p99", "p999", "p9999"}
+
+// Report 从JSON报告（reporter.JSONReporterImpl 的输出）中读取的用于对比的数据
+type Report struct {
+	Name         string // 报告来源（文件路径）
+	CompletedAt  time.Time
+	Duration     time.Duration
+	Score        float64
+	Grade        string
+	Status       string
+	Operations   int64
+	Successes    int64
+	Failures     int64
+	Availability float64
+	ErrorRate    float64
+	Throughput   float64
+
+	// Latency 整体延迟百分位，键为 Percentiles 中的名称
+	Latency map[string]time.Duration
+	// Intervals 各时间序列区间的延迟百分位（只包含有操作的区间），键为 p50/p95/p99，
+	// 用于检验延迟变化是否显著；报告不含时间序列时为空
+	Intervals map[string][]time.Duration
+}
+
+// jsonReport JSON报告中用到的字段
+type jsonReport struct {
+	TestInfo struct {
+		Duration    string `json:"duration"`
+		CompletedAt string `json:"completed_at"`
+	} `json:"test_info"`
+	Evaluation struct {
+		Score  float64 `json:"score"`
+		Grade  string  `json:"grade"`
+		Status string  `json:"status"`
+	} `json:"evaluation"`
+	Metrics *struct {
+		Availability struct {
+			Rate                 float64 `json:"rate"`
+			TotalOperations      int64   `json:"total_operations"`
+			SuccessfulOperations int64   `json:"successful_operations"`
+			FailedOperations     int64   `json:"failed_operations"`
+			ErrorRate            float64 `json:"error_rate"`
+		} `json:"availability"`
+		Performance struct {
+			P50Ms      int64            `json:"p50_latency_ms"`
+			P95Ms      int64            `json:"p95_latency_ms"`
+			P99Ms      int64            `json:"p99_latency_ms"`
+			P999Ms     int64            `json:"p999_latency_ms"`
+			P9999Ms    int64            `json:"p9999_latency_ms"`
+			Throughput float64          `json:"throughput"`
+			LatencyUs  map[string]int64 `json:"latency_us"`
+		} `json:"performance"`
+	} `json:"metrics"`
+	Series *struct {
+		Intervals []struct {
+			Operations int64  `json:"operations"`
+			P50Ms      int64  `json:"p50_latency_ms"`
+			P95Ms      int64  `json:"p95_latency_ms"`
+			P99Ms      int64  `json:"p99_latency_ms"`
+			P50Us      *int64 `json:"p50_latency_us"`
+			P95Us      *int64 `json:"p95_latency_us"`
+			P99Us      *int64 `json:"p99_latency_us"`
+		} `json:"intervals"`
+	} `json:"series"`
+}
+
+// Load 读取JSON报告文件
+func Load(path string) (*Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open report: %w", err)
+	}
+	defer f.Close()
+
+	report, err := Parse(f, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return report, nil
+}
+
+// Parse 解析JSON报告，name 为报告来源
+// 延迟优先使用微秒精度的字段，较早版本的报告只有毫秒精度
+func Parse(r io.Reader, name string) (*Report, error) {
+	var raw jsonReport
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: not a JSON report: %v", core.ErrInvalidMetrics, err)
+	}
+	if raw.Metrics == nil {
+		return nil, fmt.Errorf("%w: not a JSON report: missing metrics", core.ErrInvalidMetrics)
+	}
+
+	m := raw.Metrics
+	report := &Report{
+		Name:         name,
+		Score:        raw.Evaluation.Score,
+		Grade:        raw.Evaluation.Grade,
+		Status:       raw.Evaluation.Status,
+		Operations:   m.Availability.TotalOperations,
+		Successes:    m.Availability.SuccessfulOperations,
+		Failures:     m.Availability.FailedOperations,
+		Availability: m.Availability.Rate,
+		ErrorRate:    m.Availability.ErrorRate,
+		Throughput:   m.Performance.Throughput,
+		Latency:      make(map[string]time.Duration, len(Percentiles)),
+		Intervals:    make(map[string][]time.Duration),
+	}
+	report.Duration, _ = time.ParseDuration(raw.TestInfo.Duration)
+	report.CompletedAt, _ = time.Parse(time.RFC3339, raw.TestInfo.CompletedAt)
+
+	millis := map[string]int64{
+		"p50":   m.Performance.P50Ms,
+		"p95":   m.Performance.P95Ms,
+		"p99":   m.Performance.P99Ms,
+		"p999":  m.Performance.P999Ms,
+		"p9999": m.Performance.P9999Ms,
+	}
+	for _, p := range Percentiles {
+		if us, ok := m.Performance.LatencyUs[p]; ok {
+			report.Latency[p] = time.Duration(us) * time.Microsecond
+		} else {
+			report.Latency[p] = time.Duration(millis[p]) * time.Millisecond
+		}
+	}
+
+	if raw.Series != nil {
+		latency := func(us *int64, ms int64) time.Duration {
+			if us != nil {
+				return time.Duration(*us) * time.Microsecond
+			}
+			return time.Duration(ms) * time.Millisecond
+		}
+		for _, im := range raw.Series.Intervals {
+			if im.Operations == 0 {
+				continue
+			}
+			report.Intervals["p50"] = append(report.Intervals["p50"], latency(im.P50Us, im.P50Ms))
+			report.Intervals["p95"] = append(report.Intervals["p95"], latency(im.P95Us, im.P95Ms))
+			report.Intervals["p99"] = append(report.Intervals["p99"], latency(im.P99Us, im.P99Ms))
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,514 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// 环境变量（用于密码等敏感信息，优先级高于配置文件）
+const (
+	EnvUsername = "MCT_USERNAME"
+	EnvPassword = "MCT_PASSWORD"
+
+	// EnvHistoryDir 运行历史目录
+	EnvHistoryDir = "MCT_HISTORY_DIR"
+	// EnvLang 报告语言
+	EnvLang = "MCT_LANG"
+)
+
+// 默认值
+const (
+	DefaultRedisPort  = 6379
+	DefaultKafkaPort  = 9092
+	DefaultTimeout    = 5 * time.Second
+	DefaultKafkaTopic = "chaos-test-topic"
+	DefaultKafkaGroup = "chaos-test-group"
+)
+
+// Config 测试配置文件，实现 core.Config
+// 结构与 PLAN.md 中的 configs/test-redis.yaml 示例一致
+type Config struct {
+	Name       string            `yaml:"name"`
+	Middleware string            `yaml:"middleware"`
+	Connection ConnectionSection `yaml:"connection"`
+	Test       TestSection       `yaml:"test"`
+	Thresholds ThresholdsSection `yaml:"thresholds"`
+	Output     OutputSection     `yaml:"output"`
+	History    HistorySection    `yaml:"history"`
+
+	// Labels 运行标签（如 env: staging），记录到运行历史中用于筛选
+	Labels map[string]string `yaml:"labels"`
+}
+
+// ConnectionSection 连接配置段
+type ConnectionSection struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	DB       int           `yaml:"db"`
+	Timeout  time.Duration `yaml:"timeout"`
+
+	// Kafka特定
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+	GroupID string   `yaml:"group_id"`
+
+	Reconnect ReconnectSection `yaml:"reconnect"`
+}
+
+// ReconnectSection 自动重连策略，未配置的值使用默认策略
+type ReconnectSection struct {
+	Enabled        *bool         `yaml:"enabled"`
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Multiplier     float64       `yaml:"multiplier"`
+	Jitter         Percent       `yaml:"jitter"`
+}
+
+// TestSection 测试配置段
+type TestSection struct {
+	Duration    time.Duration     `yaml:"duration"`
+	Operations  int               `yaml:"operations"`
+	Concurrency int               `yaml:"concurrency"`
+	Workload    []WorkloadSection `yaml:"workload"`
+
+	// Rate 开环模式的固定到达速率 (ops/s)，0表示闭环
+	Rate float64 `yaml:"rate"`
+	// Profile 开环模式的目标速率曲线，以 Rate 为基准
+	Profile *ProfileSection `yaml:"profile"`
+
+	OutageDetection OutageDetectionSection `yaml:"outage_detection"`
+
+	// LatencyPrecision 延迟直方图精度（有效十进制位数1-3），0表示默认值
+	LatencyPrecision int `yaml:"latency_precision"`
+	// SeriesInterval 指标时间序列的区间长度，0表示默认值（1s）
+	SeriesInterval time.Duration `yaml:"series_interval"`
+
+	// Verify 启用数据校验：Redis为读后写一致性校验，Kafka为端到端投递跟踪
+	Verify      bool          `yaml:"verify"`
+	VerifyGrace time.Duration `yaml:"verify_grace"` // Kafka测试结束后等待迟到消息的宽限期
+}
+
+// ProfileSection 目标速率曲线，各字段含义见 core.LoadProfile
+type ProfileSection struct {
+	Shape     string        `yaml:"shape"`
+	StartRate float64       `yaml:"start_rate"`
+	RampTime  time.Duration `yaml:"ramp_time"`
+	StepRate  float64       `yaml:"step_rate"`
+	StepTime  time.Duration `yaml:"step_time"`
+	SpikeRate float64       `yaml:"spike_rate"`
+	SpikeAt   time.Duration `yaml:"spike_at"`
+	SpikeTime time.Duration `yaml:"spike_time"`
+	Amplitude float64       `yaml:"amplitude"`
+	Period    time.Duration `yaml:"period"`
+}
+
+// OutageDetectionSection 故障窗口检测规则，未配置的值使用默认规则
+type OutageDetectionSection struct {
+	ConsecutiveFailures int           `yaml:"consecutive_failures"`
+	ErrorRate           Percent       `yaml:"error_rate"`
+	Window              time.Duration `yaml:"window"`
+	MinWindowOps        int           `yaml:"min_window_ops"`
+}
+
+// WorkloadSection 工作负载条目
+type WorkloadSection struct {
+	Operation  string `yaml:"operation"`
+	Weight     int    `yaml:"weight"`
+	KeyPattern string `yaml:"key_pattern"`
+	ValueSize  int    `yaml:"value_size"`
+}
+
+// ThresholdsSection 阈值配置段，未配置的值使用评估器默认阈值
+type ThresholdsSection struct {
+	Availability PercentLevels  `yaml:"availability"`
+	P95Latency   DurationLevels `yaml:"p95_latency"`
+	P99Latency   DurationLevels `yaml:"p99_latency"`
+	ErrorRate    PercentLevels  `yaml:"error_rate"`
+	MTTR         DurationLevels `yaml:"mttr"`
+
+	// Kafka端到端延迟（发送到被消费）
+	E2EP95Latency DurationLevels `yaml:"e2e_p95_latency"`
+	E2EP99Latency DurationLevels `yaml:"e2e_p99_latency"`
+
+	// Operations 按操作名称（如 set、produce）或操作类型（read/write/delete）的阈值
+	Operations map[string]OperationThresholdsSection `yaml:"operations"`
+}
+
+// OperationThresholdsSection 单一操作的阈值，未配置的项不检查
+type OperationThresholdsSection struct {
+	Availability Percent       `yaml:"availability"`
+	ErrorRate    Percent       `yaml:"error_rate"`
+	P95Latency   time.Duration `yaml:"p95_latency"`
+	P99Latency   time.Duration `yaml:"p99_latency"`
+}
+
+// PercentLevels 百分比分级阈值
+type PercentLevels struct {
+	Excellent Percent `yaml:"excellent"`
+	Good      Percent `yaml:"good"`
+	Fair      Percent `yaml:"fair"`
+	Pass      Percent `yaml:"pass"`
+}
+
+// DurationLevels 时长分级阈值
+type DurationLevels struct {
+	Excellent time.Duration `yaml:"excellent"`
+	Good      time.Duration `yaml:"good"`
+	Fair      time.Duration `yaml:"fair"`
+	Pass      time.Duration `yaml:"pass"`
+}
+
+// OutputSection 输出配置段
+type OutputSection struct {
+	Format                 string `yaml:"format"`
+	Path                   string `yaml:"path"`
+	Template               string `yaml:"template"` // 自定义报告模板文件，仅用于 console 和 markdown
+	Lang                   string `yaml:"lang"`     // 报告语言：zh-CN（默认）或 en
+	IncludeRecommendations *bool  `yaml:"include_recommendations"`
+}
+
+// HistorySection 运行历史配置段
+type HistorySection struct {
+	// Dir 历史目录，每次运行的指标、评估结果、配置和标签追加到其中，为空时不记录
+	Dir string `yaml:"dir"`
+}
+
+// Percent 比例值（0-1）
+// 支持 "99.9%" 形式的百分比字符串，或直接写比例 0.999；同时可用作命令行参数
+type Percent float64
+
+// UnmarshalYAML 解析百分比
+func (p *Percent) UnmarshalYAML(value *yaml.Node) error {
+	if err := p.Set(value.Value); err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	return nil
+}
+
+// Set 解析百分比字符串
+func (p *Percent) Set(value string) error {
+	raw := strings.TrimSpace(value)
+	if strings.HasSuffix(raw, "%") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(raw, "%")), 64)
+		if err != nil {
+			return fmt.Errorf("invalid percentage %q", raw)
+		}
+		*p = Percent(f / 100)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ratio %q", raw)
+	}
+	*p = Percent(f)
+	return nil
+}
+
+// String 以百分比形式输出
+func (p *Percent) String() string {
+	return strconv.FormatFloat(float64(*p)*100, 'g', -1, 64) + "%"
+}
+
+// Type 命令行参数类型名
+func (p *Percent) Type() string {
+	return "percent"
+}
+
+// New 创建空配置
+func New() *Config {
+	return &Config{}
+}
+
+// Load 从文件加载配置
+// 同时支持YAML和JSON（JSON是YAML的子集），未知字段视为错误以便发现拼写问题
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse 解析配置内容，展开 ${VAR} 形式的环境变量引用
+func Parse(data []byte) (*Config, error) {
+	return ParseLiteral(expandEnv(data))
+}
+
+// ParseLiteral 解析配置内容，不展开环境变量引用，${VAR} 按原样保留
+// 用于来自API等外部来源的定义，避免泄露本机的环境变量
+func ParseLiteral(data []byte) (*Config, error) {
+	cfg := New()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", core.ErrInvalidConfig, err)
+	}
+	return cfg, nil
+}
+
+// envRefPattern 匹配 ${VAR}，不处理裸$以免破坏密码中的$字符
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func expandEnv(data []byte) []byte {
+	return envRefPattern.ReplaceAllFunc(data, func(ref []byte) []byte {
+		name := envRefPattern.FindSubmatch(ref)[1]
+		return []byte(os.Getenv(string(name)))
+	})
+}
+
+// ApplyEnv 使用环境变量覆盖敏感配置和运行历史目录
+func (c *Config) ApplyEnv() {
+	if v, ok := os.LookupEnv(EnvUsername); ok {
+		c.Connection.Username = v
+	}
+	if v, ok := os.LookupEnv(EnvPassword); ok {
+		c.Connection.Password = v
+	}
+	if v, ok := os.LookupEnv(EnvHistoryDir); ok {
+		c.History.Dir = v
+	}
+	if v, ok := os.LookupEnv(EnvLang); ok {
+		c.Output.Lang = v
+	}
+}
+
+// ApplyDefaults 填充未配置的默认值
+func (c *Config) ApplyDefaults() {
+	c.Middleware = strings.ToLower(c.Middleware)
+
+	if c.Connection.Host == "" {
+		c.Connection.Host = "localhost"
+	}
+	if c.Connection.Port == 0 {
+		switch c.Middleware {
+		case "redis":
+			c.Connection.Port = DefaultRedisPort
+		case "kafka":
+			c.Connection.Port = DefaultKafkaPort
+		}
+	}
+	if c.Connection.Timeout == 0 {
+		c.Connection.Timeout = DefaultTimeout
+	}
+
+	if c.Middleware == "kafka" {
+		if len(c.Connection.Brokers) == 0 {
+			c.Connection.Brokers = []string{fmt.Sprintf("%s:%d", c.Connection.Host, c.Connection.Port)}
+		}
+		if c.Connection.Topic == "" {
+			c.Connection.Topic = DefaultKafkaTopic
+		}
+		if c.Connection.GroupID == "" {
+			c.Connection.GroupID = DefaultKafkaGroup
+		}
+	}
+
+	if c.Test.Concurrency == 0 {
+		c.Test.Concurrency = 1
+	}
+	if c.Output.Format == "" {
+		c.Output.Format = "console"
+	}
+}
+
+// GetMiddlewareType 获取中间件类型
+func (c *Config) GetMiddlewareType() string {
+	return c.Middleware
+}
+
+// GetConnectionConfig 获取连接配置
+func (c *Config) GetConnectionConfig() *core.ConnectionConfig {
+	return &core.ConnectionConfig{
+		Host:     c.Connection.Host,
+		Port:     c.Connection.Port,
+		Username: c.Connection.Username,
+		Password: c.Connection.Password,
+		Database: c.Connection.DB,
+		Timeout:  c.Connection.Timeout,
+		Brokers:  append([]string(nil), c.Connection.Brokers...),
+		Topic:    c.Connection.Topic,
+		GroupID:  c.Connection.GroupID,
+	}
+}
+
+// GetTestConfig 获取测试配置
+func (c *Config) GetTestConfig() *core.TestConfig {
+	tc := &core.TestConfig{
+		Duration:    c.Test.Duration,
+		Operations:  c.Test.Operations,
+		Concurrency: c.Test.Concurrency,
+		Rate:        c.Test.Rate,
+	}
+	for _, wl := range c.Test.Workload {
+		tc.Workload = append(tc.Workload, core.WorkloadConfig{
+			Operation:  wl.Operation,
+			Weight:     wl.Weight,
+			KeyPattern: wl.KeyPattern,
+			ValueSize:  wl.ValueSize,
+		})
+	}
+	if p := c.Test.Profile; p != nil {
+		tc.Profile = &core.LoadProfile{
+			Shape:     p.Shape,
+			StartRate: p.StartRate,
+			RampTime:  p.RampTime,
+			StepRate:  p.StepRate,
+			StepTime:  p.StepTime,
+			SpikeRate: p.SpikeRate,
+			SpikeAt:   p.SpikeAt,
+			SpikeTime: p.SpikeTime,
+			Amplitude: p.Amplitude,
+			Period:    p.Period,
+		}
+	}
+	return tc
+}
+
+// GetReconnectPolicy 获取客户端自动重连策略，enabled: false 时返回不重连的策略
+func (c *Config) GetReconnectPolicy() middleware.ReconnectPolicy {
+	rs := c.Connection.Reconnect
+	if rs.Enabled != nil && !*rs.Enabled {
+		return middleware.ReconnectPolicy{}
+	}
+
+	policy := middleware.DefaultReconnectPolicy()
+	if rs.MaxAttempts != 0 {
+		policy.MaxAttempts = rs.MaxAttempts
+	}
+	if rs.InitialBackoff != 0 {
+		policy.InitialBackoff = rs.InitialBackoff
+	}
+	if rs.MaxBackoff != 0 {
+		policy.MaxBackoff = rs.MaxBackoff
+	}
+	if rs.Multiplier != 0 {
+		policy.Multiplier = rs.Multiplier
+	}
+	if rs.Jitter != 0 {
+		policy.Jitter = float64(rs.Jitter)
+	}
+	return policy
+}
+
+// GetOutageDetection 获取故障窗口检测规则
+func (c *Config) GetOutageDetection() collector.OutageDetection {
+	rule := collector.DefaultOutageDetection()
+	od := c.Test.OutageDetection
+	if od.ConsecutiveFailures != 0 {
+		rule.ConsecutiveFailures = od.ConsecutiveFailures
+	}
+	if od.ErrorRate != 0 {
+		rule.ErrorRate = float64(od.ErrorRate)
+	}
+	if od.Window != 0 {
+		rule.Window = od.Window
+	}
+	if od.MinWindowOps != 0 {
+		rule.MinWindowOps = od.MinWindowOps
+	}
+	return rule
+}
+
+// GetThresholds 获取阈值配置
+// 未配置的值为零，由 evaluator.MergeThresholds 使用默认值补全
+func (c *Config) GetThresholds() *core.Thresholds {
+	t := c.Thresholds
+
+	var operations map[string]core.OperationThresholds
+	if len(t.Operations) > 0 {
+		operations = make(map[string]core.OperationThresholds, len(t.Operations))
+		for name, op := range t.Operations {
+			operations[strings.ToLower(name)] = core.OperationThresholds{
+				Availability: float64(op.Availability),
+				ErrorRate:    float64(op.ErrorRate),
+				P95Latency:   op.P95Latency,
+				P99Latency:   op.P99Latency,
+			}
+		}
+	}
+
+	return &core.Thresholds{
+		AvailabilityExcellent: float64(t.Availability.Excellent),
+		AvailabilityGood:      float64(t.Availability.Good),
+		AvailabilityFair:      float64(t.Availability.Fair),
+		AvailabilityPass:      float64(t.Availability.Pass),
+
+		P95LatencyExcellent: t.P95Latency.Excellent,
+		P95LatencyGood:      t.P95Latency.Good,
+		P95LatencyFair:      t.P95Latency.Fair,
+		P95LatencyPass:      t.P95Latency.Pass,
+
+		P99LatencyExcellent: t.P99Latency.Excellent,
+		P99LatencyGood:      t.P99Latency.Good,
+		P99LatencyFair:      t.P99Latency.Fair,
+		P99LatencyPass:      t.P99Latency.Pass,
+
+		ErrorRateExcellent: float64(t.ErrorRate.Excellent),
+		ErrorRateGood:      float64(t.ErrorRate.Good),
+		ErrorRateFair:      float64(t.ErrorRate.Fair),
+		ErrorRatePass:      float64(t.ErrorRate.Pass),
+
+		MTTRExcellent: t.MTTR.Excellent,
+		MTTRGood:      t.MTTR.Good,
+		MTTRFair:      t.MTTR.Fair,
+		MTTRPass:      t.MTTR.Pass,
+
+		E2EP95LatencyExcellent: t.E2EP95Latency.Excellent,
+		E2EP95LatencyGood:      t.E2EP95Latency.Good,
+		E2EP95LatencyFair:      t.E2EP95Latency.Fair,
+		E2EP95LatencyPass:      t.E2EP95Latency.Pass,
+
+		E2EP99LatencyExcellent: t.E2EP99Latency.Excellent,
+		E2EP99LatencyGood:      t.E2EP99Latency.Good,
+		E2EP99LatencyFair:      t.E2EP99Latency.Fair,
+		E2EP99LatencyPass:      t.E2EP99Latency.Pass,
+
+		Operations: operations,
+	}
+}
+
+// GetOutputConfig 获取输出配置
+// 报告路径中的 {timestamp} 会被替换为当前时间
+func (c *Config) GetOutputConfig() *core.OutputConfig {
+	include := true
+	if c.Output.IncludeRecommendations != nil {
+		include = *c.Output.IncludeRecommendations
+	}
+	return &core.OutputConfig{
+		Format:                 c.Output.Format,
+		Path:                   strings.ReplaceAll(c.Output.Path, "{timestamp}", time.Now().Format("20060102-150405")),
+		Template:               c.Output.Template,
+		Lang:                   string(c.ReportLang()),
+		IncludeRecommendations: include,
+	}
+}
+
+// ReportLang 报告语言，未配置或无效时为默认语言（无效值由 Validate 报告）
+func (c *Config) ReportLang() i18n.Lang {
+	lang, err := i18n.Parse(c.Output.Lang)
+	if err != nil {
+		return i18n.Default
+	}
+	return lang
+}
@@ -0,0 +1,314 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+	"middleware-chaos-testing/internal/middleware"
+	"middleware-chaos-testing/internal/workload"
+)
+
+// FieldError 字段级校验错误
+// Unwrap 返回 core.ErrInvalidConfig 或 core.ErrInvalidThresholds
+type FieldError struct {
+	Field   string // 字段路径，如 test.duration
+	Message string // 错误描述
+	Err     error  // 错误类别
+}
+
+// Error 实现error接口
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Unwrap 返回错误类别
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors 校验错误列表
+type ValidationErrors []*FieldError
+
+// Error 实现error接口，逐行列出所有字段错误
+func (ve ValidationErrors) Error() string {
+	lines := make([]string, 0, len(ve))
+	for _, e := range ve {
+		lines = append(lines, e.Error())
+	}
+	return fmt.Sprintf("%d config error(s):\n  %s", len(ve), strings.Join(lines, "\n  "))
+}
+
+// Unwrap 支持 errors.Is / errors.As 逐个匹配字段错误
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i, e := range ve {
+		errs[i] = e
+	}
+	return errs
+}
+
+// validator 收集字段错误
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) config(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...), Err: core.ErrInvalidConfig})
+}
+
+func (v *validator) threshold(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...), Err: core.ErrInvalidThresholds})
+}
+
+// Validate 验证配置，返回 ValidationErrors 或nil
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	switch c.Middleware {
+	case "redis", "kafka":
+	case "":
+		v.config("middleware", "is required (redis|kafka)")
+	default:
+		v.config("middleware", "unsupported type %q (redis|kafka)", c.Middleware)
+	}
+
+	c.validateConnection(v)
+	c.validateTest(v)
+	c.validateThresholds(v)
+
+	switch c.Output.Format {
+	case "", "console", "json", "markdown", "md", "html", "junit":
+	default:
+		v.config("output.format", "unsupported format %q", c.Output.Format)
+	}
+	if c.Output.Template != "" {
+		switch c.Output.Format {
+		case "", "console", "markdown", "md":
+		default:
+			v.config("output.template", "templates are only supported for console and markdown output, not %q", c.Output.Format)
+		}
+	}
+	if _, err := i18n.Parse(c.Output.Lang); err != nil {
+		v.config("output.lang", "%v", err)
+	}
+
+	keys := make([]string, 0, len(c.Labels))
+	for key := range c.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "" || strings.ContainsAny(key, "=, ") {
+			v.config("labels", "invalid label name %q", key)
+		}
+	}
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+func (c *Config) validateConnection(v *validator) {
+	conn := c.Connection
+	if conn.Port < 0 || conn.Port > 65535 {
+		v.config("connection.port", "must be between 0 and 65535, got %d", conn.Port)
+	}
+	if conn.DB < 0 {
+		v.config("connection.db", "must not be negative")
+	}
+	if conn.Timeout < 0 {
+		v.config("connection.timeout", "must not be negative")
+	}
+	for i, broker := range conn.Brokers {
+		if !strings.Contains(broker, ":") {
+			v.config(fmt.Sprintf("connection.brokers[%d]", i), "must be host:port, got %q", broker)
+		}
+	}
+
+	rs := conn.Reconnect
+	if rs.MaxAttempts < 0 {
+		v.config("connection.reconnect.max_attempts", "must not be negative")
+	}
+	if rs.InitialBackoff < 0 {
+		v.config("connection.reconnect.initial_backoff", "must not be negative")
+	}
+	if rs.MaxBackoff < 0 {
+		v.config("connection.reconnect.max_backoff", "must not be negative")
+	}
+	if rs.InitialBackoff > 0 && rs.MaxBackoff > 0 && rs.InitialBackoff > rs.MaxBackoff {
+		v.config("connection.reconnect.initial_backoff", "must not be greater than max_backoff")
+	}
+	if rs.Multiplier != 0 && rs.Multiplier < 1 {
+		v.config("connection.reconnect.multiplier", "must be at least 1, got %g", rs.Multiplier)
+	}
+	if rs.Jitter < 0 || rs.Jitter > 1 {
+		v.config("connection.reconnect.jitter", "must be between 0%% and 100%%, got %g", float64(rs.Jitter))
+	}
+}
+
+func (c *Config) validateTest(v *validator) {
+	t := c.Test
+	if t.Duration < 0 {
+		v.config("test.duration", "must not be negative")
+	}
+	if t.Operations < 0 {
+		v.config("test.operations", "must not be negative")
+	}
+	if t.Duration == 0 && t.Operations == 0 {
+		v.config("test", "duration or operations must be set")
+	}
+	if t.Concurrency < 0 {
+		v.config("test.concurrency", "must not be negative")
+	}
+	if t.Rate < 0 {
+		v.config("test.rate", "must not be negative")
+	}
+	if t.Profile != nil {
+		tc := c.GetTestConfig()
+		if t.Rate <= 0 {
+			v.config("test.profile", "requires a positive test.rate")
+		} else if _, err := workload.NewRateProfile(tc.Rate, tc.Profile, tc.Duration); err != nil {
+			v.config("test.profile", "%v", err)
+		}
+	}
+
+	od := t.OutageDetection
+	if od.ConsecutiveFailures < 0 {
+		v.config("test.outage_detection.consecutive_failures", "must not be negative")
+	}
+	if od.ErrorRate < 0 || od.ErrorRate > 1 {
+		v.config("test.outage_detection.error_rate", "must be between 0%% and 100%%, got %g", float64(od.ErrorRate))
+	}
+	if od.Window < 0 {
+		v.config("test.outage_detection.window", "must not be negative")
+	}
+	if od.MinWindowOps < 0 {
+		v.config("test.outage_detection.min_window_ops", "must not be negative")
+	}
+
+	if t.LatencyPrecision < 0 || t.LatencyPrecision > 3 {
+		v.config("test.latency_precision", "must be between 1 and 3, got %d", t.LatencyPrecision)
+	}
+
+	if t.SeriesInterval < 0 {
+		v.config("test.series_interval", "must not be negative")
+	}
+
+	if t.VerifyGrace < 0 {
+		v.config("test.verify_grace", "must not be negative")
+	}
+
+	if len(t.Workload) > 0 && (c.Middleware == "redis" || c.Middleware == "kafka") {
+		if _, err := workload.NewGenerator(c.Middleware, c.GetTestConfig().Workload, 0); err != nil {
+			v.config("test.workload", "%v", err)
+		}
+	}
+}
+
+func (c *Config) validateThresholds(v *validator) {
+	t := c.Thresholds
+
+	checkRatio := func(field string, levels PercentLevels) {
+		values := []Percent{levels.Excellent, levels.Good, levels.Fair, levels.Pass}
+		for i, p := range values {
+			if p < 0 || p > 1 {
+				v.threshold(field+"."+levelNames[i], "must be between 0%% and 100%%, got %g", float64(p))
+			}
+		}
+	}
+	checkRatio("thresholds.availability", t.Availability)
+	checkRatio("thresholds.error_rate", t.ErrorRate)
+
+	// 可用性越高越好：excellent >= good >= fair >= pass
+	checkOrder(v, "thresholds.availability", true,
+		float64(t.Availability.Excellent), float64(t.Availability.Good),
+		float64(t.Availability.Fair), float64(t.Availability.Pass))
+
+	// 错误率和延迟越低越好：excellent <= good <= fair <= pass
+	checkOrder(v, "thresholds.error_rate", false,
+		float64(t.ErrorRate.Excellent), float64(t.ErrorRate.Good),
+		float64(t.ErrorRate.Fair), float64(t.ErrorRate.Pass))
+
+	durations := []struct {
+		field  string
+		levels DurationLevels
+	}{
+		{"thresholds.p95_latency", t.P95Latency},
+		{"thresholds.p99_latency", t.P99Latency},
+		{"thresholds.mttr", t.MTTR},
+		{"thresholds.e2e_p95_latency", t.E2EP95Latency},
+		{"thresholds.e2e_p99_latency", t.E2EP99Latency},
+	}
+	for _, d := range durations {
+		values := []time.Duration{d.levels.Excellent, d.levels.Good, d.levels.Fair, d.levels.Pass}
+		for i, value := range values {
+			if value < 0 {
+				v.threshold(d.field+"."+levelNames[i], "must not be negative")
+			}
+		}
+		checkOrder(v, d.field, false,
+			float64(values[0]), float64(values[1]), float64(values[2]), float64(values[3]))
+	}
+
+	for name, op := range t.Operations {
+		field := "thresholds.operations." + name
+		if canonical, ok := c.operationName(name); !ok {
+			v.threshold(field, "unknown operation %q for middleware %q", name, c.Middleware)
+		} else if canonical != strings.ToLower(name) {
+			v.threshold(field, "operation metrics are reported as %q, use that name instead", canonical)
+		}
+		if op.Availability < 0 || op.Availability > 1 {
+			v.threshold(field+".availability", "must be between 0%% and 100%%, got %g", float64(op.Availability))
+		}
+		if op.ErrorRate < 0 || op.ErrorRate > 1 {
+			v.threshold(field+".error_rate", "must be between 0%% and 100%%, got %g", float64(op.ErrorRate))
+		}
+		if op.P95Latency < 0 {
+			v.threshold(field+".p95_latency", "must not be negative")
+		}
+		if op.P99Latency < 0 {
+			v.threshold(field+".p99_latency", "must not be negative")
+		}
+	}
+}
+
+// operationName 返回阈值中的操作名对应的统计名称（操作类型或该中间件操作的名称）
+// 如Redis的 del 统计为 delete；无法识别时返回false
+func (c *Config) operationName(name string) (string, bool) {
+	switch t := core.OperationType(strings.ToLower(name)); t {
+	case core.OpTypeRead, core.OpTypeWrite, core.OpTypeDelete, core.OpTypeCustom:
+		return string(t), true
+	}
+	op, err := middleware.NewOperation(c.Middleware, name, "", nil)
+	if err != nil {
+		return "", false
+	}
+	return core.OperationName(op), true
+}
+
+// levelNames 分级阈值名称，与 excellent/good/fair/pass 顺序对应
+var levelNames = []string{"excellent", "good", "fair", "pass"}
+
+// checkOrder 检查已配置（非零）的分级阈值顺序
+func checkOrder(v *validator, field string, descending bool, levels ...float64) {
+	prev, prevName := 0.0, ""
+	for i, level := range levels {
+		if level == 0 {
+			continue
+		}
+		if prevName != "" {
+			if descending && level > prev {
+				v.threshold(field, "%s must not be higher than %s", levelNames[i], prevName)
+			}
+			if !descending && level < prev {
+				v.threshold(field, "%s must not be lower than %s", levelNames[i], prevName)
+			}
+		}
+		prev, prevName = level, levelNames[i]
+	}
+}
@@ -1,6 +1,9 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // MiddlewareClient 中间件客户端接口
 // 所有中间件适配器必须实现此接口
@@ -37,4 +40,22 @@ type ClientMetrics struct {
 
 	// FailedConnectionAttempts 失败的连接尝试数
 	FailedConnectionAttempts int64
+
+	// ReconnectAttempts 自动重连尝试数
+	ReconnectAttempts int64
+
+	// SuccessfulReconnects 成功的自动重连数
+	SuccessfulReconnects int64
+}
+
+// ReconnectObserver 重连观察者
+// 支持自动重连的客户端在每次重连尝试结束后回调，MetricsCollector 实现此接口
+type ReconnectObserver interface {
+	RecordReconnectAttempt(success bool, duration time.Duration)
+}
+
+// Reconnectable 支持自动重连的客户端
+// 编排器在运行前将指标收集器注册为观察者
+type Reconnectable interface {
+	SetReconnectObserver(observer ReconnectObserver)
 }
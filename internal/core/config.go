@@ -44,8 +44,41 @@ type TestConfig struct {
 	Operations  int              // 操作次数
 	Concurrency int              // 并发数
 	Workload    []WorkloadConfig // 工作负载配置
+
+	// Rate 开环模式的固定到达速率 (ops/s)，0表示闭环（按 Duration/Operations 节奏、同步执行）
+	// 开环模式下延迟从计划发送时间开始计算，服务端停顿期间排队的时间也计入延迟
+	Rate float64
+
+	// Profile 开环模式的目标速率曲线，以 Rate 为基准；nil表示恒定速率
+	Profile *LoadProfile
 }
 
+// 负载曲线形状
+const (
+	ProfileRamp  = "ramp"  // 从 StartRate 线性增加到 Rate，之后保持
+	ProfileStep  = "step"  // 从 StartRate 开始每隔 StepTime 增加 StepRate，不超过 Rate
+	ProfileSpike = "spike" // 保持 Rate，在 SpikeAt 开始的 SpikeTime 内突增到 SpikeRate，Period>0时周期重复
+	ProfileSine  = "sine"  // 以 Rate 为均值、Amplitude 为振幅、Period 为周期的正弦波
+)
+
+// LoadProfile 目标速率曲线，时间均为扣除暂停后的运行时长
+type LoadProfile struct {
+	Shape string // ramp, step, spike, sine
+
+	StartRate float64       // ramp/step: 起始速率 (ops/s)
+	RampTime  time.Duration // ramp: 爬坡时长，0表示整个测试时长
+	StepRate  float64       // step: 每级增加的速率 (ops/s)
+	StepTime  time.Duration // step: 每级持续时长
+	SpikeRate float64       // spike: 尖峰期间的速率 (ops/s)
+	SpikeAt   time.Duration // spike: 首次尖峰开始时间
+	SpikeTime time.Duration // spike: 尖峰持续时长
+	Amplitude float64       // sine: 振幅 (ops/s)，低谷处速率不低于0
+	Period    time.Duration // sine: 周期；spike: 尖峰重复周期，0表示只有一次
+}
+
+// LateStartThreshold 开环模式下操作开始执行时落后计划超过该值即计为落后
+const LateStartThreshold = 10 * time.Millisecond
+
 // WorkloadConfig 工作负载配置
 type WorkloadConfig struct {
 	Operation  string // 操作类型
@@ -58,5 +91,7 @@ type WorkloadConfig struct {
 type OutputConfig struct {
 	Format                 string // console, json, markdown
 	Path                   string // 报告保存路径
+	Template               string // 自定义报告模板文件
+	Lang                   string // 报告语言：zh-CN, en
 	IncludeRecommendations bool   // 是否包含建议
 }
@@ -0,0 +1,38 @@
+package core
+
+// ConsistencyReport 数据一致性校验统计
+type ConsistencyReport struct {
+	AckedWrites     int64 // 已确认的写入数
+	VerifiedReads   int64 // 参与校验的读取数
+	ConsistentReads int64 // 读到最新已确认值（或并发写入值）的读取数
+	StaleReads      int64 // 读到已被覆盖的旧值
+	LostWrites      int64 // 已确认但读取时不存在的写入（按写入去重）
+	CorruptedReads  int64 // 读到从未写入过的值
+}
+
+// Inconsistencies 不一致的读取数
+func (r *ConsistencyReport) Inconsistencies() int64 {
+	return r.VerifiedReads - r.ConsistentReads
+}
+
+// Apply 将校验结果写入稳定性指标
+// DataConsistency = 一致读取/校验读取，DataLossRate = 丢失写入/已确认写入
+func (r *ConsistencyReport) Apply(metrics *StabilityMetrics) {
+	report := *r
+	metrics.Consistency = &report
+
+	metrics.DataConsistency = 1
+	if r.VerifiedReads > 0 {
+		metrics.DataConsistency = float64(r.ConsistentReads) / float64(r.VerifiedReads)
+	}
+	metrics.DataLossRate = 0
+	if r.AckedWrites > 0 {
+		metrics.DataLossRate = float64(r.LostWrites) / float64(r.AckedWrites)
+	}
+}
+
+// ConsistencyVerifier 支持数据一致性校验的客户端
+// 未启用校验时返回nil
+type ConsistencyVerifier interface {
+	ConsistencyReport() *ConsistencyReport
+}
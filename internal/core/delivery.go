@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// LatencySummary 延迟分布摘要
+type LatencySummary struct {
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// DeliveryReport 消息端到端投递校验统计
+type DeliveryReport struct {
+	Produced   int64 // 发送的消息数
+	Acked      int64 // 已确认的消息数
+	Received   int64 // 收到的消息数（去重后）
+	Lost       int64 // 已确认但在宽限期内仍未收到的消息数
+	Duplicates int64 // 重复收到的消息数
+	OutOfOrder int64 // 乱序收到的消息数（同一分区内晚于其后发送的消息到达）
+
+	ProduceLatency  LatencySummary // 发送到确认的延迟（已确认的消息）
+	EndToEndLatency LatencySummary // 发送到被消费的延迟（首次收到的消息）
+}
+
+// Apply 将校验结果写入稳定性指标
+// DataLossRate = 丢失/已确认，DuplicateRate = 重复/全部投递，OutOfOrderRate = 乱序/收到，
+// ConsumerLag 取端到端延迟P95
+func (r *DeliveryReport) Apply(metrics *StabilityMetrics) {
+	report := *r
+	metrics.Delivery = &report
+
+	metrics.DataLossRate = 0
+	if r.Acked > 0 {
+		metrics.DataLossRate = float64(r.Lost) / float64(r.Acked)
+	}
+	metrics.DuplicateMessages = r.Duplicates
+	metrics.DuplicateRate = 0
+	if deliveries := r.Received + r.Duplicates; deliveries > 0 {
+		metrics.DuplicateRate = float64(r.Duplicates) / float64(deliveries)
+	}
+	metrics.OutOfOrderRate = 0
+	if r.Received > 0 {
+		metrics.OutOfOrderRate = float64(r.OutOfOrder) / float64(r.Received)
+	}
+	metrics.ConsumerLag = r.EndToEndLatency.P95
+}
+
+// DeliveryVerifier 支持消息端到端投递校验的客户端
+// DeliveryReport 在测试结束时调用，会等待迟到的消息直到全部收到、宽限期结束或ctx取消；
+// 未启用校验时返回nil
+type DeliveryVerifier interface {
+	DeliveryReport(ctx context.Context) *DeliveryReport
+}
@@ -24,4 +24,7 @@ var (
 
 	// ErrInvalidMetrics 无效的指标数据
 	ErrInvalidMetrics = errors.New("invalid metrics")
+
+	// ErrDataInconsistent 读取结果与已确认的写入不一致（丢失、过期或损坏）
+	ErrDataInconsistent = errors.New("data inconsistent")
 )
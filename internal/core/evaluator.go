@@ -89,6 +89,7 @@ type Issue struct {
 
 // Recommendation 改进建议
 type Recommendation struct {
+	Code       string   // 建议代码，如 improve_availability，不随报告语言变化
 	Priority   string   // 优先级: HIGH, MEDIUM, LOW
 	Category   string   // 类别: CONFIGURATION, SCALING, OPTIMIZATION
 	Title      string   // 标题
@@ -128,4 +129,27 @@ type Thresholds struct {
 	MTTRGood      time.Duration // <= 30s
 	MTTRFair      time.Duration // <= 60s
 	MTTRPass      time.Duration // <= 300s
+
+	// 端到端延迟阈值（Kafka：发送到被消费的延迟）
+	E2EP95LatencyExcellent time.Duration // <= 50ms
+	E2EP95LatencyGood      time.Duration // <= 100ms
+	E2EP95LatencyFair      time.Duration // <= 250ms
+	E2EP95LatencyPass      time.Duration // <= 500ms
+
+	E2EP99LatencyExcellent time.Duration // <= 100ms
+	E2EP99LatencyGood      time.Duration // <= 250ms
+	E2EP99LatencyFair      time.Duration // <= 500ms
+	E2EP99LatencyPass      time.Duration // <= 1s
+
+	// Operations 按操作名称或操作类型（read/write/delete/custom）配置的阈值，
+	// 名称优先；每个操作单独检查
+	Operations map[string]OperationThresholds
+}
+
+// OperationThresholds 单一操作的阈值，零值表示不检查
+type OperationThresholds struct {
+	Availability float64       // 最低可用性
+	ErrorRate    float64       // 最高错误率
+	P95Latency   time.Duration // 最高P95延迟
+	P99Latency   time.Duration // 最高P99延迟
 }
@@ -0,0 +1,34 @@
+package core
+
+import "time"
+
+// EventType 时间线事件类型
+type EventType string
+
+const (
+	// EventPhaseStart 阶段开始
+	EventPhaseStart EventType = "phase_start"
+	// EventPhaseEnd 阶段结束
+	EventPhaseEnd EventType = "phase_end"
+	// EventFaultStart 故障开始
+	EventFaultStart EventType = "fault_start"
+	// EventFaultStop 故障结束
+	EventFaultStop EventType = "fault_stop"
+	// EventFaultInject 瞬时故障（如重置所有连接）
+	EventFaultInject EventType = "fault_inject"
+)
+
+// Event 测试时间线事件
+type Event struct {
+	Time    time.Time     // 发生时间
+	Offset  time.Duration // 相对测试开始的时间
+	Type    EventType     // 事件类型
+	Phase   string        // 所属阶段
+	Fault   string        // 故障描述
+	Message string        // 附加说明
+}
+
+// IsFault 是否为故障事件
+func (e Event) IsFault() bool {
+	return e.Type == EventFaultStart || e.Type == EventFaultStop || e.Type == EventFaultInject
+}
@@ -73,6 +73,8 @@ func (m *MockMetricsCollector) RecordOperation(result *Result) {}
 
 func (m *MockMetricsCollector) RecordConnectionAttempt(success bool, duration time.Duration) {}
 
+func (m *MockMetricsCollector) RecordReconnectAttempt(success bool, duration time.Duration) {}
+
 func (m *MockMetricsCollector) RecordError(err error, errorType ErrorType) {}
 
 func (m *MockMetricsCollector) GetMetrics() *StabilityMetrics {
@@ -26,6 +26,9 @@ type MetricsCollector interface {
 	// RecordConnectionAttempt 记录连接尝试
 	RecordConnectionAttempt(success bool, duration time.Duration)
 
+	// RecordReconnectAttempt 记录客户端的一次自动重连尝试
+	RecordReconnectAttempt(success bool, duration time.Duration)
+
 	// RecordError 记录错误
 	RecordError(err error, errorType ErrorType)
 
@@ -50,13 +53,21 @@ type StabilityMetrics struct {
 	ConnectionSuccessRate        float64 // 连接成功率
 
 	// 性能指标
-	P50Latency time.Duration // P50延迟
-	P95Latency time.Duration // P95延迟
-	P99Latency time.Duration // P99延迟
-	AvgLatency time.Duration // 平均延迟
-	MaxLatency time.Duration // 最大延迟
-	MinLatency time.Duration // 最小延迟
-	Throughput float64       // 吞吐量 (ops/s)
+	P50Latency    time.Duration // P50延迟
+	P95Latency    time.Duration // P95延迟
+	P99Latency    time.Duration // P99延迟
+	P999Latency   time.Duration // P99.9延迟
+	P9999Latency  time.Duration // P99.99延迟
+	AvgLatency    time.Duration // 平均延迟
+	MaxLatency    time.Duration // 最大延迟
+	MinLatency    time.Duration // 最小延迟
+	StdDevLatency time.Duration // 延迟标准差
+	Throughput    float64       // 吞吐量 (ops/s)
+
+	// 开环负载（TargetRate为0表示闭环）
+	TargetRate     float64       // 平均目标到达速率 (ops/s)，使用负载曲线时为整个运行期间的平均值
+	LateOperations int64         // 开始执行时落后计划超过 LateStartThreshold 的操作数
+	MaxScheduleLag time.Duration // 最大调度落后时间（开始执行时间-计划发送时间）
 
 	// 可靠性指标
 	ErrorRate       float64 // 错误率
@@ -64,9 +75,16 @@ type StabilityMetrics struct {
 	DataConsistency float64 // 数据一致性
 	DuplicateRate   float64 // 重复率
 
+	// Consistency 数据一致性校验明细，未启用校验时为nil
+	Consistency *ConsistencyReport
+
 	// 恢复性指标
-	MTBF                   time.Duration // 平均故障间隔时间
-	MTTR                   time.Duration // 平均恢复时间
+	MTBF                   time.Duration // 平均故障间隔时间（正常运行时间/故障次数）
+	MTTR                   time.Duration // 平均恢复时间（仅统计已恢复的故障）
+	OutageCount            int64         // 故障次数
+	LongestOutage          time.Duration // 最长故障时间
+	TotalDowntime          time.Duration // 累计故障时间
+	Outages                []Outage      // 故障窗口明细
 	TotalReconnectAttempts int64         // 重连尝试次数
 	SuccessfulReconnects   int64         // 成功重连次数
 	ReconnectSuccessRate   float64       // 重连成功率
@@ -74,11 +92,21 @@ type StabilityMetrics struct {
 	// 错误统计
 	ErrorsByType map[ErrorType]int64 // 按类型分类的错误数
 
+	// Operations 按操作名称分类的指标，键为操作名称（未命名的操作为操作类型）
+	Operations map[string]*OperationMetrics
+
 	// 时间相关
 	StartTime time.Time     // 测试开始时间
 	EndTime   time.Time     // 测试结束时间
 	Duration  time.Duration // 测试持续时间
 
+	// 时间线事件（阶段切换、故障注入），按时间排序
+	Events []Event
+
+	// Series 按固定区间划分的指标时间序列，覆盖整个测试，没有操作的区间也会列出
+	Series         []IntervalMetrics
+	SeriesInterval time.Duration // 时间序列的区间长度
+
 	// 中间件特定指标（可选）
 	// Redis
 	CacheHitRate        float64 // 缓存命中率
@@ -87,9 +115,77 @@ type StabilityMetrics struct {
 
 	// Kafka
 	MessageLag        int64         // 消息积压
-	ConsumerLag       time.Duration // 消费延迟
+	ConsumerLag       time.Duration // 消费延迟（端到端延迟P95）
 	DuplicateMessages int64         // 重复消息数
+	OutOfOrderRate    float64       // 乱序率
 	RebalanceCount    int64         // 重平衡次数
+
+	// Delivery 消息投递校验明细，未启用校验时为nil
+	Delivery *DeliveryReport
+}
+
+// Outage 检测到的故障窗口
+type Outage struct {
+	Start     time.Time     // 故障开始时间（首个失败操作的开始时间）
+	End       time.Time     // 故障结束时间（恢复后首个成功操作的完成时间）
+	Duration  time.Duration // 故障持续时间
+	Failures  int64         // 故障期间的失败操作数
+	Recovered bool          // 测试结束前是否已恢复
+}
+
+// OperationMetrics 单一操作的指标
+type OperationMetrics struct {
+	Name         string              // 操作名称
+	Type         OperationType       // 操作类型
+	Operations   int64               // 操作数
+	Successes    int64               // 成功操作数
+	Failures     int64               // 失败操作数
+	Availability float64             // 成功率
+	ErrorRate    float64             // 错误率
+	Throughput   float64             // 吞吐量 (ops/s)
+	ErrorsByType map[ErrorType]int64 // 按类型分类的错误数
+	P50Latency   time.Duration       // P50延迟
+	P95Latency   time.Duration       // P95延迟
+	P99Latency   time.Duration       // P99延迟
+	P999Latency  time.Duration       // P99.9延迟
+	AvgLatency   time.Duration       // 平均延迟
+	MaxLatency   time.Duration       // 最大延迟
+}
+
+// IntervalMetrics 一个时间区间内的指标，操作按完成时间计入区间
+type IntervalMetrics struct {
+	Start        time.Time           // 区间开始时间
+	Duration     time.Duration       // 区间长度
+	Operations   int64               // 操作数
+	Successes    int64               // 成功操作数
+	Failures     int64               // 失败操作数
+	ErrorsByType map[ErrorType]int64 // 按类型分类的错误数，没有失败时为nil
+	P50Latency   time.Duration       // P50延迟
+	P95Latency   time.Duration       // P95延迟
+	P99Latency   time.Duration       // P99延迟
+	MaxLatency   time.Duration       // 最大延迟
+	TargetRate   float64             // 开环模式下区间内的平均目标速率 (ops/s)，闭环为0
+}
+
+// Availability 区间内的成功率，没有操作时返回0
+func (im IntervalMetrics) Availability() float64 {
+	if im.Operations == 0 {
+		return 0
+	}
+	return float64(im.Successes) / float64(im.Operations)
+}
+
+// Throughput 区间内的吞吐量 (ops/s)
+func (im IntervalMetrics) Throughput() float64 {
+	if im.Duration <= 0 {
+		return 0
+	}
+	return float64(im.Operations) / im.Duration.Seconds()
+}
+
+// HasUnrecoveredOutage 测试结束时是否仍处于故障状态
+func (sm *StabilityMetrics) HasUnrecoveredOutage() bool {
+	return len(sm.Outages) > 0 && !sm.Outages[len(sm.Outages)-1].Recovered
 }
 
 // Clone 克隆指标（用于并发安全读取）
@@ -101,5 +197,45 @@ func (sm *StabilityMetrics) Clone() *StabilityMetrics {
 			clone.ErrorsByType[k] = v
 		}
 	}
+	if sm.Operations != nil {
+		clone.Operations = make(map[string]*OperationMetrics, len(sm.Operations))
+		for name, om := range sm.Operations {
+			opClone := *om
+			if om.ErrorsByType != nil {
+				opClone.ErrorsByType = make(map[ErrorType]int64, len(om.ErrorsByType))
+				for k, v := range om.ErrorsByType {
+					opClone.ErrorsByType[k] = v
+				}
+			}
+			clone.Operations[name] = &opClone
+		}
+	}
+	if sm.Outages != nil {
+		clone.Outages = append([]Outage(nil), sm.Outages...)
+	}
+	if sm.Events != nil {
+		clone.Events = append([]Event(nil), sm.Events...)
+	}
+	if sm.Series != nil {
+		clone.Series = make([]IntervalMetrics, len(sm.Series))
+		for i, im := range sm.Series {
+			if im.ErrorsByType != nil {
+				errors := make(map[ErrorType]int64, len(im.ErrorsByType))
+				for k, v := range im.ErrorsByType {
+					errors[k] = v
+				}
+				im.ErrorsByType = errors
+			}
+			clone.Series[i] = im
+		}
+	}
+	if sm.Consistency != nil {
+		consistency := *sm.Consistency
+		clone.Consistency = &consistency
+	}
+	if sm.Delivery != nil {
+		delivery := *sm.Delivery
+		clone.Delivery = &delivery
+	}
 	return &clone
 }
@@ -28,3 +28,19 @@ type Operation interface {
 	// Metadata 返回操作的元数据
 	Metadata() map[string]interface{}
 }
+
+// NamedOperation 具有名称的操作（如 set、produce），指标按名称分类统计
+type NamedOperation interface {
+	Operation
+
+	// Name 返回操作名称
+	Name() string
+}
+
+// OperationName 返回操作的名称，未实现 NamedOperation 时使用操作类型
+func OperationName(op Operation) string {
+	if named, ok := op.(NamedOperation); ok && named.Name() != "" {
+		return named.Name()
+	}
+	return string(op.Type())
+}
@@ -32,4 +32,5 @@ type OrchestratorStatus struct {
 	Progress    float64       // 进度 0-1
 	ElapsedTime time.Duration // 已运行时间
 	Operations  int64         // 已完成操作数
+	Phase       string        // 当前场景阶段（未使用场景时为空）
 }
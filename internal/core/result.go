@@ -21,6 +21,10 @@ type Result struct {
 
 	// Timestamp 操作完成时间
 	Timestamp time.Time
+
+	// Operation 操作名称，OpType 操作类型，由执行者填写，用于按操作分类统计
+	Operation string
+	OpType    OperationType
 }
 
 // NewResult 创建新的结果
@@ -0,0 +1,31 @@
+package evaluator
+
+import (
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// EvaluateMiddleware 按中间件类型评估：Kafka以 KafkaThresholds 为基准并使用 EvaluateKafka，
+// Redis使用 EvaluateRedis，其他中间件使用默认阈值；thresholds 中的非零值覆盖基准阈值，
+// 问题、建议和判断依据使用 lang 语言
+func EvaluateMiddleware(
+	middlewareType string,
+	thresholds *core.Thresholds,
+	lang i18n.Lang,
+	metrics *core.StabilityMetrics,
+) *core.EvaluationResult {
+	if middlewareType == "kafka" {
+		thresholds = MergeThresholds(KafkaThresholds(), thresholds)
+	}
+	se := NewStabilityEvaluator(thresholds)
+	se.SetLang(lang)
+
+	switch middlewareType {
+	case "kafka":
+		return se.EvaluateKafka(metrics)
+	case "redis":
+		return se.EvaluateRedis(metrics)
+	default:
+		return se.Evaluate(metrics)
+	}
+}
@@ -7,19 +7,27 @@ import (
 	"time"
 
 	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
 )
 
 // StabilityEvaluator 稳定性评估器
 type StabilityEvaluator struct {
 	thresholds *core.Thresholds
+	lang       i18n.Lang // 问题、建议和判断依据的语言，为空时使用默认语言
 }
 
 // NewStabilityEvaluator 创建新的稳定性评估器
 func NewStabilityEvaluator(thresholds *core.Thresholds) *StabilityEvaluator {
-	// 从默认阈值开始
-	finalThresholds := DefaultThresholds()
+	// 从默认阈值开始，如果提供了自定义阈值，合并非零值
+	return &StabilityEvaluator{
+		thresholds: MergeThresholds(DefaultThresholds(), thresholds),
+	}
+}
+
+// MergeThresholds 以base为基础合并thresholds中的非零值，返回新的阈值
+func MergeThresholds(base, thresholds *core.Thresholds) *core.Thresholds {
+	finalThresholds := *base
 
-	// 如果提供了自定义阈值，合并非零值
 	if thresholds != nil {
 		if thresholds.AvailabilityExcellent > 0 {
 			finalThresholds.AvailabilityExcellent = thresholds.AvailabilityExcellent
@@ -85,11 +93,47 @@ func NewStabilityEvaluator(thresholds *core.Thresholds) *StabilityEvaluator {
 		if thresholds.MTTRPass > 0 {
 			finalThresholds.MTTRPass = thresholds.MTTRPass
 		}
-	}
 
-	return &StabilityEvaluator{
-		thresholds: finalThresholds,
+		if thresholds.E2EP95LatencyExcellent > 0 {
+			finalThresholds.E2EP95LatencyExcellent = thresholds.E2EP95LatencyExcellent
+		}
+		if thresholds.E2EP95LatencyGood > 0 {
+			finalThresholds.E2EP95LatencyGood = thresholds.E2EP95LatencyGood
+		}
+		if thresholds.E2EP95LatencyFair > 0 {
+			finalThresholds.E2EP95LatencyFair = thresholds.E2EP95LatencyFair
+		}
+		if thresholds.E2EP95LatencyPass > 0 {
+			finalThresholds.E2EP95LatencyPass = thresholds.E2EP95LatencyPass
+		}
+
+		if thresholds.E2EP99LatencyExcellent > 0 {
+			finalThresholds.E2EP99LatencyExcellent = thresholds.E2EP99LatencyExcellent
+		}
+		if thresholds.E2EP99LatencyGood > 0 {
+			finalThresholds.E2EP99LatencyGood = thresholds.E2EP99LatencyGood
+		}
+		if thresholds.E2EP99LatencyFair > 0 {
+			finalThresholds.E2EP99LatencyFair = thresholds.E2EP99LatencyFair
+		}
+		if thresholds.E2EP99LatencyPass > 0 {
+			finalThresholds.E2EP99LatencyPass = thresholds.E2EP99LatencyPass
+		}
+
+		// 按操作的阈值按键合并，同名的整组替换
+		if len(thresholds.Operations) > 0 {
+			operations := make(map[string]core.OperationThresholds, len(base.Operations)+len(thresholds.Operations))
+			for name, t := range base.Operations {
+				operations[name] = t
+			}
+			for name, t := range thresholds.Operations {
+				operations[name] = t
+			}
+			finalThresholds.Operations = operations
+		}
 	}
+
+	return &finalThresholds
 }
 
 // DefaultThresholds 返回默认阈值（适用于Redis等低延迟中间件）
@@ -119,6 +163,16 @@ func DefaultThresholds() *core.Thresholds {
 		MTTRGood:      30 * time.Second,
 		MTTRFair:      60 * time.Second,
 		MTTRPass:      300 * time.Second,
+
+		E2EP95LatencyExcellent: 50 * time.Millisecond,
+		E2EP95LatencyGood:      100 * time.Millisecond,
+		E2EP95LatencyFair:      250 * time.Millisecond,
+		E2EP95LatencyPass:      500 * time.Millisecond,
+
+		E2EP99LatencyExcellent: 100 * time.Millisecond,
+		E2EP99LatencyGood:      250 * time.Millisecond,
+		E2EP99LatencyFair:      500 * time.Millisecond,
+		E2EP99LatencyPass:      time.Second,
 	}
 }
 
@@ -163,6 +217,17 @@ func KafkaThresholds() *core.Thresholds {
 		MTTRGood:      15 * time.Second,  // 包含重试
 		MTTRFair:      30 * time.Second,  // 可能触发重平衡
 		MTTRPass:      60 * time.Second,  // 需要手动介入
+
+		// 端到端延迟（发送到被消费）：包含生产批处理、复制和消费者拉取等待（MaxWait）
+		E2EP95LatencyExcellent: 50 * time.Millisecond,
+		E2EP95LatencyGood:      100 * time.Millisecond,
+		E2EP95LatencyFair:      250 * time.Millisecond,
+		E2EP95LatencyPass:      500 * time.Millisecond,
+
+		E2EP99LatencyExcellent: 100 * time.Millisecond,
+		E2EP99LatencyGood:      250 * time.Millisecond,
+		E2EP99LatencyFair:      500 * time.Millisecond,
+		E2EP99LatencyPass:      time.Second,
 	}
 }
 
@@ -179,7 +244,17 @@ func (se *StabilityEvaluator) Evaluate(metrics *core.StabilityMetrics) *core.Eva
 	result.Scores.Performance = se.calculatePerformanceScore(metrics, result)
 	result.Scores.Reliability = se.calculateReliabilityScore(metrics, result)
 	result.Scores.Resilience = se.calculateResilienceScore(metrics, result)
+	se.checkErrorTypes(metrics, result)
+	se.checkOperations(metrics, result)
+	se.checkSchedule(metrics, result)
+
+	se.finalize(metrics, result)
+	return result
+}
 
+// finalize 根据各维度得分和问题计算总分、等级、状态、建议和判断依据
+// 中间件特定评估调整得分或追加问题后需重新调用
+func (se *StabilityEvaluator) finalize(metrics *core.StabilityMetrics, result *core.EvaluationResult) {
 	// 计算总分（直接相加，各维度满分分别是30/25/25/20）
 	result.Score = result.Scores.Availability +
 		result.Scores.Performance +
@@ -192,9 +267,7 @@ func (se *StabilityEvaluator) Evaluate(metrics *core.StabilityMetrics) *core.Eva
 
 	// 生成建议和判断依据
 	result.Recommendations = se.generateRecommendations(result)
-	result.Rationale = se.generateRationale(result)
-
-	return result
+	result.Rationale = se.generateRationale(metrics, result)
 }
 
 // calculateAvailabilityScore 计算可用性得分 (满分30分)
@@ -222,7 +295,7 @@ func (se *StabilityEvaluator) calculateAvailabilityScore(
 			Metric:   "availability",
 			Current:  availability * 100,
 			Expected: se.thresholds.AvailabilityPass * 100,
-			Message: fmt.Sprintf("可用性%.2f%%低于最低要求%.2f%%",
+			Message: se.lang.T("issue.low_availability",
 				availability*100,
 				se.thresholds.AvailabilityPass*100),
 		})
@@ -258,7 +331,7 @@ func (se *StabilityEvaluator) calculatePerformanceScore(
 			Metric:   "p95_latency",
 			Current:  float64(p95.Milliseconds()),
 			Expected: float64(se.thresholds.P95LatencyPass.Milliseconds()),
-			Message:  fmt.Sprintf("P95延迟%v超过阈值%v", p95, se.thresholds.P95LatencyPass),
+			Message:  se.lang.T("issue.high_p95_latency", p95, se.thresholds.P95LatencyPass),
 		})
 	}
 
@@ -281,7 +354,7 @@ func (se *StabilityEvaluator) calculatePerformanceScore(
 			Metric:   "p99_latency",
 			Current:  float64(p99.Milliseconds()),
 			Expected: float64(se.thresholds.P99LatencyPass.Milliseconds()),
-			Message:  fmt.Sprintf("P99延迟%v超过阈值%v", p99, se.thresholds.P99LatencyPass),
+			Message:  se.lang.T("issue.high_p99_latency", p99, se.thresholds.P99LatencyPass),
 		})
 	}
 
@@ -315,7 +388,7 @@ func (se *StabilityEvaluator) calculateReliabilityScore(
 			Metric:   "error_rate",
 			Current:  errorRate * 100,
 			Expected: se.thresholds.ErrorRatePass * 100,
-			Message: fmt.Sprintf("错误率%.4f%%超过阈值%.2f%%",
+			Message: se.lang.T("issue.high_error_rate",
 				errorRate*100,
 				se.thresholds.ErrorRatePass*100),
 		})
@@ -338,13 +411,166 @@ func (se *StabilityEvaluator) calculateReliabilityScore(
 			Metric:   "data_loss_rate",
 			Current:  dataLossRate * 100,
 			Expected: 0,
-			Message:  fmt.Sprintf("检测到数据丢失，丢失率%.4f%%", dataLossRate*100),
+			Message:  se.lang.T("issue.data_loss_detected", dataLossRate*100),
 		})
 	}
 
 	return errorScore + lossScore
 }
 
+// checkErrorTypes 根据错误分类生成针对性的问题（不影响得分）
+// 认证失败无法通过重试恢复，出现即判定为CRITICAL；网络和超时错误占比超过Fair阈值时报告
+func (se *StabilityEvaluator) checkErrorTypes(
+	metrics *core.StabilityMetrics,
+	result *core.EvaluationResult,
+) {
+	if metrics.TotalOperations == 0 || len(metrics.ErrorsByType) == 0 {
+		return
+	}
+	total := float64(metrics.TotalOperations)
+
+	if n := metrics.ErrorsByType[core.ErrorTypeAuthentication]; n > 0 {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "authentication_failures",
+			Severity: "CRITICAL",
+			Metric:   "errors_by_type.authentication",
+			Current:  float64(n),
+			Expected: 0,
+			Message:  se.lang.T("issue.authentication_failures", n),
+		})
+	}
+
+	if n := metrics.ErrorsByType[core.ErrorTypeNetwork]; float64(n)/total > se.thresholds.ErrorRateFair {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "network_errors",
+			Severity: "HIGH",
+			Metric:   "errors_by_type.network",
+			Current:  float64(n) / total * 100,
+			Expected: se.thresholds.ErrorRateFair * 100,
+			Message:  se.lang.T("issue.network_errors", n, float64(n)/total*100),
+		})
+	}
+
+	if n := metrics.ErrorsByType[core.ErrorTypeTimeout]; float64(n)/total > se.thresholds.ErrorRateFair {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "timeout_errors",
+			Severity: "MEDIUM",
+			Metric:   "errors_by_type.timeout",
+			Current:  float64(n) / total * 100,
+			Expected: se.thresholds.ErrorRateFair * 100,
+			Message:  se.lang.T("issue.timeout_errors", n, float64(n)/total*100),
+		})
+	}
+
+	if n := metrics.ErrorsByType[core.ErrorTypeDataLoss]; n > 0 {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "data_loss_errors",
+			Severity: "HIGH",
+			Metric:   "errors_by_type.data_loss",
+			Current:  float64(n),
+			Expected: 0,
+			Message:  se.lang.T("issue.data_loss_errors", n),
+		})
+	}
+}
+
+// checkOperations 按操作阈值逐个检查各操作（不影响得分）
+// 阈值按操作名称查找，未配置时使用操作类型的阈值
+func (se *StabilityEvaluator) checkOperations(
+	metrics *core.StabilityMetrics,
+	result *core.EvaluationResult,
+) {
+	if len(se.thresholds.Operations) == 0 {
+		return
+	}
+
+	for _, name := range sortedOperationNames(metrics.Operations) {
+		op := metrics.Operations[name]
+		t, ok := se.thresholds.Operations[name]
+		if !ok {
+			t, ok = se.thresholds.Operations[string(op.Type)]
+		}
+		if !ok || op.Operations == 0 {
+			continue
+		}
+
+		if t.Availability > 0 && op.Availability < t.Availability {
+			result.Issues = append(result.Issues, core.Issue{
+				Type:     "operation_low_availability",
+				Severity: "HIGH",
+				Metric:   "operations." + name + ".availability",
+				Current:  op.Availability * 100,
+				Expected: t.Availability * 100,
+				Message:  se.lang.T("issue.operation_low_availability", name, op.Availability*100, t.Availability*100),
+			})
+		}
+		if t.ErrorRate > 0 && op.ErrorRate > t.ErrorRate {
+			result.Issues = append(result.Issues, core.Issue{
+				Type:     "operation_high_error_rate",
+				Severity: "HIGH",
+				Metric:   "operations." + name + ".error_rate",
+				Current:  op.ErrorRate * 100,
+				Expected: t.ErrorRate * 100,
+				Message:  se.lang.T("issue.operation_high_error_rate", name, op.ErrorRate*100, t.ErrorRate*100),
+			})
+		}
+		if t.P95Latency > 0 && op.P95Latency > t.P95Latency {
+			result.Issues = append(result.Issues, core.Issue{
+				Type:     "operation_high_latency",
+				Severity: "MEDIUM",
+				Metric:   "operations." + name + ".p95_latency",
+				Current:  float64(op.P95Latency.Milliseconds()),
+				Expected: float64(t.P95Latency.Milliseconds()),
+				Message:  se.lang.T("issue.operation_high_latency.p95", name, op.P95Latency.Round(time.Millisecond), t.P95Latency),
+			})
+		}
+		if t.P99Latency > 0 && op.P99Latency > t.P99Latency {
+			result.Issues = append(result.Issues, core.Issue{
+				Type:     "operation_high_latency",
+				Severity: "MEDIUM",
+				Metric:   "operations." + name + ".p99_latency",
+				Current:  float64(op.P99Latency.Milliseconds()),
+				Expected: float64(t.P99Latency.Milliseconds()),
+				Message:  se.lang.T("issue.operation_high_latency.p99", name, op.P99Latency.Round(time.Millisecond), t.P99Latency),
+			})
+		}
+	}
+}
+
+// checkSchedule 检查开环负载是否按计划发送（不影响得分）
+// 超过1%的操作开始执行时落后计划，说明worker不足以维持目标速率，实际发送速率低于目标
+func (se *StabilityEvaluator) checkSchedule(
+	metrics *core.StabilityMetrics,
+	result *core.EvaluationResult,
+) {
+	if metrics.TargetRate <= 0 || metrics.TotalOperations == 0 {
+		return
+	}
+	lateRate := float64(metrics.LateOperations) / float64(metrics.TotalOperations)
+	if lateRate <= 0.01 {
+		return
+	}
+	result.Issues = append(result.Issues, core.Issue{
+		Type:     "load_generator_behind",
+		Severity: "MEDIUM",
+		Metric:   "late_operations",
+		Current:  lateRate * 100,
+		Expected: 1,
+		Message: se.lang.T("issue.load_generator_behind",
+			lateRate*100, metrics.MaxScheduleLag.Round(time.Millisecond), metrics.TargetRate, metrics.Throughput),
+	})
+}
+
+// sortedOperationNames 按名称排序的操作列表
+func sortedOperationNames(operations map[string]*core.OperationMetrics) []string {
+	names := make([]string, 0, len(operations))
+	for name := range operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // calculateResilienceScore 计算恢复力得分 (满分20分)
 func (se *StabilityEvaluator) calculateResilienceScore(
 	metrics *core.StabilityMetrics,
@@ -356,6 +582,18 @@ func (se *StabilityEvaluator) calculateResilienceScore(
 	// 恢复时间得分 (12分)
 	var mttrScore float64
 	switch {
+	case metrics.HasUnrecoveredOutage():
+		// 测试结束时仍处于故障状态，无法恢复
+		last := metrics.Outages[len(metrics.Outages)-1]
+		mttrScore = 0
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "unrecovered_outage",
+			Severity: "CRITICAL",
+			Metric:   "mttr",
+			Current:  last.Duration.Seconds(),
+			Expected: se.thresholds.MTTRPass.Seconds(),
+			Message:  se.lang.T("issue.unrecovered_outage", last.Duration.Round(time.Millisecond)),
+		})
 	case mttr <= se.thresholds.MTTRExcellent:
 		mttrScore = 12.0
 	case mttr <= se.thresholds.MTTRGood:
@@ -372,7 +610,7 @@ func (se *StabilityEvaluator) calculateResilienceScore(
 			Metric:   "mttr",
 			Current:  float64(mttr.Seconds()),
 			Expected: float64(se.thresholds.MTTRPass.Seconds()),
-			Message:  fmt.Sprintf("平均恢复时间%v超过阈值%v", mttr, se.thresholds.MTTRPass),
+			Message:  se.lang.T("issue.slow_recovery", mttr, se.thresholds.MTTRPass),
 		})
 	}
 
@@ -393,7 +631,7 @@ func (se *StabilityEvaluator) calculateResilienceScore(
 			Metric:   "reconnect_success_rate",
 			Current:  reconnectRate * 100,
 			Expected: 95.0,
-			Message:  fmt.Sprintf("重连成功率%.2f%%低于预期", reconnectRate*100),
+			Message:  se.lang.T("issue.low_reconnect_rate", reconnectRate*100),
 		})
 	}
 
@@ -445,100 +683,65 @@ func (se *StabilityEvaluator) determineStatus(result *core.EvaluationResult) cor
 	return core.StatusPass
 }
 
+// recommendationSpec 建议的固定属性，标题、说明和行动项取自消息目录 rec.<Code>.title|message|actions
+type recommendationSpec struct {
+	Code       string
+	Priority   string
+	Category   string
+	References []string
+}
+
+// issueRecommendations 问题类型 -> 建议
+var issueRecommendations = map[string]recommendationSpec{
+	"low_availability": {
+		Code:     "improve_availability",
+		Priority: "HIGH",
+		Category: "SCALING",
+		References: []string{
+			"https://redis.io/topics/sentinel",
+			"https://kafka.apache.org/documentation/#replication",
+		},
+	},
+	"high_p95_latency":           {Code: "reduce_latency", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"high_p99_latency":           {Code: "reduce_latency", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"high_error_rate":            {Code: "reduce_error_rate", Priority: "HIGH", Category: "CONFIGURATION"},
+	"data_loss_detected":         {Code: "prevent_data_loss", Priority: "HIGH", Category: "CONFIGURATION"},
+	"slow_recovery":              {Code: "speed_up_recovery", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"authentication_failures":    {Code: "fix_authentication", Priority: "HIGH", Category: "CONFIGURATION"},
+	"network_errors":             {Code: "check_network", Priority: "HIGH", Category: "SCALING"},
+	"timeout_errors":             {Code: "reduce_timeouts", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"data_loss_errors":           {Code: "check_retention", Priority: "HIGH", Category: "CONFIGURATION"},
+	"high_e2e_latency":           {Code: "reduce_e2e_latency", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"duplicate_messages":         {Code: "enable_idempotence", Priority: "MEDIUM", Category: "CONFIGURATION"},
+	"out_of_order_messages":      {Code: "preserve_ordering", Priority: "MEDIUM", Category: "CONFIGURATION"},
+	"operation_low_availability": {Code: "investigate_failing_operations", Priority: "HIGH", Category: "CONFIGURATION"},
+	"operation_high_error_rate":  {Code: "investigate_failing_operations", Priority: "HIGH", Category: "CONFIGURATION"},
+	"operation_high_latency":     {Code: "optimize_slow_operations", Priority: "MEDIUM", Category: "OPTIMIZATION"},
+	"load_generator_behind":      {Code: "increase_load_concurrency", Priority: "MEDIUM", Category: "CONFIGURATION"},
+	"low_reconnect_rate":         {Code: "improve_reconnect_rate", Priority: "MEDIUM", Category: "CONFIGURATION"},
+}
+
+// recommendation 按评估语言生成建议，args 用于格式化说明
+func (se *StabilityEvaluator) recommendation(spec recommendationSpec, args ...any) core.Recommendation {
+	key := "rec." + spec.Code
+	return core.Recommendation{
+		Code:       spec.Code,
+		Priority:   spec.Priority,
+		Category:   spec.Category,
+		Title:      se.lang.T(key+".title"),
+		Message:    se.lang.T(key+".message", args...),
+		Actions:    se.lang.Lines(key + ".actions"),
+		References: spec.References,
+	}
+}
+
 // generateRecommendations 生成建议
 func (se *StabilityEvaluator) generateRecommendations(result *core.EvaluationResult) []core.Recommendation {
 	recommendations := make([]core.Recommendation, 0)
 
 	for _, issue := range result.Issues {
-		switch issue.Type {
-		case "low_availability":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "HIGH",
-				Category: "SCALING",
-				Title:    "提高系统可用性",
-				Message:  "当前可用性不满足生产环境要求",
-				Actions: []string{
-					"检查服务健康状态，排查频繁失败原因",
-					"增加实例数量，实现高可用部署",
-					"配置健康检查和自动重启机制",
-					"实施熔断和降级策略",
-				},
-				References: []string{
-					"https://redis.io/topics/sentinel",
-					"https://kafka.apache.org/documentation/#replication",
-				},
-			})
-
-		case "high_p95_latency", "high_p99_latency":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "MEDIUM",
-				Category: "OPTIMIZATION",
-				Title:    "优化响应延迟",
-				Message:  "延迟指标超出可接受范围",
-				Actions: []string{
-					"分析慢查询日志，优化热点操作",
-					"检查网络延迟和带宽瓶颈",
-					"优化数据结构和查询模式",
-					"考虑增加缓存层或读写分离",
-					"评估硬件资源是否充足",
-				},
-			})
-
-		case "high_error_rate":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "HIGH",
-				Category: "CONFIGURATION",
-				Title:    "降低错误率",
-				Message:  "错误率过高可能导致业务中断",
-				Actions: []string{
-					"查看错误日志，分析错误类型",
-					"检查客户端配置（超时、重试）",
-					"验证服务端配置",
-					"实施错误处理和重试逻辑",
-				},
-			})
-
-		case "data_loss_detected":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "HIGH",
-				Category: "CONFIGURATION",
-				Title:    "防止数据丢失",
-				Message:  "检测到数据丢失，需立即处理",
-				Actions: []string{
-					"检查持久化配置",
-					"确保有足够的副本数",
-					"配置fsync策略",
-					"实施数据校验机制",
-				},
-			})
-
-		case "slow_recovery":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "MEDIUM",
-				Category: "OPTIMIZATION",
-				Title:    "加快故障恢复",
-				Message:  "平均恢复时间过长，影响系统可用性",
-				Actions: []string{
-					"优化健康检查频率和超时设置",
-					"实施更激进的重试策略",
-					"增加备用连接池",
-					"优化故障检测算法",
-				},
-			})
-
-		case "low_reconnect_rate":
-			recommendations = append(recommendations, core.Recommendation{
-				Priority: "MEDIUM",
-				Category: "CONFIGURATION",
-				Title:    "提高重连成功率",
-				Message:  "重连成功率低，影响系统稳定性",
-				Actions: []string{
-					"检查网络稳定性",
-					"调整重连间隔和最大重试次数",
-					"实施指数退避算法",
-					"检查服务端连接限制",
-				},
-			})
+		if spec, ok := issueRecommendations[issue.Type]; ok {
+			recommendations = append(recommendations, se.recommendation(spec))
 		}
 	}
 
@@ -552,8 +755,8 @@ func (se *StabilityEvaluator) generateRecommendations(result *core.EvaluationRes
 	seen := make(map[string]bool)
 	unique := make([]core.Recommendation, 0)
 	for _, rec := range recommendations {
-		if !seen[rec.Title] {
-			seen[rec.Title] = true
+		if !seen[rec.Code] {
+			seen[rec.Code] = true
 			unique = append(unique, rec)
 		}
 	}
@@ -562,49 +765,87 @@ func (se *StabilityEvaluator) generateRecommendations(result *core.EvaluationRes
 }
 
 // generateRationale 生成判断依据
-func (se *StabilityEvaluator) generateRationale(result *core.EvaluationResult) string {
+func (se *StabilityEvaluator) generateRationale(metrics *core.StabilityMetrics, result *core.EvaluationResult) string {
 	var b strings.Builder
+	t := se.lang.T
 
-	b.WriteString(fmt.Sprintf("综合评分: %.2f/100 (%s)\n\n", result.Score, result.Grade))
-	b.WriteString("各维度得分:\n")
-	b.WriteString(fmt.Sprintf("- 可用性: %.2f/30 (权重30%%)\n", result.Scores.Availability))
-	b.WriteString(fmt.Sprintf("- 性能: %.2f/25 (权重25%%)\n", result.Scores.Performance))
-	b.WriteString(fmt.Sprintf("- 可靠性: %.2f/25 (权重25%%)\n", result.Scores.Reliability))
-	b.WriteString(fmt.Sprintf("- 恢复力: %.2f/20 (权重20%%)\n\n", result.Scores.Resilience))
+	b.WriteString(t("rationale.score", result.Score, result.Grade) + "\n\n")
+	b.WriteString(t("rationale.scores") + "\n")
+	b.WriteString(t("rationale.dimension", t("dimension.availability"), result.Scores.Availability, 30, 30) + "\n")
+	b.WriteString(t("rationale.dimension", t("dimension.performance"), result.Scores.Performance, 25, 25) + "\n")
+	b.WriteString(t("rationale.dimension", t("dimension.reliability"), result.Scores.Reliability, 25, 25) + "\n")
+	b.WriteString(t("rationale.dimension", t("dimension.resilience"), result.Scores.Resilience, 20, 20) + "\n\n")
+
+	if summary := se.faultSummary(metrics.Events); summary != "" {
+		b.WriteString(summary)
+	}
+	if metrics.OutageCount > 0 {
+		b.WriteString(t("rationale.outages",
+			metrics.OutageCount,
+			metrics.TotalDowntime.Round(time.Millisecond),
+			metrics.LongestOutage.Round(time.Millisecond),
+			metrics.MTTR.Round(time.Millisecond)) + "\n\n")
+	}
+	if d := metrics.Delivery; d != nil && d.Received > 0 {
+		b.WriteString(t("rationale.e2e_latency",
+			d.EndToEndLatency.P50.Round(time.Millisecond),
+			d.EndToEndLatency.P95.Round(time.Millisecond),
+			d.EndToEndLatency.P99.Round(time.Millisecond),
+			d.ProduceLatency.P95.Round(time.Millisecond)) + "\n\n")
+	}
+	if metrics.TargetRate > 0 {
+		b.WriteString(t("rationale.open_loop",
+			metrics.TargetRate, metrics.Throughput, metrics.LateOperations,
+			core.LateStartThreshold, metrics.MaxScheduleLag.Round(time.Millisecond)) + "\n\n")
+	}
+	if len(metrics.Operations) > 1 {
+		parts := make([]string, 0, len(metrics.Operations))
+		for _, name := range sortedOperationNames(metrics.Operations) {
+			op := metrics.Operations[name]
+			parts = append(parts, t("rationale.operation",
+				name, op.Availability*100, op.P99Latency.Round(time.Millisecond)))
+		}
+		b.WriteString(t("rationale.operations", strings.Join(parts, t("rationale.operations_separator"))) + "\n\n")
+	}
 
 	switch result.Status {
 	case core.StatusPass:
-		b.WriteString("✅ 测试通过: 系统稳定性符合预期，可以用于生产环境。\n")
+		b.WriteString(t("rationale.pass") + "\n")
 	case core.StatusWarning:
-		b.WriteString("⚠️  警告: 系统存在需要关注的问题，建议优化后再部署。\n")
+		b.WriteString(t("rationale.warning") + "\n")
 	case core.StatusFail:
-		b.WriteString("❌ 测试失败: 系统稳定性不满足最低要求，不建议用于生产环境。\n")
+		b.WriteString(t("rationale.fail") + "\n")
 	}
 
 	if len(result.Issues) > 0 {
-		b.WriteString(fmt.Sprintf("\n发现 %d 个问题需要处理。\n", len(result.Issues)))
+		b.WriteString("\n" + t("rationale.issues", len(result.Issues)) + "\n")
 	}
 
 	return b.String()
 }
 
+// faultSummary 汇总场景中注入的故障，无故障事件时返回空字符串
+func (se *StabilityEvaluator) faultSummary(events []core.Event) string {
+	var faults []string
+	for _, e := range events {
+		if e.Type == core.EventFaultStart || e.Type == core.EventFaultInject {
+			faults = append(faults, fmt.Sprintf("%s@%v", e.Fault, e.Offset.Round(time.Second)))
+		}
+	}
+	if len(faults) == 0 {
+		return ""
+	}
+	return se.lang.T("rationale.faults", len(faults), strings.Join(faults, ", ")) + "\n\n"
+}
+
 // EvaluateRedis Redis特定评估
 func (se *StabilityEvaluator) EvaluateRedis(metrics *core.StabilityMetrics) *core.EvaluationResult {
 	result := se.Evaluate(metrics)
 
 	// 添加Redis特定检查
 	if metrics.CacheHitRate > 0 && metrics.CacheHitRate < 0.90 {
-		result.Recommendations = append(result.Recommendations, core.Recommendation{
-			Priority: "MEDIUM",
-			Category: "OPTIMIZATION",
-			Title:    "提高缓存命中率",
-			Message:  fmt.Sprintf("当前命中率%.2f%%偏低", metrics.CacheHitRate*100),
-			Actions: []string{
-				"分析缓存键的访问模式",
-				"调整缓存过期策略",
-				"考虑增加缓存容量",
-			},
-		})
+		spec := recommendationSpec{Code: "improve_cache_hit_rate", Priority: "MEDIUM", Category: "OPTIMIZATION"}
+		result.Recommendations = append(result.Recommendations, se.recommendation(spec, metrics.CacheHitRate*100))
 	}
 
 	return result
@@ -622,13 +863,100 @@ func (se *StabilityEvaluator) EvaluateKafka(metrics *core.StabilityMetrics) *cor
 			Metric:   "message_lag",
 			Current:  float64(metrics.MessageLag),
 			Expected: 1000,
-			Message:  "消息积压过多",
+			Message:  se.lang.T("issue.high_message_lag"),
+		})
+	}
+	if metrics.DuplicateMessages > 0 {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "duplicate_messages",
+			Severity: "MEDIUM",
+			Metric:   "duplicate_rate",
+			Current:  metrics.DuplicateRate * 100,
+			Expected: 0,
+			Message: se.lang.T("issue.duplicate_messages",
+				metrics.DuplicateMessages, metrics.DuplicateRate*100),
+		})
+	}
+	if metrics.OutOfOrderRate > 0 {
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "out_of_order_messages",
+			Severity: "MEDIUM",
+			Metric:   "out_of_order_rate",
+			Current:  metrics.OutOfOrderRate * 100,
+			Expected: 0,
+			Message:  se.lang.T("issue.out_of_order_messages", metrics.OutOfOrderRate*100),
 		})
 	}
 
+	// 启用投递跟踪时，性能得分由发送确认延迟和端到端延迟各占一半
+	if d := metrics.Delivery; d != nil && d.Received > 0 {
+		e2eScore := se.calculateE2ELatencyScore(d.EndToEndLatency, result)
+		result.Scores.Performance = (result.Scores.Performance + e2eScore) / 2
+	}
+	se.finalize(metrics, result)
+
 	return result
 }
 
+// calculateE2ELatencyScore 计算端到端延迟得分 (满分25分，P95占15分，P99占10分)
+func (se *StabilityEvaluator) calculateE2ELatencyScore(
+	latency core.LatencySummary,
+	result *core.EvaluationResult,
+) float64 {
+	p95, p99 := latency.P95, latency.P99
+
+	var p95Score float64
+	switch {
+	case p95 <= se.thresholds.E2EP95LatencyExcellent:
+		p95Score = 15.0
+	case p95 <= se.thresholds.E2EP95LatencyGood:
+		p95Score = 13.5
+	case p95 <= se.thresholds.E2EP95LatencyFair:
+		p95Score = 12.0
+	case p95 <= se.thresholds.E2EP95LatencyPass:
+		p95Score = 10.0
+	default:
+		p95Score = 8.0
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "high_e2e_latency",
+			Severity: "HIGH",
+			Metric:   "e2e_p95_latency",
+			Current:  float64(p95.Milliseconds()),
+			Expected: float64(se.thresholds.E2EP95LatencyPass.Milliseconds()),
+			Message:  se.lang.T("issue.high_e2e_latency.p95", p95, se.thresholds.E2EP95LatencyPass),
+		})
+	}
+
+	var p99Score float64
+	switch {
+	case p99 <= se.thresholds.E2EP99LatencyExcellent:
+		p99Score = 10.0
+	case p99 <= se.thresholds.E2EP99LatencyGood:
+		p99Score = 9.0
+	case p99 <= se.thresholds.E2EP99LatencyFair:
+		p99Score = 8.0
+	case p99 <= se.thresholds.E2EP99LatencyPass:
+		p99Score = 6.5
+	default:
+		p99Score = 5.0
+		result.Issues = append(result.Issues, core.Issue{
+			Type:     "high_e2e_latency",
+			Severity: "MEDIUM",
+			Metric:   "e2e_p99_latency",
+			Current:  float64(p99.Milliseconds()),
+			Expected: float64(se.thresholds.E2EP99LatencyPass.Milliseconds()),
+			Message:  se.lang.T("issue.high_e2e_latency.p99", p99, se.thresholds.E2EP99LatencyPass),
+		})
+	}
+
+	return p95Score + p99Score
+}
+
+// SetLang 设置问题、建议和判断依据的语言
+func (se *StabilityEvaluator) SetLang(lang i18n.Lang) {
+	se.lang = lang
+}
+
 // SetThresholds 设置自定义阈值
 func (se *StabilityEvaluator) SetThresholds(thresholds *core.Thresholds) {
 	se.thresholds = thresholds
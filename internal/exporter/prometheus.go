@@ -0,0 +1,250 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// MetricsPath 指标的HTTP路径
+const MetricsPath = "/metrics"
+
+// DefaultBuckets 延迟直方图的桶上界（秒）
+var DefaultBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Exporter 以Prometheus文本格式实时导出操作指标
+//
+// 实现 collector.OperationObserver，由收集器在每次记录操作后调用，记录过程无锁。
+// 导出的指标（均带 middleware、operation、type、phase 标签）：
+//
+//	mct_operations_total{result="success|failure"}  操作数
+//	mct_errors_total{error_type="..."}               按错误分类的失败操作数
+//	mct_operation_duration_seconds                   操作延迟直方图
+type Exporter struct {
+	middleware string
+	buckets    []float64
+	phase      atomic.Pointer[func() string]
+
+	// seriesKey -> *opSeries
+	series sync.Map
+
+	mu       sync.Mutex
+	listener net.Listener
+	server   *http.Server
+}
+
+// seriesKey 一组标签
+type seriesKey struct {
+	operation string
+	opType    string
+	phase     string
+}
+
+// opSeries 一组标签下的计数和延迟分布
+type opSeries struct {
+	successes atomic.Int64
+	failures  atomic.Int64
+	errors    sync.Map // core.ErrorType -> *atomic.Int64
+	buckets   []atomic.Int64
+	sumNanos  atomic.Int64
+}
+
+// NewExporter 创建导出器，middleware 作为所有指标的 middleware 标签
+func NewExporter(middleware string) *Exporter {
+	return &Exporter{middleware: middleware, buckets: DefaultBuckets}
+}
+
+// SetPhaseSource 设置当前场景阶段的来源，作为 phase 标签；未设置时标签为空
+func (e *Exporter) SetPhaseSource(phase func() string) {
+	e.phase.Store(&phase)
+}
+
+// ObserveOperation 记录一次操作，可被多个goroutine并发调用
+func (e *Exporter) ObserveOperation(result *core.Result, errType core.ErrorType) {
+	key := seriesKey{operation: result.Operation, opType: string(result.OpType)}
+	if phase := e.phase.Load(); phase != nil {
+		key.phase = (*phase)()
+	}
+	s := e.seriesFor(key)
+
+	seconds := result.Duration.Seconds()
+	i := sort.SearchFloat64s(e.buckets, seconds)
+	s.buckets[i].Add(1)
+	s.sumNanos.Add(int64(result.Duration))
+
+	if result.Success {
+		s.successes.Add(1)
+		return
+	}
+	s.failures.Add(1)
+	counter, _ := s.errors.LoadOrStore(errType, new(atomic.Int64))
+	counter.(*atomic.Int64).Add(1)
+}
+
+func (e *Exporter) seriesFor(key seriesKey) *opSeries {
+	if s, ok := e.series.Load(key); ok {
+		return s.(*opSeries)
+	}
+	// 最后一个桶为 +Inf
+	s, _ := e.series.LoadOrStore(key, &opSeries{buckets: make([]atomic.Int64, len(e.buckets)+1)})
+	return s.(*opSeries)
+}
+
+// ServeHTTP 以Prometheus文本格式（0.0.4）输出当前指标
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	e.Write(bw)
+	bw.Flush()
+}
+
+// Write 以Prometheus文本格式写出当前指标，序列按标签排序
+func (e *Exporter) Write(w io.Writer) {
+	type entry struct {
+		key    seriesKey
+		series *opSeries
+	}
+	var entries []entry
+	e.series.Range(func(k, v any) bool {
+		entries = append(entries, entry{k.(seriesKey), v.(*opSeries)})
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].key, entries[j].key
+		if a.operation != b.operation {
+			return a.operation < b.operation
+		}
+		if a.opType != b.opType {
+			return a.opType < b.opType
+		}
+		return a.phase < b.phase
+	})
+
+	labels := func(key seriesKey, extra ...string) string {
+		pairs := []string{
+			"middleware", e.middleware,
+			"operation", key.operation,
+			"type", key.opType,
+			"phase", key.phase,
+		}
+		pairs = append(pairs, extra...)
+		var sb strings.Builder
+		sb.WriteByte('{')
+		for i := 0; i < len(pairs); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(pairs[i])
+			sb.WriteString(`="`)
+			sb.WriteString(escapeLabel(pairs[i+1]))
+			sb.WriteByte('"')
+		}
+		sb.WriteByte('}')
+		return sb.String()
+	}
+
+	fmt.Fprintln(w, "# HELP mct_operations_total Operations performed during the test, by result.")
+	fmt.Fprintln(w, "# TYPE mct_operations_total counter")
+	for _, en := range entries {
+		fmt.Fprintf(w, "mct_operations_total%s %d\n", labels(en.key, "result", "success"), en.series.successes.Load())
+		fmt.Fprintf(w, "mct_operations_total%s %d\n", labels(en.key, "result", "failure"), en.series.failures.Load())
+	}
+
+	fmt.Fprintln(w, "# HELP mct_errors_total Failed operations by error type.")
+	fmt.Fprintln(w, "# TYPE mct_errors_total counter")
+	for _, en := range entries {
+		var types []string
+		en.series.errors.Range(func(k, _ any) bool {
+			types = append(types, string(k.(core.ErrorType)))
+			return true
+		})
+		sort.Strings(types)
+		for _, t := range types {
+			counter, _ := en.series.errors.Load(core.ErrorType(t))
+			fmt.Fprintf(w, "mct_errors_total%s %d\n", labels(en.key, "error_type", t), counter.(*atomic.Int64).Load())
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP mct_operation_duration_seconds Operation latency in seconds.")
+	fmt.Fprintln(w, "# TYPE mct_operation_duration_seconds histogram")
+	for _, en := range entries {
+		var cumulative int64
+		for i := range en.series.buckets {
+			cumulative += en.series.buckets[i].Load()
+			le := "+Inf"
+			if i < len(e.buckets) {
+				le = strconv.FormatFloat(e.buckets[i], 'g', -1, 64)
+			}
+			fmt.Fprintf(w, "mct_operation_duration_seconds_bucket%s %d\n", labels(en.key, "le", le), cumulative)
+		}
+		fmt.Fprintf(w, "mct_operation_duration_seconds_sum%s %s\n", labels(en.key),
+			strconv.FormatFloat(time.Duration(en.series.sumNanos.Load()).Seconds(), 'g', -1, 64))
+		fmt.Fprintf(w, "mct_operation_duration_seconds_count%s %d\n", labels(en.key), cumulative)
+	}
+}
+
+// escapeLabel 按文本格式转义标签值
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// Start 在 listenAddr 上提供 /metrics，listenAddr 可使用 127.0.0.1:0 自动分配端口
+func (e *Exporter) Start(listenAddr string) error {
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, e)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	e.mu.Lock()
+	e.listener = ln
+	e.server = server
+	e.mu.Unlock()
+
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ln.Close()
+		}
+	}()
+	return nil
+}
+
+// Addr 返回监听地址，未监听时返回空字符串
+func (e *Exporter) Addr() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.listener == nil {
+		return ""
+	}
+	return e.listener.Addr().String()
+}
+
+// Close 停止HTTP服务，等待进行中的抓取完成
+func (e *Exporter) Close() error {
+	e.mu.Lock()
+	server := e.server
+	e.server, e.listener = nil, nil
+	e.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
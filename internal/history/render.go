@@ -0,0 +1,228 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// Trend 一个指标在各次运行中的变化
+type Trend struct {
+	Metric string    // 指标名称，如 score、p99_latency
+	Values []float64 // 按运行顺序的值，延迟为纳秒
+}
+
+// trendMetrics 趋势中列出的指标
+var trendMetrics = []struct {
+	name  string
+	value func(*core.StabilityMetrics, *core.EvaluationResult) float64
+}{
+	{"score", func(_ *core.StabilityMetrics, e *core.EvaluationResult) float64 { return e.Score }},
+	{"availability", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return m.Availability }},
+	{"throughput", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return m.Throughput }},
+	{"p50_latency", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return float64(m.P50Latency) }},
+	{"p95_latency", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return float64(m.P95Latency) }},
+	{"p99_latency", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return float64(m.P99Latency) }},
+	{"p999_latency", func(m *core.StabilityMetrics, _ *core.EvaluationResult) float64 { return float64(m.P999Latency) }},
+}
+
+// Trends 计算各指标的趋势，跳过缺少指标或评估结果的记录
+func Trends(records []*Record) []Trend {
+	trends := make([]Trend, len(trendMetrics))
+	for i, tm := range trendMetrics {
+		trends[i].Metric = tm.name
+		for _, r := range records {
+			if r.Metrics == nil || r.Evaluation == nil {
+				continue
+			}
+			trends[i].Values = append(trends[i].Values, tm.value(r.Metrics, r.Evaluation))
+		}
+	}
+	return trends
+}
+
+// metricLabel 指标的显示名称
+func metricLabel(metric string) string {
+	switch metric {
+	case "score":
+		return "总分"
+	case "availability":
+		return "可用性"
+	case "throughput":
+		return "吞吐量"
+	case "p50_latency":
+		return "P50延迟"
+	case "p95_latency":
+		return "P95延迟"
+	case "p99_latency":
+		return "P99延迟"
+	case "p999_latency":
+		return "P99.9延迟"
+	default:
+		return metric
+	}
+}
+
+// formatValue 按指标格式化值
+func formatValue(metric string, v float64) string {
+	switch {
+	case metric == "score":
+		return fmt.Sprintf("%.1f", v)
+	case metric == "availability":
+		return fmt.Sprintf("%.3f%%", v*100)
+	case metric == "throughput":
+		return fmt.Sprintf("%.0f ops/s", v)
+	case strings.HasSuffix(metric, "_latency"):
+		return time.Duration(v).Round(time.Microsecond).String()
+	default:
+		return fmt.Sprintf("%g", v)
+	}
+}
+
+// formatChange 最近一次相对首次的变化，可用性以百分点表示
+func formatChange(metric string, first, last float64) string {
+	switch {
+	case metric == "availability":
+		return fmt.Sprintf("%+.3fpp", (last-first)*100)
+	case first == 0:
+		return "-"
+	default:
+		return fmt.Sprintf("%+.1f%%", (last-first)/first*100)
+	}
+}
+
+// sparkBlocks 趋势图字符，从低到高
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline 按最小值到最大值的范围绘制趋势图，值都相同时显示为中间高度
+func sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+// formatLabels 以 key=value 形式按键排序输出标签
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// displayWidth 终端显示宽度，中文等全角字符占两列
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || (r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// writeTable 按显示宽度对齐输出表格，第一行为表头
+func writeTable(sb *strings.Builder, table [][]string) {
+	widths := make([]int, len(table[0]))
+	for _, row := range table {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	for _, row := range table {
+		line := make([]string, len(row))
+		for i, cell := range row {
+			line[i] = cell + strings.Repeat(" ", widths[i]-displayWidth(cell))
+		}
+		sb.WriteString("  " + strings.TrimRight(strings.Join(line, "  "), " ") + "\n")
+	}
+}
+
+// runRows 运行列表的各行
+func runRows(records []*Record) [][]string {
+	table := [][]string{{"ID", "开始时间", "名称", "中间件", "标签", "总分", "等级", "状态", "可用性", "P50", "P95", "P99"}}
+	for _, r := range records {
+		row := []string{r.ID, r.StartTime().Local().Format("2006-01-02 15:04"), r.Name, r.Middleware,
+			formatLabels(r.Labels), "-", "-", "-", "-", "-", "-", "-"}
+		if e := r.Evaluation; e != nil {
+			row[5], row[6], row[7] = formatValue("score", e.Score), string(e.Grade), string(e.Status)
+		}
+		if m := r.Metrics; m != nil {
+			row[8] = formatValue("availability", m.Availability)
+			row[9] = formatValue("p50_latency", float64(m.P50Latency))
+			row[10] = formatValue("p95_latency", float64(m.P95Latency))
+			row[11] = formatValue("p99_latency", float64(m.P99Latency))
+		}
+		table = append(table, row)
+	}
+	return table
+}
+
+// WriteConsole 以控制台格式输出运行列表和趋势
+func WriteConsole(w io.Writer, records []*Record) error {
+	var sb strings.Builder
+	sb.WriteString("==========================================\n")
+	sb.WriteString("   运行历史\n")
+	sb.WriteString("==========================================\n\n")
+
+	if len(records) == 0 {
+		sb.WriteString("没有符合条件的运行记录\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	writeTable(&sb, runRows(records))
+
+	trends := Trends(records)
+	if len(trends[0].Values) >= 2 {
+		sb.WriteString(fmt.Sprintf("\n趋势 (%s ~ %s, %d 次运行):\n",
+			records[0].StartTime().Local().Format("2006-01-02 15:04"),
+			records[len(records)-1].StartTime().Local().Format("2006-01-02 15:04"),
+			len(trends[0].Values)))
+
+		table := [][]string{{"指标", "趋势", "首次", "最近", "最小", "最大", "变化"}}
+		for _, t := range trends {
+			first, last := t.Values[0], t.Values[len(t.Values)-1]
+			lo, hi := t.Values[0], t.Values[0]
+			for _, v := range t.Values {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+			table = append(table, []string{
+				metricLabel(t.Metric),
+				sparkline(t.Values),
+				formatValue(t.Metric, first),
+				formatValue(t.Metric, last),
+				formatValue(t.Metric, lo),
+				formatValue(t.Metric, hi),
+				formatChange(t.Metric, first, last),
+			})
+		}
+		writeTable(&sb, table)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,214 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+)
+
+// FileName 历史记录文件名，每行一条JSON记录，只追加不修改
+const FileName = "runs.jsonl"
+
+// Record 一次运行的记录
+type Record struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Middleware string                 `json:"middleware"`
+	Labels     map[string]string      `json:"labels,omitempty"`
+	RecordedAt time.Time              `json:"recorded_at"`
+	Config     *config.Config         `json:"config,omitempty"` // 不含密码
+	Metrics    *core.StabilityMetrics `json:"metrics"`
+	Evaluation *core.EvaluationResult `json:"evaluation"`
+}
+
+// NewRecord 根据配置和测试结果创建记录，ID由测试开始时间和随机后缀组成
+func NewRecord(cfg *config.Config, metrics *core.StabilityMetrics, evaluation *core.EvaluationResult) *Record {
+	recordedAt := time.Now()
+	started := metrics.StartTime
+	if started.IsZero() {
+		started = recordedAt
+	}
+
+	redacted := *cfg
+	redacted.Connection.Password = ""
+
+	return &Record{
+		ID:         fmt.Sprintf("%s-%04x", started.Format("20060102-150405"), rand.IntN(0x10000)),
+		Name:       cfg.Name,
+		Middleware: cfg.GetMiddlewareType(),
+		Labels:     cfg.Labels,
+		RecordedAt: recordedAt,
+		Config:     &redacted,
+		Metrics:    metrics,
+		Evaluation: evaluation,
+	}
+}
+
+// StartTime 测试开始时间，没有时为记录时间
+func (r *Record) StartTime() time.Time {
+	if r.Metrics != nil && !r.Metrics.StartTime.IsZero() {
+		return r.Metrics.StartTime
+	}
+	return r.RecordedAt
+}
+
+// Filter 查询条件，零值匹配所有记录
+type Filter struct {
+	Middleware string
+	Name       string
+	// Labels 需要全部匹配的标签，值为空时只要求存在该标签
+	Labels map[string]string
+	Since  time.Time
+	// Limit 只保留最近的N条，0表示不限制
+	Limit int
+}
+
+// Match 记录是否满足查询条件
+func (f Filter) Match(r *Record) bool {
+	if f.Middleware != "" && !strings.EqualFold(r.Middleware, f.Middleware) {
+		return false
+	}
+	if f.Name != "" && r.Name != f.Name {
+		return false
+	}
+	for k, v := range f.Labels {
+		actual, ok := r.Labels[k]
+		if !ok || (v != "" && actual != v) {
+			return false
+		}
+	}
+	return f.Since.IsZero() || !r.StartTime().Before(f.Since)
+}
+
+// Store 基于目录的运行历史存储
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewStore 创建历史存储，目录在第一次写入时创建
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Path 历史记录文件路径
+func (s *Store) Path() string {
+	return filepath.Join(s.dir, FileName)
+}
+
+// Append 追加一条记录，整条记录一次写入
+// 文件末尾有未写完的记录（没有换行符，例如写入时进程被终止）时先将其截掉，避免新记录接在其后无法解析
+func (s *Store) Append(r *Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode history record: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(s.Path(), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if err := trimPartial(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to repair history: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+// trimPartial 将文件截断到最后一个换行符之后，去掉末尾未写完的记录
+func trimPartial(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	end := info.Size()
+	for end > 0 {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+	if end == info.Size() {
+		return nil
+	}
+	return f.Truncate(end)
+}
+
+// List 按测试开始时间顺序返回满足条件的记录，历史文件不存在时返回空列表
+// 最后一行没有换行符时视为未写完的记录并忽略，其他无法解析的行返回 core.ErrInvalidMetrics
+func (s *Store) List(filter Filter) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.Path())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	var records []*Record
+	reader := bufio.NewReader(f)
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		r := &Record{}
+		if err := json.Unmarshal(line, r); err != nil {
+			return nil, fmt.Errorf("%w: %s line %d: %v", core.ErrInvalidMetrics, s.Path(), lineNo, err)
+		}
+		if filter.Match(r) {
+			records = append(records, r)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].StartTime().Before(records[j].StartTime())
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, nil
+}
@@ -0,0 +1,283 @@
+package i18n
+
+// en 英文消息目录
+var en = map[string]string{
+	// 评估问题
+	"issue.low_availability":           "Availability %.2f%% is below the required minimum of %.2f%%",
+	"issue.high_p95_latency":           "P95 latency %v exceeds the threshold of %v",
+	"issue.high_p99_latency":           "P99 latency %v exceeds the threshold of %v",
+	"issue.high_error_rate":            "Error rate %.4f%% exceeds the threshold of %.2f%%",
+	"issue.data_loss_detected":         "Data loss detected, loss rate %.4f%%",
+	"issue.authentication_failures":    "%d authentication failures; check the username, password or SASL settings",
+	"issue.network_errors":             "%d network errors, %.2f%% of all operations",
+	"issue.timeout_errors":             "%d timeouts, %.2f%% of all operations",
+	"issue.data_loss_errors":           "%d operations failed because the data was no longer readable (e.g. offset out of range)",
+	"issue.operation_low_availability": "%s availability %.2f%% is below the required %.2f%%",
+	"issue.operation_high_error_rate":  "%s error rate %.2f%% is above the allowed %.2f%%",
+	"issue.operation_high_latency.p95": "%s P95 latency %v exceeds %v",
+	"issue.operation_high_latency.p99": "%s P99 latency %v exceeds %v",
+	"issue.load_generator_behind":      "%.2f%% of operations started behind schedule (max %v), target %.0f ops/s, actual %.0f ops/s; latency is measured from the intended send time",
+	"issue.unrecovered_outage":         "The service had not recovered when the test ended; the outage lasted %v",
+	"issue.slow_recovery":              "Mean time to recovery %v exceeds the threshold of %v",
+	"issue.low_reconnect_rate":         "Reconnect success rate %.2f%% is lower than expected",
+	"issue.high_message_lag":           "Too many messages are waiting to be consumed",
+	"issue.duplicate_messages":         "%d duplicate messages detected, duplicate rate %.4f%%",
+	"issue.out_of_order_messages":      "Out-of-order messages detected within partitions, out-of-order rate %.4f%%",
+	"issue.high_e2e_latency.p95":       "End-to-end P95 latency %v exceeds the threshold of %v",
+	"issue.high_e2e_latency.p99":       "End-to-end P99 latency %v exceeds the threshold of %v",
+
+	// 改进建议
+	"rec.improve_availability.title":   "Improve availability",
+	"rec.improve_availability.message": "Availability does not meet production requirements",
+	"rec.improve_availability.actions": "Check service health and find the cause of frequent failures\n" +
+		"Add instances for a highly available deployment\n" +
+		"Configure health checks and automatic restarts\n" +
+		"Introduce circuit breaking and graceful degradation",
+	"rec.reduce_latency.title":   "Reduce response latency",
+	"rec.reduce_latency.message": "Latency is outside the acceptable range",
+	"rec.reduce_latency.actions": "Analyze the slow log and optimize hot operations\n" +
+		"Check for network latency and bandwidth bottlenecks\n" +
+		"Optimize data structures and access patterns\n" +
+		"Consider adding a cache layer or separating reads from writes\n" +
+		"Check whether hardware resources are sufficient",
+	"rec.reduce_error_rate.title":   "Reduce the error rate",
+	"rec.reduce_error_rate.message": "A high error rate can interrupt the business",
+	"rec.reduce_error_rate.actions": "Review the error logs and break errors down by type\n" +
+		"Check client settings (timeouts, retries)\n" +
+		"Verify the server configuration\n" +
+		"Implement error handling and retry logic",
+	"rec.prevent_data_loss.title":   "Prevent data loss",
+	"rec.prevent_data_loss.message": "Data loss was detected and needs immediate attention",
+	"rec.prevent_data_loss.actions": "Check the persistence settings\n" +
+		"Make sure there are enough replicas\n" +
+		"Configure the fsync policy\n" +
+		"Add data verification",
+	"rec.speed_up_recovery.title":   "Speed up recovery",
+	"rec.speed_up_recovery.message": "Mean time to recovery is too long and hurts availability",
+	"rec.speed_up_recovery.actions": "Tune health check intervals and timeouts\n" +
+		"Use a more aggressive retry strategy\n" +
+		"Add a standby connection pool\n" +
+		"Improve failure detection",
+	"rec.fix_authentication.title":   "Fix authentication settings",
+	"rec.fix_authentication.message": "Authentication failures do not recover with retries; fix the credentials or permissions",
+	"rec.fix_authentication.actions": "Check MCT_USERNAME / MCT_PASSWORD or the credentials in the config file\n" +
+		"Make sure the Redis ACL user or Kafka SASL mechanism is configured correctly\n" +
+		"Check the ACLs for the Kafka topic and group",
+	"rec.check_network.title":   "Investigate network connectivity",
+	"rec.check_network.message": "Many operations failed because connections were dropped or refused",
+	"rec.check_network.actions": "Check that the server process and port are reachable\n" +
+		"Check the server's maximum connection limit\n" +
+		"Make sure load balancers and firewalls are not closing connections\n" +
+		"Enable automatic reconnects in the client",
+	"rec.reduce_timeouts.title":   "Reduce operation timeouts",
+	"rec.reduce_timeouts.message": "Many operations timed out; the server is slow or the network is congested",
+	"rec.reduce_timeouts.actions": "Check the server slow log and resource usage\n" +
+		"Adjust client timeouts based on P99 latency\n" +
+		"Check network bandwidth and packet loss",
+	"rec.check_retention.title":   "Check the message retention policy",
+	"rec.check_retention.message": "Messages at the consumer position were deleted, so unconsumed messages were lost",
+	"rec.check_retention.actions": "Increase retention.ms/retention.bytes for the topic\n" +
+		"Consume faster to reduce the backlog\n" +
+		"Check the auto.offset.reset policy",
+	"rec.reduce_e2e_latency.title":   "Reduce end-to-end latency",
+	"rec.reduce_e2e_latency.message": "Messages take too long from being produced to being consumed",
+	"rec.reduce_e2e_latency.actions": "Lower the producer batch wait time (BatchTimeout/linger.ms)\n" +
+		"Lower the consumer fetch wait time (MaxWait/fetch.max.wait.ms)\n" +
+		"Check consumer capacity and backlog\n" +
+		"Check replica sync latency (with acks=all)",
+	"rec.enable_idempotence.title":   "Enable idempotent producing",
+	"rec.enable_idempotence.message": "Producer retries wrote duplicate messages",
+	"rec.enable_idempotence.actions": "Enable producer idempotence (enable.idempotence=true)\n" +
+		"Deduplicate on the consumer by business key or message ID",
+	"rec.preserve_ordering.title":   "Preserve ordering within partitions",
+	"rec.preserve_ordering.message": "Retries or concurrent requests reordered messages within a partition",
+	"rec.preserve_ordering.actions": "For strict ordering, set max.in.flight.requests.per.connection to 1 or enable idempotent producing\n" +
+		"Write messages that must stay in order with the same key so they land in the same partition",
+	"rec.investigate_failing_operations.title":   "Investigate operations with concentrated failures",
+	"rec.investigate_failing_operations.message": "Some operations fail much more often than the overall average",
+	"rec.investigate_failing_operations.actions": "Use the per-operation error breakdown to find the cause\n" +
+		"For failing writes, check the primary, read-only replicas and memory/disk quotas\n" +
+		"For failing reads, check replica sync and consumer state",
+	"rec.optimize_slow_operations.title":   "Optimize slow operations",
+	"rec.optimize_slow_operations.message": "Some operations exceed their latency thresholds; fast operations can hide them in the overall latency",
+	"rec.optimize_slow_operations.actions": "For slow writes, check the persistence policy (AOF fsync, acks, replica sync)\n" +
+		"Check for big keys and large values\n" +
+		"For slow reads, check the consumer fetch wait time and batch size",
+	"rec.increase_load_concurrency.title":   "Increase load generator concurrency",
+	"rec.increase_load_concurrency.message": "With all workers busy, operations could not be sent on schedule; queueing during faults is included in latency, but the actual load was below target",
+	"rec.increase_load_concurrency.actions": "Increase --concurrency to at least target rate × expected latency during faults\n" +
+		"Falling behind only while faults are injected is expected; confirm with the metric trends\n" +
+		"Check whether CPU or network on the load generator machine is the bottleneck",
+	"rec.improve_reconnect_rate.title":   "Improve the reconnect success rate",
+	"rec.improve_reconnect_rate.message": "A low reconnect success rate hurts stability",
+	"rec.improve_reconnect_rate.actions": "Check network stability\n" +
+		"Tune the reconnect interval and maximum retries\n" +
+		"Use exponential backoff\n" +
+		"Check the server's connection limits",
+	"rec.improve_cache_hit_rate.title":   "Improve the cache hit rate",
+	"rec.improve_cache_hit_rate.message": "The hit rate of %.2f%% is low",
+	"rec.improve_cache_hit_rate.actions": "Analyze key access patterns\n" +
+		"Adjust the expiration policy\n" +
+		"Consider increasing cache capacity",
+
+	// 判断依据
+	"rationale.score":                "Overall score: %.2f/100 (%s)",
+	"rationale.scores":               "Dimension scores:",
+	"rationale.dimension":            "- %s: %.2f/%d (weight %d%%)",
+	"rationale.faults":               "Chaos scenario: %d faults were injected during the test (%s); the results above include behavior under faults.",
+	"rationale.outages":              "Outage detection: %d outages, %v in total, longest %v, MTTR %v.",
+	"rationale.e2e_latency":          "End-to-end latency: P50 %v, P95 %v, P99 %v (produce ack P95 %v).",
+	"rationale.open_loop":            "Open-loop load: target %.0f ops/s, actual %.0f ops/s, %d operations started more than %v behind schedule (max %v).",
+	"rationale.operation":            "%s availability %.2f%%, P99 %v",
+	"rationale.operations":           "By operation: %s.",
+	"rationale.operations_separator": "; ",
+	"rationale.pass":                 "✅ Passed: stability meets expectations and the system is ready for production.",
+	"rationale.warning":              "⚠️  Warning: some issues need attention; fix them before deploying.",
+	"rationale.fail":                 "❌ Failed: stability is below the minimum requirements; not recommended for production.",
+	"rationale.issues":               "Issues to address: %d.",
+
+	// 评分维度
+	"dimension.availability": "Availability",
+	"dimension.performance":  "Performance",
+	"dimension.reliability":  "Reliability",
+	"dimension.resilience":   "Resilience",
+
+	// 错误类型
+	"error_type.network":        "Network",
+	"error_type.timeout":        "Timeout",
+	"error_type.authentication": "Authentication",
+	"error_type.data_loss":      "Data loss",
+	"error_type.other":          "Other",
+
+	// 时间线事件
+	"event.phase_start":  "Phase start",
+	"event.phase_end":    "Phase end",
+	"event.fault_start":  "Fault start",
+	"event.fault_stop":   "Fault stop",
+	"event.fault_inject": "Fault injected",
+
+	// 编排器状态
+	"state.running":   "Running",
+	"state.paused":    "Paused",
+	"state.stopped":   "Stopped",
+	"state.completed": "Completed",
+
+	// 报告标题和段落
+	"report.title":                  "Middleware Stability Test Report",
+	"report.test_info":              "Test information",
+	"report.duration":               "Duration",
+	"report.completed":              "Completed",
+	"report.overall_score":          "Overall score",
+	"report.dimension_scores":       "Dimension scores",
+	"report.weight":                 "weight %d%%",
+	"report.key_metrics":            "Key metrics",
+	"report.performance":            "Performance",
+	"report.reliability":            "Reliability",
+	"report.recovery":               "Recovery",
+	"report.by_operation":           "By operation",
+	"report.errors_by_type":         "Errors by type",
+	"report.outage_windows":         "Outage windows",
+	"report.trends":                 "Trends",
+	"report.trends_per_cell":        "%v per cell",
+	"report.trends_note":            "%v per cell; see the JSON report for the full interval data.",
+	"report.timeline":               "Timeline",
+	"report.issues":                 "Issues (%d)",
+	"report.recommendations":        "Recommendations",
+	"report.recommendations_sorted": "Recommendations (by priority)",
+	"report.conclusion":             "Conclusion",
+	"report.category":               "Category",
+	"report.actions":                "Actions",
+	"report.references":             "References",
+	"report.metric":                 "Metric",
+	"report.current":                "Current",
+	"report.expected":               "Expected",
+	"report.description":            "Description",
+	"report.open_loop_detail":       "target %.0f ops/s, %d operations more than %v behind schedule (max %v)",
+	"report.delivery_detail":        "acked %d, received %d, lost %d",
+	"report.unrecovered":            "Not recovered when the test ended",
+	"report.unrecovered_note":       " (not recovered when the test ended)",
+	"report.yes":                    "yes",
+	"report.no":                     "no",
+
+	// 指标名称
+	"metric.availability":            "Availability",
+	"metric.availability_rate":       "Availability",
+	"metric.total_operations":        "Total operations",
+	"metric.successful_operations":   "Successful operations",
+	"metric.failed_operations":       "Failed operations",
+	"metric.success_failure":         "Successful / failed",
+	"metric.error_rate":              "Error rate",
+	"metric.latency":                 "%s latency",
+	"metric.tail_latency":            "Tail latency",
+	"metric.max_latency":             "Max latency",
+	"metric.avg_latency":             "Average latency",
+	"metric.stddev":                  "stddev %v",
+	"metric.avg_throughput":          "Average throughput",
+	"metric.open_loop":               "Open-loop load",
+	"metric.produce_latency":         "Produce ack latency",
+	"metric.e2e_latency":             "End-to-end latency",
+	"metric.data_loss_rate":          "Data loss rate",
+	"metric.data_consistency":        "Data consistency",
+	"metric.lost_writes":             "Lost writes",
+	"metric.stale_reads":             "Stale reads",
+	"metric.corrupted_reads":         "Corrupted reads",
+	"metric.delivery":                "Message delivery",
+	"metric.duplicate_rate":          "Duplicate rate",
+	"metric.out_of_order_rate":       "Out-of-order rate",
+	"metric.duplicates_out_of_order": "Duplicates / out of order",
+	"metric.outages":                 "Outages",
+	"metric.total_downtime":          "Total downtime",
+	"metric.longest_outage":          "Longest outage",
+	"metric.reconnect_rate":          "Reconnect success rate",
+
+	// 表格列
+	"column.dimension":         "Dimension",
+	"column.score":             "Score",
+	"column.percent":           "Percent",
+	"column.weight":            "Weight",
+	"column.operation":         "Operation",
+	"column.type":              "Type",
+	"column.operations":        "Operations",
+	"column.availability":      "Availability",
+	"column.throughput":        "Throughput",
+	"column.errors":            "Errors",
+	"column.error_type":        "Error type",
+	"column.count":             "Count",
+	"column.share_of_failures": "Share of failures",
+	"column.latency":           "Latency",
+	"column.produce_ack":       "Produce ack",
+	"column.end_to_end":        "End-to-end",
+	"column.start":             "Start",
+	"column.duration":          "Duration",
+	"column.failed_operations": "Failed operations",
+	"column.recovered":         "Recovered",
+	"column.time":              "Time",
+	"column.event":             "Event",
+	"column.phase":             "Phase",
+	"column.fault":             "Fault",
+	"column.description":       "Description",
+
+	// 指标趋势和图表
+	"trend.availability":       "Availability",
+	"trend.throughput":         "Throughput (peak %.0f ops/s)",
+	"trend.target_rate":        "Target rate (peak %.0f ops/s)",
+	"trend.p99_latency":        "P99 latency (peak %v)",
+	"chart.latency":            "Latency percentiles",
+	"chart.throughput":         "Throughput (ops/s)",
+	"chart.errors":             "Errors (by type)",
+	"chart.legend_throughput":  "Throughput",
+	"chart.legend_target_rate": "Target rate",
+	"chart.legend_fault":       "Injected fault",
+	"chart.legend_outage":      "Detected outage",
+	"chart.outage":             "Outage %v, %d failures",
+
+	// 实时进度
+	"progress.elapsed":   "elapsed %v",
+	"progress.summary":   "Operations: %d  Success rate: %.2f%%  Current: %.0f ops/s  Average: %.0f ops/s",
+	"progress.latency":   "Latency (last %v)",
+	"progress.errors":    "Errors",
+	"progress.phase":     "Phase",
+	"progress.trend":     "Throughput: %s (peak %.0f ops/s)",
+	"progress.log":       "ops %d | success %.2f%% | %.0f ops/s",
+	"progress.no_errors": "none",
+}
@@ -0,0 +1,101 @@
+// Package i18n 报告和评估结果的多语言消息目录
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lang 报告语言
+type Lang string
+
+const (
+	// ZhCN 简体中文
+	ZhCN Lang = "zh-CN"
+	// En 英文
+	En Lang = "en"
+
+	// Default 默认语言，未设置语言或缺少翻译时使用
+	Default = ZhCN
+)
+
+// Langs 支持的语言
+var Langs = []Lang{ZhCN, En}
+
+// catalogs 各语言的消息目录：消息key -> fmt格式的消息
+var catalogs = map[Lang]map[string]string{
+	ZhCN: zhCN,
+	En:   en,
+}
+
+// Parse 解析语言名称，不区分大小写，接受 zh、zh-CN、zh_CN、en、en-US 等写法；空字符串为默认语言
+func Parse(name string) (Lang, error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", "-"))
+	if name == "" {
+		return Default, nil
+	}
+	base, _, _ := strings.Cut(name, "-")
+	switch {
+	case name == "zh" || name == "zh-cn" || name == "zh-hans":
+		return ZhCN, nil
+	case base == "en":
+		return En, nil
+	default:
+		return "", fmt.Errorf("unsupported language %q (zh-CN|en)", name)
+	}
+}
+
+// lookup 查找消息，缺少翻译时使用默认语言
+func (l Lang) lookup(key string) (string, bool) {
+	if msg, ok := catalogs[l][key]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[Default][key]
+	return msg, ok
+}
+
+// Has 该语言的消息目录中是否有key（不回退到默认语言）
+func (l Lang) Has(key string) bool {
+	_, ok := catalogs[l][key]
+	return ok
+}
+
+// T 返回key对应的消息，有参数时按 fmt.Sprintf 格式化；消息不存在时返回key
+func (l Lang) T(key string, args ...any) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Lines 返回按行拆分的多行消息，如建议的行动项
+func (l Lang) Lines(key string) []string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return nil
+	}
+	return strings.Split(msg, "\n")
+}
+
+// Name 返回代码（如错误类型、事件类型）的显示名称，key为 group.code，消息不存在时返回代码本身
+func (l Lang) Name(group, code string) string {
+	if msg, ok := l.lookup(group + "." + code); ok {
+		return msg
+	}
+	return code
+}
+
+// Keys 返回该语言消息目录中的所有key，按字母排序
+func Keys(lang Lang) []string {
+	keys := make([]string, 0, len(catalogs[lang]))
+	for key := range catalogs[lang] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,283 @@
+package i18n
+
+// zhCN 简体中文消息目录
+var zhCN = map[string]string{
+	// 评估问题，key为 issue.<问题类型>[.<指标>]
+	"issue.low_availability":           "可用性%.2f%%低于最低要求%.2f%%",
+	"issue.high_p95_latency":           "P95延迟%v超过阈值%v",
+	"issue.high_p99_latency":           "P99延迟%v超过阈值%v",
+	"issue.high_error_rate":            "错误率%.4f%%超过阈值%.2f%%",
+	"issue.data_loss_detected":         "检测到数据丢失，丢失率%.4f%%",
+	"issue.authentication_failures":    "出现%d次认证失败，请检查用户名、密码或SASL配置",
+	"issue.network_errors":             "网络错误%d次，占操作总数%.2f%%",
+	"issue.timeout_errors":             "超时错误%d次，占操作总数%.2f%%",
+	"issue.data_loss_errors":           "%d次操作因数据不可读而失败（如offset超出范围）",
+	"issue.operation_low_availability": "%s操作可用性%.2f%%，低于要求的%.2f%%",
+	"issue.operation_high_error_rate":  "%s操作错误率%.2f%%，高于允许的%.2f%%",
+	"issue.operation_high_latency.p95": "%s操作P95延迟%v，超过%v",
+	"issue.operation_high_latency.p99": "%s操作P99延迟%v，超过%v",
+	"issue.load_generator_behind":      "%.2f%%的操作开始执行时落后计划（最大%v），目标%.0f ops/s，实际%.0f ops/s；延迟已从计划发送时间计算",
+	"issue.unrecovered_outage":         "测试结束时服务仍未恢复，故障已持续%v",
+	"issue.slow_recovery":              "平均恢复时间%v超过阈值%v",
+	"issue.low_reconnect_rate":         "重连成功率%.2f%%低于预期",
+	"issue.high_message_lag":           "消息积压过多",
+	"issue.duplicate_messages":         "检测到%d条重复消息，重复率%.4f%%",
+	"issue.out_of_order_messages":      "检测到分区内消息乱序，乱序率%.4f%%",
+	"issue.high_e2e_latency.p95":       "端到端P95延迟%v超过阈值%v",
+	"issue.high_e2e_latency.p99":       "端到端P99延迟%v超过阈值%v",
+
+	// 改进建议，key为 rec.<建议代码>.title|message|actions，行动项每行一条
+	"rec.improve_availability.title":   "提高系统可用性",
+	"rec.improve_availability.message": "当前可用性不满足生产环境要求",
+	"rec.improve_availability.actions": "检查服务健康状态，排查频繁失败原因\n" +
+		"增加实例数量，实现高可用部署\n" +
+		"配置健康检查和自动重启机制\n" +
+		"实施熔断和降级策略",
+	"rec.reduce_latency.title":   "优化响应延迟",
+	"rec.reduce_latency.message": "延迟指标超出可接受范围",
+	"rec.reduce_latency.actions": "分析慢查询日志，优化热点操作\n" +
+		"检查网络延迟和带宽瓶颈\n" +
+		"优化数据结构和查询模式\n" +
+		"考虑增加缓存层或读写分离\n" +
+		"评估硬件资源是否充足",
+	"rec.reduce_error_rate.title":   "降低错误率",
+	"rec.reduce_error_rate.message": "错误率过高可能导致业务中断",
+	"rec.reduce_error_rate.actions": "查看错误日志，分析错误类型\n" +
+		"检查客户端配置（超时、重试）\n" +
+		"验证服务端配置\n" +
+		"实施错误处理和重试逻辑",
+	"rec.prevent_data_loss.title":   "防止数据丢失",
+	"rec.prevent_data_loss.message": "检测到数据丢失，需立即处理",
+	"rec.prevent_data_loss.actions": "检查持久化配置\n" +
+		"确保有足够的副本数\n" +
+		"配置fsync策略\n" +
+		"实施数据校验机制",
+	"rec.speed_up_recovery.title":   "加快故障恢复",
+	"rec.speed_up_recovery.message": "平均恢复时间过长，影响系统可用性",
+	"rec.speed_up_recovery.actions": "优化健康检查频率和超时设置\n" +
+		"实施更激进的重试策略\n" +
+		"增加备用连接池\n" +
+		"优化故障检测算法",
+	"rec.fix_authentication.title":   "修复认证配置",
+	"rec.fix_authentication.message": "认证失败不会随重试恢复，需要修正凭据或权限",
+	"rec.fix_authentication.actions": "检查 MCT_USERNAME / MCT_PASSWORD 或配置文件中的凭据\n" +
+		"确认Redis ACL用户或Kafka SASL机制配置正确\n" +
+		"检查Kafka Topic/Group的ACL授权",
+	"rec.check_network.title":   "排查网络连接问题",
+	"rec.check_network.message": "大量操作因连接断开或拒绝而失败",
+	"rec.check_network.actions": "检查服务端进程和端口是否可达\n" +
+		"检查服务端最大连接数限制\n" +
+		"确认负载均衡和防火墙未主动断开连接\n" +
+		"启用客户端自动重连",
+	"rec.reduce_timeouts.title":   "减少操作超时",
+	"rec.reduce_timeouts.message": "大量操作超时，服务端响应慢或网络拥塞",
+	"rec.reduce_timeouts.actions": "检查服务端慢日志和资源使用率\n" +
+		"根据P99延迟调整客户端超时时间\n" +
+		"检查网络带宽和丢包情况",
+	"rec.check_retention.title":   "检查消息保留策略",
+	"rec.check_retention.message": "消费位置的消息已被删除，未消费的消息丢失",
+	"rec.check_retention.actions": "增大Topic的retention.ms/retention.bytes\n" +
+		"提高消费速度，减少消息积压\n" +
+		"检查auto.offset.reset策略",
+	"rec.reduce_e2e_latency.title":   "降低端到端延迟",
+	"rec.reduce_e2e_latency.message": "消息从发送到被消费的延迟过高",
+	"rec.reduce_e2e_latency.actions": "减小生产者批处理等待时间（BatchTimeout/linger.ms）\n" +
+		"减小消费者拉取等待时间（MaxWait/fetch.max.wait.ms）\n" +
+		"检查消费者处理能力和消息积压\n" +
+		"检查副本同步延迟（acks=all时）",
+	"rec.enable_idempotence.title":   "启用幂等生产",
+	"rec.enable_idempotence.message": "生产者重试导致消息重复写入",
+	"rec.enable_idempotence.actions": "启用生产者幂等（enable.idempotence=true）\n" +
+		"消费端按业务键或消息ID去重",
+	"rec.preserve_ordering.title":   "保证分区内消息顺序",
+	"rec.preserve_ordering.message": "重试或并发请求导致同一分区内消息乱序",
+	"rec.preserve_ordering.actions": "需要严格顺序时将max.in.flight.requests.per.connection设为1或启用幂等生产\n" +
+		"需要顺序的消息使用相同的Key写入同一分区",
+	"rec.investigate_failing_operations.title":   "排查失败集中的操作",
+	"rec.investigate_failing_operations.message": "部分操作的失败率明显高于整体水平",
+	"rec.investigate_failing_operations.actions": "对照按操作统计的错误分类定位失败原因\n" +
+		"写操作失败时检查主节点状态、只读副本和内存/磁盘配额\n" +
+		"读操作失败时检查副本同步和消费者状态",
+	"rec.optimize_slow_operations.title":   "优化慢操作",
+	"rec.optimize_slow_operations.message": "部分操作的延迟超过阈值，整体延迟可能被其他快速操作掩盖",
+	"rec.optimize_slow_operations.actions": "写操作慢时检查持久化策略（AOF fsync、acks、副本同步）\n" +
+		"检查大Key和值大小\n" +
+		"读操作慢时检查消费者拉取等待时间和批大小",
+	"rec.increase_load_concurrency.title":   "增大负载生成并发",
+	"rec.increase_load_concurrency.message": "worker全部忙碌时操作无法按计划发送，故障期间的排队已计入延迟，但实际负载低于目标",
+	"rec.increase_load_concurrency.actions": "增大并发数（--concurrency），使其不小于 目标速率 × 故障期间的预期延迟\n" +
+		"若仅在故障注入期间落后，属于预期现象，可对照指标趋势确认\n" +
+		"检查负载生成机器的CPU和网络是否成为瓶颈",
+	"rec.improve_reconnect_rate.title":   "提高重连成功率",
+	"rec.improve_reconnect_rate.message": "重连成功率低，影响系统稳定性",
+	"rec.improve_reconnect_rate.actions": "检查网络稳定性\n" +
+		"调整重连间隔和最大重试次数\n" +
+		"实施指数退避算法\n" +
+		"检查服务端连接限制",
+	"rec.improve_cache_hit_rate.title":   "提高缓存命中率",
+	"rec.improve_cache_hit_rate.message": "当前命中率%.2f%%偏低",
+	"rec.improve_cache_hit_rate.actions": "分析缓存键的访问模式\n" +
+		"调整缓存过期策略\n" +
+		"考虑增加缓存容量",
+
+	// 判断依据
+	"rationale.score":                "综合评分: %.2f/100 (%s)",
+	"rationale.scores":               "各维度得分:",
+	"rationale.dimension":            "- %s: %.2f/%d (权重%d%%)",
+	"rationale.faults":               "混沌场景: 测试期间注入 %d 次故障 (%s)，以上结果包含故障期间的表现。",
+	"rationale.outages":              "故障检测: 共 %d 次故障，累计 %v，最长 %v，MTTR %v。",
+	"rationale.e2e_latency":          "端到端延迟: P50 %v，P95 %v，P99 %v（发送确认P95 %v）。",
+	"rationale.open_loop":            "开环负载: 目标 %.0f ops/s，实际 %.0f ops/s，%d 次操作落后计划超过%v（最大 %v）。",
+	"rationale.operation":            "%s 可用性 %.2f%%，P99 %v",
+	"rationale.operations":           "按操作: %s。",
+	"rationale.operations_separator": "；",
+	"rationale.pass":                 "✅ 测试通过: 系统稳定性符合预期，可以用于生产环境。",
+	"rationale.warning":              "⚠️  警告: 系统存在需要关注的问题，建议优化后再部署。",
+	"rationale.fail":                 "❌ 测试失败: 系统稳定性不满足最低要求，不建议用于生产环境。",
+	"rationale.issues":               "发现 %d 个问题需要处理。",
+
+	// 评分维度
+	"dimension.availability": "可用性",
+	"dimension.performance":  "性能",
+	"dimension.reliability":  "可靠性",
+	"dimension.resilience":   "恢复力",
+
+	// 错误类型
+	"error_type.network":        "网络错误",
+	"error_type.timeout":        "超时",
+	"error_type.authentication": "认证失败",
+	"error_type.data_loss":      "数据丢失",
+	"error_type.other":          "其他",
+
+	// 时间线事件
+	"event.phase_start":  "阶段开始",
+	"event.phase_end":    "阶段结束",
+	"event.fault_start":  "故障开始",
+	"event.fault_stop":   "故障结束",
+	"event.fault_inject": "故障注入",
+
+	// 编排器状态
+	"state.running":   "运行中",
+	"state.paused":    "已暂停",
+	"state.stopped":   "已停止",
+	"state.completed": "已完成",
+
+	// 报告标题和段落
+	"report.title":                  "中间件稳定性测试报告",
+	"report.test_info":              "测试信息",
+	"report.duration":               "测试时长",
+	"report.completed":              "测试完成",
+	"report.overall_score":          "总体评分",
+	"report.dimension_scores":       "各维度得分",
+	"report.weight":                 "权重%d%%",
+	"report.key_metrics":            "核心指标",
+	"report.performance":            "性能指标",
+	"report.reliability":            "可靠性",
+	"report.recovery":               "恢复性",
+	"report.by_operation":           "按操作统计",
+	"report.errors_by_type":         "错误分类",
+	"report.outage_windows":         "故障窗口",
+	"report.trends":                 "指标趋势",
+	"report.trends_per_cell":        "每格 %v",
+	"report.trends_note":            "每格 %v，完整的区间数据见JSON报告。",
+	"report.timeline":               "时间线",
+	"report.issues":                 "发现的问题 (%d个)",
+	"report.recommendations":        "改进建议",
+	"report.recommendations_sorted": "改进建议 (按优先级排序)",
+	"report.conclusion":             "结论",
+	"report.category":               "分类",
+	"report.actions":                "具体行动",
+	"report.references":             "参考文档",
+	"report.metric":                 "指标",
+	"report.current":                "当前值",
+	"report.expected":               "期望值",
+	"report.description":            "说明",
+	"report.open_loop_detail":       "目标 %.0f ops/s，%d 次操作落后计划超过 %v（最大 %v）",
+	"report.delivery_detail":        "已确认 %d, 收到 %d, 丢失 %d",
+	"report.unrecovered":            "测试结束时仍未恢复",
+	"report.unrecovered_note":       "（测试结束时仍未恢复）",
+	"report.yes":                    "是",
+	"report.no":                     "否",
+
+	// 指标名称
+	"metric.availability":            "可用性",
+	"metric.availability_rate":       "可用性率",
+	"metric.total_operations":        "总操作数",
+	"metric.successful_operations":   "成功操作",
+	"metric.failed_operations":       "失败操作",
+	"metric.success_failure":         "成功 / 失败",
+	"metric.error_rate":              "错误率",
+	"metric.latency":                 "%s 延迟",
+	"metric.tail_latency":            "尾部延迟",
+	"metric.max_latency":             "最大延迟",
+	"metric.avg_latency":             "平均延迟",
+	"metric.stddev":                  "标准差 %v",
+	"metric.avg_throughput":          "平均吞吐",
+	"metric.open_loop":               "开环负载",
+	"metric.produce_latency":         "发送确认延迟",
+	"metric.e2e_latency":             "端到端延迟",
+	"metric.data_loss_rate":          "数据丢失率",
+	"metric.data_consistency":        "数据一致性",
+	"metric.lost_writes":             "丢失写入",
+	"metric.stale_reads":             "过期读取",
+	"metric.corrupted_reads":         "数据损坏",
+	"metric.delivery":                "消息投递",
+	"metric.duplicate_rate":          "重复率",
+	"metric.out_of_order_rate":       "乱序率",
+	"metric.duplicates_out_of_order": "重复 / 乱序",
+	"metric.outages":                 "故障次数",
+	"metric.total_downtime":          "累计故障时间",
+	"metric.longest_outage":          "最长故障",
+	"metric.reconnect_rate":          "重连成功率",
+
+	// 表格列
+	"column.dimension":         "维度",
+	"column.score":             "得分",
+	"column.percent":           "百分比",
+	"column.weight":            "权重",
+	"column.operation":         "操作",
+	"column.type":              "类型",
+	"column.operations":        "操作数",
+	"column.availability":      "可用性",
+	"column.throughput":        "吞吐",
+	"column.errors":            "错误",
+	"column.error_type":        "错误类型",
+	"column.count":             "次数",
+	"column.share_of_failures": "占失败操作",
+	"column.latency":           "延迟",
+	"column.produce_ack":       "发送确认",
+	"column.end_to_end":        "端到端",
+	"column.start":             "开始",
+	"column.duration":          "持续时间",
+	"column.failed_operations": "失败操作",
+	"column.recovered":         "已恢复",
+	"column.time":              "时间",
+	"column.event":             "事件",
+	"column.phase":             "阶段",
+	"column.fault":             "故障",
+	"column.description":       "说明",
+
+	// 指标趋势和图表
+	"trend.availability":       "可用性",
+	"trend.throughput":         "吞吐量 (峰值 %.0f ops/s)",
+	"trend.target_rate":        "目标速率 (峰值 %.0f ops/s)",
+	"trend.p99_latency":        "P99延迟 (峰值 %v)",
+	"chart.latency":            "延迟百分位",
+	"chart.throughput":         "吞吐量 (ops/s)",
+	"chart.errors":             "错误数（按类型）",
+	"chart.legend_throughput":  "吞吐量",
+	"chart.legend_target_rate": "目标速率",
+	"chart.legend_fault":       "注入故障",
+	"chart.legend_outage":      "检测到的故障窗口",
+	"chart.outage":             "故障 %v，失败 %d 次",
+
+	// 实时进度
+	"progress.elapsed":   "已运行 %v",
+	"progress.summary":   "操作: %d  成功率: %.2f%%  当前吞吐: %.0f ops/s  平均吞吐: %.0f ops/s",
+	"progress.latency":   "延迟 (最近 %v)",
+	"progress.errors":    "错误",
+	"progress.phase":     "阶段",
+	"progress.trend":     "吞吐趋势: %s (峰值 %.0f ops/s)",
+	"progress.log":       "操作 %d | 成功率 %.2f%% | 吞吐 %.0f ops/s",
+	"progress.no_errors": "无",
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"middleware-chaos-testing/internal/core"
+)
+
+// redisAuthPrefixes Redis认证失败的错误前缀
+var redisAuthPrefixes = []string{"NOAUTH", "WRONGPASS", "NOPERM", "ERR invalid password", "ERR AUTH", "ERR Client sent AUTH"}
+
+// ClassifyError 将go-redis/kafka-go返回的错误归类为 core.ErrorType，err为nil时返回空字符串
+//
+// 一致性校验失败（core.ErrDataInconsistent）归类为数据丢失，其余判断顺序：认证 > 超时 > 网络 > 其他。
+// Kafka错误码同时实现了 net.Error，因此先于通用的网络错误判断
+func ClassifyError(err error) core.ErrorType {
+	if err == nil {
+		return ""
+	}
+
+	if errors.Is(err, core.ErrDataInconsistent) {
+		return core.ErrorTypeDataLoss
+	}
+
+	// kafka.WriteErrors 按批次内第一个非nil错误归类
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, e := range writeErrs {
+			if e != nil {
+				return ClassifyError(e)
+			}
+		}
+	}
+
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return classifyKafkaError(kafkaErr)
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		msg := redisErr.Error()
+		for _, prefix := range redisAuthPrefixes {
+			if strings.HasPrefix(msg, prefix) {
+				return core.ErrorTypeAuthentication
+			}
+		}
+		return core.ErrorTypeOther
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) ||
+		strings.Contains(err.Error(), "connection pool timeout") {
+		return core.ErrorTypeTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return core.ErrorTypeTimeout
+	}
+
+	if IsConnectionError(err) || errors.Is(err, redis.ErrClosed) {
+		return core.ErrorTypeNetwork
+	}
+	if errors.As(err, &netErr) {
+		return core.ErrorTypeNetwork
+	}
+
+	// kafka-go的SASL握手失败不一定携带错误码
+	if strings.Contains(strings.ToLower(err.Error()), "sasl") {
+		return core.ErrorTypeAuthentication
+	}
+
+	return core.ErrorTypeOther
+}
+
+// classifyKafkaError 按Kafka错误码归类
+func classifyKafkaError(err kafka.Error) core.ErrorType {
+	switch err {
+	case kafka.SASLAuthenticationFailed, kafka.UnsupportedSASLMechanism, kafka.IllegalSASLState,
+		kafka.TopicAuthorizationFailed, kafka.GroupAuthorizationFailed, kafka.ClusterAuthorizationFailed,
+		kafka.TransactionalIDAuthorizationFailed, kafka.DelegationTokenAuthorizationFailed:
+		return core.ErrorTypeAuthentication
+	case kafka.RequestTimedOut:
+		return core.ErrorTypeTimeout
+	case kafka.NetworkException, kafka.LeaderNotAvailable, kafka.NotLeaderForPartition,
+		kafka.BrokerNotAvailable, kafka.ReplicaNotAvailable, kafka.GroupCoordinatorNotAvailable,
+		kafka.NotCoordinatorForGroup, kafka.NotEnoughReplicas, kafka.NotEnoughReplicasAfterAppend:
+		return core.ErrorTypeNetwork
+	case kafka.OffsetOutOfRange:
+		// 请求的offset已被删除或截断，未消费的消息已不可读
+		return core.ErrorTypeDataLoss
+	}
+
+	if err.Timeout() {
+		return core.ErrorTypeTimeout
+	}
+	return core.ErrorTypeOther
+}
@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/core"
+)
+
+// 投递跟踪使用的消息头
+const (
+	HeaderRunID  = "mct-run-id"  // 测试运行ID，用于忽略其他运行或其他生产者的消息
+	HeaderSeq    = "mct-seq"     // 生产者分配的序列号（十进制）
+	HeaderSentAt = "mct-sent-at" // 发送时间（Unix纳秒，十进制），用于计算端到端延迟
+)
+
+// DefaultDeliveryGrace 测试结束后等待迟到消息的默认宽限期
+const DefaultDeliveryGrace = 10 * time.Second
+
+// sentMessage 一条已发送消息的状态
+type sentMessage struct {
+	start    time.Time // 开始发送时间
+	ackAt    time.Time // 确认时间，零值表示未确认
+	failedAt time.Time // 发送失败（结果未知）的时间，零值表示未失败；失败后不会再确认
+	received bool
+}
+
+// settledAt 发送结果已知的时间，仍在发送中时返回零值
+func (m *sentMessage) settledAt() time.Time {
+	if !m.ackAt.IsZero() {
+		return m.ackAt
+	}
+	return m.failedAt
+}
+
+// seqRange 连续的序列号区间 [lo, hi]
+type seqRange struct {
+	lo, hi uint64
+}
+
+// DeliveryTracker 消息端到端投递跟踪
+//
+// 生产时为每条消息分配序列号并写入消息头，消费端收到消息后与已确认的发送记录核对：
+// 重复收到同一序列号计为重复；同一分区内，若某条消息在另一条已收到的消息开始发送之前就已确认，
+// 却晚于它到达，计为乱序；宽限期结束时仍未收到的已确认消息计为丢失。
+// 发送失败（结果未知）的消息若被收到不计为异常。
+// 同时记录每条消息的发送确认延迟和端到端延迟（发送时间取自消息头）。
+//
+// 消息收到且发送结果已知后即从发送记录中删除；发送结果已知但超过宽限期仍未收到的消息
+// （已确认的计为丢失，发送失败的不再等待）也被删除，只记入过期区间，
+// 内存只与宽限期内未完成的消息数和过期区间数有关，长时间运行时不会随消息数增长。
+// 不在发送记录中的已分配序列号：属于过期区间的是迟到消息，仍按丢失计，其他的说明此前已收到，再次收到计为重复。
+type DeliveryTracker struct {
+	runID string
+	grace time.Duration
+
+	mu         sync.Mutex
+	seq        uint64
+	sent       map[uint64]*sentMessage // 尚未完成（未收到或发送结果未知）的消息
+	oldest     uint64                  // 可能仍在发送记录中的最小序列号
+	expired    []seqRange              // 超过宽限期仍未收到而被删除的序列号，按序列号升序
+	partitions map[int]time.Time       // 每个分区已收到消息的最晚发送开始时间
+	pending    int64                   // 已确认但尚未收到（且未过期）的消息数
+	report     core.DeliveryReport     // Lost 为已过期的丢失消息数
+
+	produceLatency  *collector.Histogram
+	endToEndLatency *collector.Histogram
+}
+
+// NewDeliveryTracker 创建投递跟踪器，runID为空时随机生成
+func NewDeliveryTracker(runID string) *DeliveryTracker {
+	if runID == "" {
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		runID = hex.EncodeToString(buf)
+	}
+	return &DeliveryTracker{
+		runID:           runID,
+		grace:           DefaultDeliveryGrace,
+		oldest:          1,
+		sent:            make(map[uint64]*sentMessage),
+		partitions:      make(map[int]time.Time),
+		produceLatency:  collector.NewHistogram(collector.DefaultLatencyPrecision),
+		endToEndLatency: collector.NewHistogram(collector.DefaultLatencyPrecision),
+	}
+}
+
+// SetGrace 设置等待迟到消息的宽限期，发送结果已知后超过宽限期仍未收到的消息不再跟踪，需在发送之前调用
+func (t *DeliveryTracker) SetGrace(grace time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.grace = grace
+}
+
+// RunID 返回测试运行ID
+func (t *DeliveryTracker) RunID() string {
+	return t.runID
+}
+
+// Stamp 为消息分配序列号并写入消息头，返回序列号
+func (t *DeliveryTracker) Stamp(msg *kafka.Message, start time.Time) uint64 {
+	t.mu.Lock()
+	t.expire(start)
+	t.seq++
+	seq := t.seq
+	t.sent[seq] = &sentMessage{start: start}
+	t.report.Produced++
+	t.mu.Unlock()
+
+	msg.Headers = append(msg.Headers,
+		kafka.Header{Key: HeaderRunID, Value: []byte(t.runID)},
+		kafka.Header{Key: HeaderSeq, Value: []byte(strconv.FormatUint(seq, 10))},
+		kafka.Header{Key: HeaderSentAt, Value: []byte(strconv.FormatInt(start.UnixNano(), 10))},
+	)
+	return seq
+}
+
+// Ack 记录发送结果，ok为false表示结果未知（消息可能已写入）
+func (t *DeliveryTracker) Ack(seq uint64, ok bool, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	defer t.expire(at)
+
+	m := t.sent[seq]
+	if m == nil || !m.settledAt().IsZero() {
+		return
+	}
+	if !ok {
+		m.failedAt = at
+	} else {
+		m.ackAt = at
+		t.report.Acked++
+		t.produceLatency.Record(at.Sub(m.start))
+	}
+	if m.received {
+		delete(t.sent, seq)
+	} else if ok {
+		t.pending++
+	}
+}
+
+// Observe 核对一条在 at 时刻收到的消息，其他运行的消息被忽略
+func (t *DeliveryTracker) Observe(msg kafka.Message, at time.Time) {
+	seq, sentAt, ok := t.parse(msg)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if seq == 0 || seq > t.seq {
+		return
+	}
+	m := t.sent[seq]
+	if m == nil && t.isExpired(seq) {
+		return // 宽限期后才到达，已按丢失或发送失败处理
+	}
+	if m == nil || m.received {
+		t.report.Duplicates++
+		return
+	}
+	m.received = true
+	t.report.Received++
+	if sentAt.IsZero() {
+		sentAt = m.start
+	}
+	t.endToEndLatency.Record(at.Sub(sentAt))
+
+	latest := t.partitions[msg.Partition]
+	if !m.ackAt.IsZero() {
+		t.pending--
+		if m.ackAt.Before(latest) {
+			t.report.OutOfOrder++
+		}
+	}
+	if m.start.After(latest) {
+		t.partitions[msg.Partition] = m.start
+	}
+	if !m.settledAt().IsZero() {
+		delete(t.sent, seq)
+	}
+}
+
+// expire 按序列号顺序删除发送结果已知、到 now 超过宽限期仍未收到的消息，需持有锁
+// 仍在发送中的消息会阻塞其后的消息过期，直到它的发送结果已知（受发送超时限制）
+func (t *DeliveryTracker) expire(now time.Time) {
+	for ; t.oldest <= t.seq; t.oldest++ {
+		m := t.sent[t.oldest]
+		if m == nil {
+			continue
+		}
+		settled := m.settledAt()
+		if settled.IsZero() || now.Sub(settled) < t.grace {
+			return
+		}
+		if !m.ackAt.IsZero() {
+			t.pending--
+			t.report.Lost++
+		}
+		delete(t.sent, t.oldest)
+		if n := len(t.expired); n > 0 && t.expired[n-1].hi+1 == t.oldest {
+			t.expired[n-1].hi = t.oldest
+		} else {
+			t.expired = append(t.expired, seqRange{lo: t.oldest, hi: t.oldest})
+		}
+	}
+}
+
+// isExpired 序列号是否因超过宽限期而被删除，需持有锁
+func (t *DeliveryTracker) isExpired(seq uint64) bool {
+	i := sort.Search(len(t.expired), func(i int) bool { return t.expired[i].hi >= seq })
+	return i < len(t.expired) && t.expired[i].lo <= seq
+}
+
+// parse 从消息头解析序列号和发送时间
+func (t *DeliveryTracker) parse(msg kafka.Message) (uint64, time.Time, bool) {
+	var runID, seq, sentAt []byte
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case HeaderRunID:
+			runID = h.Value
+		case HeaderSeq:
+			seq = h.Value
+		case HeaderSentAt:
+			sentAt = h.Value
+		}
+	}
+	if string(runID) != t.runID {
+		return 0, time.Time{}, false
+	}
+	n, err := strconv.ParseUint(string(seq), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	var sent time.Time
+	if nanos, err := strconv.ParseInt(string(sentAt), 10, 64); err == nil {
+		sent = time.Unix(0, nanos)
+	}
+	return n, sent, true
+}
+
+// Pending 已确认但尚未收到的消息数
+func (t *DeliveryTracker) Pending() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.pending
+}
+
+// Wait 等待所有已确认的消息到达，直到宽限期结束或ctx取消
+func (t *DeliveryTracker) Wait(ctx context.Context, grace time.Duration) {
+	deadline := time.NewTimer(grace)
+	defer deadline.Stop()
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for t.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline.C:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report 返回当前的投递统计，尚未收到的已确认消息计为丢失
+func (t *DeliveryTracker) Report() *core.DeliveryReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := t.report
+	report.Lost += t.pending
+	report.ProduceLatency = summarize(t.produceLatency.Snapshot())
+	report.EndToEndLatency = summarize(t.endToEndLatency.Snapshot())
+	return &report
+}
+
+// Tracked 尚未完成（未收到或发送结果未知）的发送记录数
+func (t *DeliveryTracker) Tracked() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sent)
+}
+
+// summarize 计算延迟分布摘要
+func summarize(s *collector.HistogramSnapshot) core.LatencySummary {
+	return core.LatencySummary{
+		P50: s.Percentile(50),
+		P95: s.Percentile(95),
+		P99: s.Percentile(99),
+		Max: s.Max(),
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// DefaultConsumeMaxWait 工作负载生成的消费操作的默认最大等待时间
+const DefaultConsumeMaxWait = 100 * time.Millisecond
+
+// NewOperation 根据中间件类型和操作名创建操作
+// Redis支持: set, get, delete(del)
+// Kafka支持: produce(write), consume(read)
+func NewOperation(middlewareType, name, key string, value []byte) (core.Operation, error) {
+	switch strings.ToLower(middlewareType) {
+	case "redis":
+		switch strings.ToLower(name) {
+		case "set":
+			return &RedisSetOperation{OpKey: key, OpValue: value}, nil
+		case "get":
+			return &RedisGetOperation{OpKey: key}, nil
+		case "delete", "del":
+			return &RedisDeleteOperation{OpKey: key}, nil
+		}
+	case "kafka":
+		switch strings.ToLower(name) {
+		case "produce", "write":
+			return &KafkaProduceOperation{OpKey: key, OpValue: value}, nil
+		case "consume", "read":
+			return &KafkaConsumeOperation{MaxWait: DefaultConsumeMaxWait}, nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: middleware %q", core.ErrUnsupportedOperation, middlewareType)
+	}
+
+	return nil, fmt.Errorf("%w: %s operation %q", core.ErrUnsupportedOperation, middlewareType, name)
+}
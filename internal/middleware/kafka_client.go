@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -11,13 +12,32 @@ import (
 
 // KafkaClient Kafka客户端实现
 type KafkaClient struct {
-	config   *KafkaConfig
-	writer   *kafka.Writer
-	reader   *kafka.Reader
-	topic    string
-	groupID  string
-	brokers  []string
-	logger   *Logger
+	config      *KafkaConfig
+	mu          sync.RWMutex
+	writer      *kafka.Writer
+	reader      *kafka.Reader
+	users       *sync.WaitGroup // 借用当前Writer/Reader的进行中操作
+	connected   bool
+	topic       string
+	groupID     string
+	brokers     []string
+	logger      *Logger
+	metrics     *kafkaClientMetrics
+	reconnector *reconnector
+
+	// 投递跟踪（未启用时tracker为nil）
+	tracker      *DeliveryTracker
+	trackCancel  context.CancelFunc
+	trackReaders []*kafka.Reader
+	trackWG      sync.WaitGroup
+}
+
+// kafkaClientMetrics Kafka客户端内部指标
+type kafkaClientMetrics struct {
+	mu                       sync.RWMutex
+	activeConnections        int
+	totalConnectionAttempts  int64
+	failedConnectionAttempts int64
 }
 
 // NewKafkaClient 创建新的Kafka客户端
@@ -29,22 +49,202 @@ func NewKafkaClient(config *KafkaConfig) *KafkaClient {
 	logger.Info("Creating new Kafka client: brokers=%v topic=%s groupID=%s",
 		config.Brokers, config.Topic, config.GroupID)
 
-	return &KafkaClient{
+	k := &KafkaClient{
 		config:  config,
 		topic:   config.Topic,
 		groupID: config.GroupID,
 		brokers: config.Brokers,
 		logger:  logger,
+		metrics: &kafkaClientMetrics{},
+		users:   new(sync.WaitGroup),
 	}
+	k.reconnector = newReconnector(*config.Reconnect, config.Timeout, k.reconnect)
+	if config.Verify {
+		k.tracker = NewDeliveryTracker("")
+		k.tracker.SetGrace(config.VerifyGrace)
+	}
+	return k
+}
+
+// SetReconnectObserver 设置重连观察者，实现 core.Reconnectable
+func (k *KafkaClient) SetReconnectObserver(observer core.ReconnectObserver) {
+	k.reconnector.setObserver(observer)
 }
 
 // Connect 连接到Kafka
 func (k *KafkaClient) Connect(ctx context.Context) error {
 	k.logger.Info("Connecting to Kafka: brokers=%v topic=%s", k.brokers, k.topic)
 
-	// 创建Writer（生产者）- 使用业界最佳实践配置
+	k.metrics.mu.Lock()
+	k.metrics.totalConnectionAttempts++
+	k.metrics.mu.Unlock()
+
+	// 测试连接 - 尝试获取topic元数据
+	if err := k.probe(ctx); err != nil {
+		k.logger.Error("Failed to connect to Kafka: %v", err)
+		k.metrics.mu.Lock()
+		k.metrics.failedConnectionAttempts++
+		k.metrics.mu.Unlock()
+		return err
+	}
+
+	writer, reader := k.newWriter(), k.newReader()
+
+	k.mu.Lock()
+	oldWriter, oldReader, users := k.writer, k.reader, k.users
+	k.writer, k.reader, k.users = writer, reader, new(sync.WaitGroup)
+	k.connected = true
+	k.mu.Unlock()
+	retireAfter(users, func() { k.closeAll(oldWriter, oldReader) })
+
+	k.metrics.mu.Lock()
+	k.metrics.activeConnections = 1
+	k.metrics.mu.Unlock()
+	k.reconnector.rearm()
+
+	if err := k.startTracking(ctx); err != nil {
+		k.logger.Error("Failed to start delivery tracking: %v", err)
+		return err
+	}
+
+	k.logger.Info("Successfully connected to Kafka")
+	return nil
+}
+
+// startTracking 启动投递跟踪消费者：每个分区一个独立于消费者组的Reader，从当前末尾offset开始读取
+// 分区Reader在broker故障时自行重试，重连时无需重建；已启动时不重复启动
+func (k *KafkaClient) startTracking(ctx context.Context) error {
+	if k.tracker == nil {
+		return nil
+	}
+	k.mu.RLock()
+	started := k.trackCancel != nil
+	k.mu.RUnlock()
+	if started {
+		return nil
+	}
+
+	dialer := k.dialer()
+	partitions, err := dialer.LookupPartitions(ctx, "tcp", k.brokers[0], k.topic)
+	if err != nil {
+		return fmt.Errorf("failed to lookup partitions: %w", err)
+	}
+
+	readers := make([]*kafka.Reader, 0, len(partitions))
+	closeReaders := func() {
+		for _, reader := range readers {
+			_ = reader.Close()
+		}
+	}
+	for _, p := range partitions {
+		conn, err := dialer.DialLeader(ctx, "tcp", k.brokers[0], k.topic, p.ID)
+		if err != nil {
+			closeReaders()
+			return fmt.Errorf("failed to dial leader of partition %d: %w", p.ID, err)
+		}
+		offset, err := conn.ReadLastOffset()
+		conn.Close()
+		if err != nil {
+			closeReaders()
+			return fmt.Errorf("failed to read last offset of partition %d: %w", p.ID, err)
+		}
+
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   k.brokers,
+			Topic:     k.topic,
+			Partition: p.ID,
+			MinBytes:  1,
+			MaxBytes:  k.config.MaxBytes,
+			MaxWait:   k.config.MaxWait,
+			Dialer:    dialer,
+		})
+		if err := reader.SetOffset(offset); err != nil {
+			reader.Close()
+			closeReaders()
+			return fmt.Errorf("failed to set offset of partition %d: %w", p.ID, err)
+		}
+		readers = append(readers, reader)
+	}
+
+	k.mu.Lock()
+	if k.trackCancel != nil {
+		k.mu.Unlock()
+		closeReaders()
+		return nil
+	}
+	trackCtx, cancel := context.WithCancel(context.Background())
+	k.trackCancel = cancel
+	k.trackReaders = readers
+	k.trackWG.Add(len(readers))
+	k.mu.Unlock()
+
+	for _, reader := range readers {
+		go k.track(trackCtx, reader)
+	}
+
+	k.logger.Info("Delivery tracking started: runID=%s partitions=%d", k.tracker.RunID(), len(readers))
+	return nil
+}
+
+// track 持续读取一个分区的消息并交给跟踪器核对
+func (k *KafkaClient) track(ctx context.Context, reader *kafka.Reader) {
+	defer k.trackWG.Done()
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			k.logger.Debug("Tracking consumer error: %v", err)
+			continue
+		}
+		k.tracker.Observe(msg, time.Now())
+	}
+}
+
+// stopTracking 停止投递跟踪消费者
+func (k *KafkaClient) stopTracking() {
+	k.mu.Lock()
+	cancel, readers := k.trackCancel, k.trackReaders
+	k.trackCancel, k.trackReaders = nil, nil
+	k.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	k.trackWG.Wait()
+	for _, reader := range readers {
+		if err := reader.Close(); err != nil {
+			k.logger.Debug("Failed to close tracking reader: %v", err)
+		}
+	}
+}
+
+// DeliveryReport 等待迟到消息后返回投递校验统计，实现 core.DeliveryVerifier
+// 未启用校验（KafkaConfig.Verify）时返回nil
+func (k *KafkaClient) DeliveryReport(ctx context.Context) *core.DeliveryReport {
+	if k.tracker == nil {
+		return nil
+	}
+	k.tracker.Wait(ctx, k.config.VerifyGrace)
+	return k.tracker.Report()
+}
+
+// probe 连接topic的leader以验证broker可用
+func (k *KafkaClient) probe(ctx context.Context) error {
+	k.logger.Debug("Testing connection to Kafka broker...")
+	conn, err := k.dialer().DialLeader(ctx, "tcp", k.brokers[0], k.topic, 0)
+	if err != nil {
+		return fmt.Errorf("failed to dial leader: %w", err)
+	}
+	return conn.Close()
+}
+
+// newWriter 创建Writer（生产者）- 使用业界最佳实践配置
+func (k *KafkaClient) newWriter() *kafka.Writer {
 	compressionCodec := k.getCompressionCodec()
-	k.writer = &kafka.Writer{
+	writer := &kafka.Writer{
 		Addr:         kafka.TCP(k.brokers...),
 		Topic:        k.topic,
 		Balancer:     &kafka.LeastBytes{}, // 负载均衡
@@ -59,13 +259,19 @@ func (k *KafkaClient) Connect(ctx context.Context) error {
 		WriteTimeout: k.config.Timeout,
 		ReadTimeout:  k.config.Timeout,
 	}
+	if k.config.Dial != nil {
+		writer.Transport = &kafka.Transport{Dial: k.config.Dial}
+	}
 
 	k.logger.Info("Writer configured: batchSize=%d batchTimeout=%v compression=%d acks=%d async=%v",
 		k.config.BatchSize, k.config.BatchTimeout, k.config.Compression,
 		k.config.RequiredAcks, k.config.Async)
+	return writer
+}
 
-	// 创建Reader（消费者）- 使用业界最佳实践配置
-	k.reader = kafka.NewReader(kafka.ReaderConfig{
+// newReader 创建Reader（消费者）- 使用业界最佳实践配置
+func (k *KafkaClient) newReader() *kafka.Reader {
+	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  k.brokers,
 		Topic:    k.topic,
 		GroupID:  k.groupID,
@@ -78,24 +284,69 @@ func (k *KafkaClient) Connect(ctx context.Context) error {
 		HeartbeatInterval: k.config.HeartbeatInterval, // 心跳间隔
 		SessionTimeout:    k.config.SessionTimeout,    // 会话超时
 		RebalanceTimeout:  k.config.RebalanceTimeout,  // 重平衡超时
+		Dialer:            k.dialer(),
 	})
 
 	k.logger.Info("Reader configured: minBytes=%d maxBytes=%d maxWait=%v commitInterval=%v",
 		k.config.MinBytes, k.config.MaxBytes, k.config.MaxWait, k.config.CommitInterval)
+	return reader
+}
 
-	// 测试连接 - 尝试获取topic元数据
-	k.logger.Debug("Testing connection to Kafka broker...")
-	conn, err := kafka.DialLeader(ctx, "tcp", k.brokers[0], k.topic, 0)
-	if err != nil {
-		k.logger.Error("Failed to connect to Kafka: %v", err)
-		return fmt.Errorf("failed to dial leader: %w", err)
+// reconnect 重建Writer和Reader，由 reconnector 在后台调用
+// broker可用后才替换旧的Writer/Reader，旧的在借用它们的操作结束后关闭；期间已断开（Disconnect）则不再重建
+func (k *KafkaClient) reconnect(ctx context.Context) error {
+	if err := k.probe(ctx); err != nil {
+		k.logger.Warn("Reconnect attempt failed: %v", err)
+		return err
 	}
-	conn.Close()
 
-	k.logger.Info("Successfully connected to Kafka")
+	writer, reader := k.newWriter(), k.newReader()
+
+	k.mu.Lock()
+	if !k.connected {
+		k.mu.Unlock()
+		k.closeAll(writer, reader)
+		return nil
+	}
+	oldWriter, oldReader, users := k.writer, k.reader, k.users
+	k.writer, k.reader, k.users = writer, reader, new(sync.WaitGroup)
+	k.mu.Unlock()
+
+	retireAfter(users, func() { k.closeAll(oldWriter, oldReader) })
+	k.logger.Info("Reconnected to Kafka")
 	return nil
 }
 
+// acquire 借用当前的Writer和Reader（未连接时为nil），操作结束后须调用release
+func (k *KafkaClient) acquire() (writer *kafka.Writer, reader *kafka.Reader, release func()) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	k.users.Add(1)
+	return k.writer, k.reader, k.users.Done
+}
+
+// current Writer是否仍是当前的（未被重连替换）
+func (k *KafkaClient) current(writer *kafka.Writer) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.writer == writer
+}
+
+// closeAll 关闭被替换的Writer和Reader
+func (k *KafkaClient) closeAll(writer *kafka.Writer, reader *kafka.Reader) {
+	if writer != nil {
+		if err := writer.Close(); err != nil {
+			k.logger.Debug("Failed to close replaced writer: %v", err)
+		}
+	}
+	if reader != nil {
+		if err := reader.Close(); err != nil {
+			k.logger.Debug("Failed to close replaced reader: %v", err)
+		}
+	}
+}
+
 // getCompressionCodec 获取压缩编码器
 func (k *KafkaClient) getCompressionCodec() kafka.Compression {
 	switch k.config.Compression {
@@ -117,10 +368,18 @@ func (k *KafkaClient) getCompressionCodec() kafka.Compression {
 // Disconnect 断开连接
 func (k *KafkaClient) Disconnect(ctx context.Context) error {
 	k.logger.Info("Disconnecting from Kafka...")
+	k.reconnector.stop()
+	k.stopTracking()
+
+	k.mu.Lock()
+	writer, reader := k.writer, k.reader
+	k.connected = false
+	k.mu.Unlock()
+
 	var errs []error
 
-	if k.writer != nil {
-		if err := k.writer.Close(); err != nil {
+	if writer != nil {
+		if err := writer.Close(); err != nil {
 			k.logger.Error("Failed to close writer: %v", err)
 			errs = append(errs, fmt.Errorf("failed to close writer: %w", err))
 		} else {
@@ -128,8 +387,8 @@ func (k *KafkaClient) Disconnect(ctx context.Context) error {
 		}
 	}
 
-	if k.reader != nil {
-		if err := k.reader.Close(); err != nil {
+	if reader != nil {
+		if err := reader.Close(); err != nil {
 			k.logger.Error("Failed to close reader: %v", err)
 			errs = append(errs, fmt.Errorf("failed to close reader: %w", err))
 		} else {
@@ -137,6 +396,10 @@ func (k *KafkaClient) Disconnect(ctx context.Context) error {
 		}
 	}
 
+	k.metrics.mu.Lock()
+	k.metrics.activeConnections = 0
+	k.metrics.mu.Unlock()
+
 	if len(errs) > 0 {
 		k.logger.Error("Disconnect completed with errors: %v", errs)
 		return fmt.Errorf("disconnect errors: %v", errs)
@@ -151,20 +414,31 @@ func (k *KafkaClient) Execute(ctx context.Context, op core.Operation) (*core.Res
 	startTime := time.Now()
 	k.logger.Debug("Executing operation: type=%s key=%s", op.Type(), op.Key())
 
+	writer, reader, release := k.acquire()
+	defer release()
+
 	var result *core.Result
 	var err error
 
 	switch op.Type() {
 	case core.OpTypeWrite:
-		result, err = k.executeProduce(ctx, op, startTime)
+		result, err = k.executeProduce(ctx, writer, op, startTime)
 	case core.OpTypeRead:
-		result, err = k.executeConsume(ctx, op, startTime)
+		result, err = k.executeConsume(ctx, reader, op, startTime)
 	default:
 		duration := time.Since(startTime)
 		opErr := fmt.Errorf("unsupported operation type: %s", op.Type())
 		k.logger.Error("Operation failed: %v", opErr)
 		return core.NewResult(false, duration, opErr), nil
 	}
+	if result == nil {
+		return nil, err
+	}
+
+	// 连接断开时在后台自动重连；已被替换的旧Writer/Reader上的失败无需再次重连
+	if k.current(writer) && k.reconnector.trigger(result.Error) {
+		k.logger.Warn("Connection lost, reconnecting: %v", result.Error)
+	}
 
 	// 记录操作日志
 	opLog := &OperationLog{
@@ -184,7 +458,7 @@ func (k *KafkaClient) Execute(ctx context.Context, op core.Operation) (*core.Res
 }
 
 // executeProduce 执行生产消息操作
-func (k *KafkaClient) executeProduce(ctx context.Context, op core.Operation, startTime time.Time) (*core.Result, error) {
+func (k *KafkaClient) executeProduce(ctx context.Context, writer *kafka.Writer, op core.Operation, startTime time.Time) (*core.Result, error) {
 	// 构造Kafka消息
 	msg := kafka.Message{
 		Key:   []byte(op.Key()),
@@ -204,9 +478,24 @@ func (k *KafkaClient) executeProduce(ctx context.Context, op core.Operation, sta
 		op.Key(), topic, len(op.Value()))
 
 	// 发送消息
-	err := k.writer.WriteMessages(ctx, msg)
+	if writer == nil {
+		return nil, core.ErrClientNotConnected
+	}
+
+	// 只跟踪发往默认topic的消息
+	tracked := k.tracker != nil && msg.Topic == ""
+	var seq uint64
+	if tracked {
+		seq = k.tracker.Stamp(&msg, startTime)
+	}
+
+	err := writer.WriteMessages(ctx, msg)
 	duration := time.Since(startTime)
 
+	if tracked {
+		k.tracker.Ack(seq, err == nil, time.Now())
+	}
+
 	if err != nil {
 		k.logger.Error("Failed to produce message: key=%s topic=%s error=%v duration=%v",
 			op.Key(), topic, err, duration)
@@ -219,7 +508,7 @@ func (k *KafkaClient) executeProduce(ctx context.Context, op core.Operation, sta
 }
 
 // executeConsume 执行消费消息操作
-func (k *KafkaClient) executeConsume(ctx context.Context, op core.Operation, startTime time.Time) (*core.Result, error) {
+func (k *KafkaClient) executeConsume(ctx context.Context, reader *kafka.Reader, op core.Operation, startTime time.Time) (*core.Result, error) {
 	// 设置读取超时
 	readCtx := ctx
 	maxWait := k.config.MaxWait
@@ -236,7 +525,11 @@ func (k *KafkaClient) executeConsume(ctx context.Context, op core.Operation, sta
 		k.topic, k.groupID, maxWait)
 
 	// 读取消息
-	msg, err := k.reader.ReadMessage(readCtx)
+	if reader == nil {
+		return nil, core.ErrClientNotConnected
+	}
+
+	msg, err := reader.ReadMessage(readCtx)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -265,9 +558,22 @@ func (k *KafkaClient) executeConsume(ctx context.Context, op core.Operation, sta
 	return result, nil
 }
 
+// dialer 创建Kafka拨号器，配置了 Dial 时所有连接经由该函数建立
+func (k *KafkaClient) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:   k.config.Timeout,
+		DualStack: true,
+		DialFunc:  k.config.Dial,
+	}
+}
+
 // Ping 检查连接是否正常
 func (k *KafkaClient) Ping(ctx context.Context) error {
-	if k.writer == nil {
+	k.mu.RLock()
+	writer := k.writer
+	k.mu.RUnlock()
+
+	if writer == nil {
 		k.logger.Error("Ping failed: writer not initialized")
 		return fmt.Errorf("writer not initialized")
 	}
@@ -275,7 +581,7 @@ func (k *KafkaClient) Ping(ctx context.Context) error {
 	k.logger.Debug("Pinging Kafka broker: %s", k.brokers[0])
 
 	// 尝试连接到broker
-	conn, err := kafka.DialLeader(ctx, "tcp", k.brokers[0], k.topic, 0)
+	conn, err := k.dialer().DialLeader(ctx, "tcp", k.brokers[0], k.topic, 0)
 	if err != nil {
 		k.logger.Error("Ping failed: %v", err)
 		return fmt.Errorf("failed to ping kafka: %w", err)
@@ -286,12 +592,44 @@ func (k *KafkaClient) Ping(ctx context.Context) error {
 	return nil
 }
 
+// HealthCheck 健康检查
+func (k *KafkaClient) HealthCheck(ctx context.Context) error {
+	k.mu.RLock()
+	writer := k.writer
+	k.mu.RUnlock()
+
+	if writer == nil {
+		return core.ErrClientNotConnected
+	}
+	return k.Ping(ctx)
+}
+
+// GetMetrics 获取客户端指标
+func (k *KafkaClient) GetMetrics() *core.ClientMetrics {
+	attempts, successful := k.reconnector.stats()
+
+	k.metrics.mu.RLock()
+	defer k.metrics.mu.RUnlock()
+
+	return &core.ClientMetrics{
+		ActiveConnections:        k.metrics.activeConnections,
+		TotalConnectionAttempts:  k.metrics.totalConnectionAttempts,
+		FailedConnectionAttempts: k.metrics.failedConnectionAttempts,
+		ReconnectAttempts:        attempts,
+		SuccessfulReconnects:     successful,
+	}
+}
+
 // GetStats 获取统计信息
 func (k *KafkaClient) GetStats() map[string]interface{} {
 	stats := make(map[string]interface{})
 
-	if k.writer != nil {
-		writerStats := k.writer.Stats()
+	k.mu.RLock()
+	writer, reader := k.writer, k.reader
+	k.mu.RUnlock()
+
+	if writer != nil {
+		writerStats := writer.Stats()
 		stats["writer_messages"] = writerStats.Messages
 		stats["writer_bytes"] = writerStats.Bytes
 		stats["writer_errors"] = writerStats.Errors
@@ -299,8 +637,8 @@ func (k *KafkaClient) GetStats() map[string]interface{} {
 			writerStats.Messages, writerStats.Bytes, writerStats.Errors)
 	}
 
-	if k.reader != nil {
-		readerStats := k.reader.Stats()
+	if reader != nil {
+		readerStats := reader.Stats()
 		stats["reader_messages"] = readerStats.Messages
 		stats["reader_bytes"] = readerStats.Bytes
 		stats["reader_errors"] = readerStats.Errors
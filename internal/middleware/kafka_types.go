@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"time"
 
 	"middleware-chaos-testing/internal/core"
@@ -35,6 +37,16 @@ type KafkaConfig struct {
 	// 连接池配置
 	MaxIdleConns int           // 最大空闲连接数（默认：10）
 	IdleTimeout  time.Duration // 空闲连接超时（默认：30s）
+
+	// Dial 自定义拨号函数（可选），用于经由故障注入代理连接所有broker
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// Reconnect 自动重连策略（默认：DefaultReconnectPolicy）
+	Reconnect *ReconnectPolicy
+
+	// Verify 启用端到端投递跟踪：消息头携带运行ID和序列号，由独立的消费者核对丢失、重复和乱序
+	Verify      bool
+	VerifyGrace time.Duration // 测试结束后等待迟到消息的宽限期（默认：10s）
 }
 
 // ApplyDefaults 应用默认配置（业界最佳实践）
@@ -93,6 +105,16 @@ func (c *KafkaConfig) ApplyDefaults() {
 	if c.IdleTimeout == 0 {
 		c.IdleTimeout = 30 * time.Second
 	}
+
+	if c.VerifyGrace == 0 {
+		c.VerifyGrace = DefaultDeliveryGrace
+	}
+
+	// 重连配置
+	if c.Reconnect == nil {
+		policy := DefaultReconnectPolicy()
+		c.Reconnect = &policy
+	}
 }
 
 // KafkaProduceOperation 生产消息操作
@@ -106,6 +128,10 @@ func (k *KafkaProduceOperation) Type() core.OperationType {
 	return core.OpTypeWrite
 }
 
+func (k *KafkaProduceOperation) Name() string {
+	return "produce"
+}
+
 func (k *KafkaProduceOperation) Key() string {
 	return k.OpKey
 }
@@ -132,6 +158,10 @@ func (k *KafkaConsumeOperation) Type() core.OperationType {
 	return core.OpTypeRead
 }
 
+func (k *KafkaConsumeOperation) Name() string {
+	return "consume"
+}
+
 func (k *KafkaConsumeOperation) Key() string {
 	return ""
 }
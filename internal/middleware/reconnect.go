@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// ReconnectPolicy 自动重连策略（指数退避 + 随机抖动）
+// 第n次尝试前等待 min(InitialBackoff * Multiplier^(n-1), MaxBackoff)，再叠加 ±Jitter 比例的随机抖动
+type ReconnectPolicy struct {
+	MaxAttempts    int           // 每轮重连的最大尝试次数，0表示不自动重连
+	InitialBackoff time.Duration // 首次重连前的等待时间
+	MaxBackoff     time.Duration // 最大等待时间
+	Multiplier     float64       // 退避倍数
+	Jitter         float64       // 随机抖动比例（0-1）
+}
+
+// DefaultReconnectPolicy 默认重连策略：最多10次，100ms起指数退避至5s，20%抖动
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Enabled 是否启用自动重连
+func (p ReconnectPolicy) Enabled() bool {
+	return p.MaxAttempts > 0
+}
+
+// Backoff 返回第 attempt 次（从1开始）重连前的等待时间
+func (p ReconnectPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(backoff)
+}
+
+// IsConnectionError 判断错误是否表示连接已断开（需要重连）
+// 超时不视为连接断开：延迟故障下重建连接并不能恢复服务
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return !opErr.Timeout()
+	}
+	return false
+}
+
+// retireAfter 在借用旧连接的操作（users）全部结束后于后台关闭旧连接
+// 调用前旧连接须已被替换，不会再有新的操作借用它；
+// 立即关闭会让仍在使用旧连接的worker得到 "client is closed" 之类的错误并被计为故障
+func retireAfter(users *sync.WaitGroup, close func()) {
+	go func() {
+		users.Wait()
+		close()
+	}()
+}
+
+// reconnector 在后台按重连策略重建连接
+// 同一时间最多只有一轮重连；一轮用尽 MaxAttempts 后暂停自动重连，
+// 直到下一次成功的操作或 Connect（服务恢复后再次中断时仍会重连并计入统计）
+type reconnector struct {
+	policy  ReconnectPolicy
+	connect func(ctx context.Context) error
+	timeout time.Duration // 单次重连尝试的超时
+
+	mu         sync.Mutex
+	observer   core.ReconnectObserver
+	running    bool
+	exhausted  atomic.Bool // 上一轮重连用尽尝试次数
+	cancel     context.CancelFunc
+	done       chan struct{}
+	attempts   int64
+	successful int64
+}
+
+func newReconnector(policy ReconnectPolicy, timeout time.Duration, connect func(ctx context.Context) error) *reconnector {
+	return &reconnector{
+		policy:  policy,
+		connect: connect,
+		timeout: timeout,
+	}
+}
+
+// setObserver 设置重连观察者
+func (r *reconnector) setObserver(observer core.ReconnectObserver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observer = observer
+}
+
+// trigger 每次操作后调用：err 为连接错误时启动一轮后台重连；
+// err 为nil表示服务可用，重新允许已用尽的自动重连
+func (r *reconnector) trigger(err error) bool {
+	if !r.policy.Enabled() {
+		return false
+	}
+	if err == nil {
+		if r.exhausted.Load() {
+			r.exhausted.Store(false)
+		}
+		return false
+	}
+	if !IsConnectionError(err) {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running || r.exhausted.Load() {
+		return false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.running = true
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.loop(ctx, r.done)
+	return true
+}
+
+// loop 执行一轮重连，直到成功、用尽尝试次数或被停止
+func (r *reconnector) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	succeeded := false
+	defer func() {
+		r.mu.Lock()
+		r.running = false
+		r.exhausted.Store(!succeeded && ctx.Err() == nil)
+		r.mu.Unlock()
+	}()
+
+	for attempt := 1; attempt <= r.policy.MaxAttempts; attempt++ {
+		timer := time.NewTimer(r.policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		start := time.Now()
+		err := r.connect(attemptCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		r.record(err == nil, time.Since(start))
+		if err == nil {
+			succeeded = true
+			return
+		}
+	}
+}
+
+// record 记录一次重连尝试
+func (r *reconnector) record(success bool, duration time.Duration) {
+	r.mu.Lock()
+	r.attempts++
+	if success {
+		r.successful++
+	}
+	observer := r.observer
+	r.mu.Unlock()
+
+	if observer != nil {
+		observer.RecordReconnectAttempt(success, duration)
+	}
+}
+
+// stop 停止进行中的重连并等待其退出
+func (r *reconnector) stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// rearm 连接成功后重新允许自动重连
+func (r *reconnector) rearm() {
+	r.exhausted.Store(false)
+}
+
+// stats 返回重连尝试次数和成功次数
+func (r *reconnector) stats() (attempts, successful int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts, r.successful
+}
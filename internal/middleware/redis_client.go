@@ -12,10 +12,13 @@ import (
 
 // RedisClient Redis客户端实现
 type RedisClient struct {
-	config  *RedisConfig
-	client  *redis.Client
-	mu      sync.RWMutex
-	metrics *redisClientMetrics
+	config      *RedisConfig
+	client      *redis.Client
+	users       *sync.WaitGroup // 借用当前client的进行中操作
+	mu          sync.RWMutex
+	metrics     *redisClientMetrics
+	reconnector *reconnector
+	verifier    *writeVerifier // 未启用校验时为nil
 }
 
 // redisClientMetrics Redis客户端内部指标
@@ -28,7 +31,7 @@ type redisClientMetrics struct {
 
 // NewRedisClient 创建新的Redis客户端
 func NewRedisClient(config *RedisConfig) *RedisClient {
-	return &RedisClient{
+	r := &RedisClient{
 		config: config,
 		metrics: &redisClientMetrics{
 			activeConnections:        0,
@@ -36,6 +39,34 @@ func NewRedisClient(config *RedisConfig) *RedisClient {
 			failedConnectionAttempts: 0,
 		},
 	}
+
+	policy := DefaultReconnectPolicy()
+	if config.Reconnect != nil {
+		policy = *config.Reconnect
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	r.reconnector = newReconnector(policy, timeout, r.reconnect)
+	if config.Verify {
+		r.verifier = newWriteVerifier()
+	}
+	return r
+}
+
+// ConsistencyReport 返回读后写一致性校验统计，实现 core.ConsistencyVerifier
+// 未启用校验（RedisConfig.Verify）时返回nil
+func (r *RedisClient) ConsistencyReport() *core.ConsistencyReport {
+	if r.verifier == nil {
+		return nil
+	}
+	return r.verifier.snapshot()
+}
+
+// SetReconnectObserver 设置重连观察者，实现 core.Reconnectable
+func (r *RedisClient) SetReconnectObserver(observer core.ReconnectObserver) {
+	r.reconnector.setObserver(observer)
 }
 
 // Connect 建立连接
@@ -49,16 +80,35 @@ func (r *RedisClient) Connect(ctx context.Context) error {
 	r.metrics.mu.Unlock()
 
 	// 如果已经连接，直接返回（幂等性）
-	if r.client != nil {
+	if old := r.client; old != nil {
 		// 验证连接是否有效
-		if err := r.client.Ping(ctx).Err(); err == nil {
+		if err := old.Ping(ctx).Err(); err == nil {
 			return nil
 		}
-		// 连接无效，关闭旧连接
-		_ = r.client.Close()
+		// 连接无效，待进行中的操作结束后关闭旧连接
+		retireAfter(r.users, func() { _ = old.Close() })
 		r.client = nil
 	}
 
+	client, err := r.dial(ctx)
+	if err != nil {
+		r.metrics.mu.Lock()
+		r.metrics.failedConnectionAttempts++
+		r.metrics.mu.Unlock()
+		return err
+	}
+
+	r.client, r.users = client, new(sync.WaitGroup)
+	r.metrics.mu.Lock()
+	r.metrics.activeConnections = 1
+	r.metrics.mu.Unlock()
+	r.reconnector.rearm()
+
+	return nil
+}
+
+// dial 创建新的Redis客户端并验证连接
+func (r *RedisClient) dial(ctx context.Context) (*redis.Client, error) {
 	// 创建Redis客户端配置
 	options := &redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", r.config.Host, r.config.Port),
@@ -78,23 +128,57 @@ func (r *RedisClient) Connect(ctx context.Context) error {
 
 	// 测试连接
 	if err := client.Ping(ctx).Err(); err != nil {
-		r.metrics.mu.Lock()
-		r.metrics.failedConnectionAttempts++
-		r.metrics.mu.Unlock()
 		_ = client.Close()
-		return fmt.Errorf("%w: %v", core.ErrConnectionFailed, err)
+		return nil, fmt.Errorf("%w: %v", core.ErrConnectionFailed, err)
 	}
+	return client, nil
+}
 
-	r.client = client
-	r.metrics.mu.Lock()
-	r.metrics.activeConnections = 1
-	r.metrics.mu.Unlock()
+// reconnect 重建连接，由 reconnector 在后台调用
+// 新连接验证成功后替换旧连接，旧连接在借用它的操作结束后关闭；期间已断开（Disconnect）则丢弃新连接
+func (r *RedisClient) reconnect(ctx context.Context) error {
+	client, err := r.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	old, users := r.client, r.users
+	if old == nil {
+		r.mu.Unlock()
+		return client.Close()
+	}
+	r.client, r.users = client, new(sync.WaitGroup)
+	r.mu.Unlock()
 
+	retireAfter(users, func() { _ = old.Close() })
 	return nil
 }
 
+// acquire 借用当前连接，操作结束后须调用release；未连接时返回nil
+func (r *RedisClient) acquire() (client *redis.Client, release func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.client == nil {
+		return nil, nil
+	}
+	r.users.Add(1)
+	return r.client, r.users.Done
+}
+
+// current 连接是否仍是当前连接（未被重连替换）
+func (r *RedisClient) current(client *redis.Client) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.client == client
+}
+
 // Disconnect 断开连接
 func (r *RedisClient) Disconnect(ctx context.Context) error {
+	// 先停止后台重连，reconnect 需要获取 r.mu
+	r.reconnector.stop()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -114,27 +198,34 @@ func (r *RedisClient) Disconnect(ctx context.Context) error {
 
 // Execute 执行操作
 func (r *RedisClient) Execute(ctx context.Context, op core.Operation) (*core.Result, error) {
-	r.mu.RLock()
-	client := r.client
-	r.mu.RUnlock()
-
+	client, release := r.acquire()
 	if client == nil {
 		return nil, core.ErrClientNotConnected
 	}
+	defer release()
 
 	startTime := time.Now()
 
+	var result *core.Result
+	var err error
+
 	// 根据操作类型执行不同的命令
 	switch v := op.(type) {
 	case *RedisSetOperation:
-		return r.executeSet(ctx, client, v, startTime)
+		result, err = r.executeSet(ctx, client, v, startTime)
 	case *RedisGetOperation:
-		return r.executeGet(ctx, client, v, startTime)
+		result, err = r.executeGet(ctx, client, v, startTime)
 	case *RedisDeleteOperation:
-		return r.executeDelete(ctx, client, v, startTime)
+		result, err = r.executeDelete(ctx, client, v, startTime)
 	default:
 		return nil, fmt.Errorf("%w: %T", core.ErrUnsupportedOperation, op)
 	}
+
+	// 连接断开时在后台自动重连；已被替换的旧连接上的失败无需再次重连
+	if r.current(client) {
+		r.reconnector.trigger(err)
+	}
+	return result, err
 }
 
 // executeSet 执行SET操作
@@ -144,9 +235,18 @@ func (r *RedisClient) executeSet(
 	op *RedisSetOperation,
 	startTime time.Time,
 ) (*core.Result, error) {
+	var write *writeRecord
+	if r.verifier != nil {
+		write = r.verifier.beginWrite(op.Key(), op.Value(), false, startTime)
+	}
+
 	err := client.Set(ctx, op.Key(), op.Value(), 0).Err()
 	duration := time.Since(startTime)
 
+	if write != nil {
+		r.verifier.endWrite(op.Key(), write, err == nil, time.Now())
+	}
+
 	if err != nil {
 		return &core.Result{
 			Success:   false,
@@ -172,9 +272,34 @@ func (r *RedisClient) executeGet(
 	op *RedisGetOperation,
 	startTime time.Time,
 ) (*core.Result, error) {
+	var readID uint64
+	if r.verifier != nil {
+		readID = r.verifier.beginRead(startTime)
+	}
+
 	val, err := client.Get(ctx, op.Key()).Bytes()
 	duration := time.Since(startTime)
 
+	// 校验读取结果，不一致的读取视为失败（ErrorTypeDataLoss）
+	if r.verifier != nil {
+		var verr error
+		if err == nil || err == redis.Nil {
+			verr = r.verifier.endRead(readID, op.Key(), val, err == nil, time.Now())
+		} else {
+			r.verifier.endRead(readID, "", nil, false, time.Now())
+		}
+		if verr != nil {
+			return &core.Result{
+				Success:   false,
+				Duration:  duration,
+				Data:      val,
+				Error:     verr,
+				Timestamp: time.Now(),
+				Metadata:  make(map[string]interface{}),
+			}, verr
+		}
+	}
+
 	// Redis的GET命令，键不存在时返回redis.Nil错误
 	// 这不算操作失败，而是正常的空值返回
 	if err == redis.Nil {
@@ -213,9 +338,18 @@ func (r *RedisClient) executeDelete(
 	op *RedisDeleteOperation,
 	startTime time.Time,
 ) (*core.Result, error) {
+	var write *writeRecord
+	if r.verifier != nil {
+		write = r.verifier.beginWrite(op.Key(), nil, true, startTime)
+	}
+
 	err := client.Del(ctx, op.Key()).Err()
 	duration := time.Since(startTime)
 
+	if write != nil {
+		r.verifier.endWrite(op.Key(), write, err == nil, time.Now())
+	}
+
 	if err != nil {
 		return &core.Result{
 			Success:   false,
@@ -236,19 +370,19 @@ func (r *RedisClient) executeDelete(
 
 // HealthCheck 健康检查
 func (r *RedisClient) HealthCheck(ctx context.Context) error {
-	r.mu.RLock()
-	client := r.client
-	r.mu.RUnlock()
-
+	client, release := r.acquire()
 	if client == nil {
 		return core.ErrClientNotConnected
 	}
+	defer release()
 
 	return client.Ping(ctx).Err()
 }
 
 // GetMetrics 获取客户端指标
 func (r *RedisClient) GetMetrics() *core.ClientMetrics {
+	attempts, successful := r.reconnector.stats()
+
 	r.metrics.mu.RLock()
 	defer r.metrics.mu.RUnlock()
 
@@ -256,5 +390,7 @@ func (r *RedisClient) GetMetrics() *core.ClientMetrics {
 		ActiveConnections:        r.metrics.activeConnections,
 		TotalConnectionAttempts:  r.metrics.totalConnectionAttempts,
 		FailedConnectionAttempts: r.metrics.failedConnectionAttempts,
+		ReconnectAttempts:        attempts,
+		SuccessfulReconnects:     successful,
 	}
 }
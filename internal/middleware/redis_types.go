@@ -13,6 +13,12 @@ type RedisConfig struct {
 	Password string        // 密码
 	DB       int           // 数据库编号
 	Timeout  time.Duration // 超时时间
+
+	// Reconnect 自动重连策略，nil表示使用 DefaultReconnectPolicy
+	Reconnect *ReconnectPolicy
+
+	// Verify 启用读后写一致性校验：跟踪SET/DEL，校验GET是否返回最新已确认的值
+	Verify bool
 }
 
 // RedisClient 的完整实现在 redis_client.go 中
@@ -27,6 +33,10 @@ func (r *RedisSetOperation) Type() core.OperationType {
 	return core.OpTypeWrite
 }
 
+func (r *RedisSetOperation) Name() string {
+	return "set"
+}
+
 func (r *RedisSetOperation) Key() string {
 	return r.OpKey
 }
@@ -48,6 +58,10 @@ func (r *RedisGetOperation) Type() core.OperationType {
 	return core.OpTypeRead
 }
 
+func (r *RedisGetOperation) Name() string {
+	return "get"
+}
+
 func (r *RedisGetOperation) Key() string {
 	return r.OpKey
 }
@@ -69,6 +83,10 @@ func (r *RedisDeleteOperation) Type() core.OperationType {
 	return core.OpTypeDelete
 }
 
+func (r *RedisDeleteOperation) Name() string {
+	return "delete"
+}
+
 func (r *RedisDeleteOperation) Key() string {
 	return r.OpKey
 }
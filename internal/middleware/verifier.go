@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// maxOverwrittenDigests 每个键保留的已覆盖值摘要数，用于识别过期读取
+const maxOverwrittenDigests = 16
+
+// writeRecord 一次写入（SET或DEL）
+type writeRecord struct {
+	version uint64
+	digest  uint64
+	deleted bool
+	start   time.Time
+	end     time.Time // 零值表示进行中
+	acked   bool      // 是否已确认；失败的写入结果未知，可能已生效
+}
+
+// keyHistory 单个键的写入历史
+type keyHistory struct {
+	writes      []*writeRecord // 可能仍然可见的写入，按版本递增
+	overwritten []uint64       // 已确定被覆盖的值摘要
+}
+
+// writeVerifier 读后写一致性校验器
+//
+// 记录每个键的写入（版本、值摘要、开始/确认时间），校验读取结果：
+// 读取开始前已确认、且未被后续写入确定覆盖的值，以及与读取并发或结果未知的写入，
+// 都是合法的读取结果。否则按情况计为丢失写入（键不存在）、过期读取（旧值）或数据损坏（未知值）。
+// 读取开始前没有已确认写入的键不参与校验（例如测试开始前已存在的键）。
+// 内存占用与写入过的键数成正比。
+type writeVerifier struct {
+	mu       sync.Mutex
+	keys     map[string]*keyHistory
+	version  uint64
+	inflight map[uint64]time.Time // 进行中的读取开始时间
+	readSeq  uint64
+	lost     map[uint64]struct{} // 已计为丢失的写入版本
+	report   core.ConsistencyReport
+}
+
+func newWriteVerifier() *writeVerifier {
+	return &writeVerifier{
+		keys:     make(map[string]*keyHistory),
+		inflight: make(map[uint64]time.Time),
+		lost:     make(map[uint64]struct{}),
+	}
+}
+
+func digest(value []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(value)
+	return h.Sum64()
+}
+
+// beginWrite 记录写入开始，value为nil且deleted为true表示删除
+func (v *writeVerifier) beginWrite(key string, value []byte, deleted bool, start time.Time) *writeRecord {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.version++
+	w := &writeRecord{version: v.version, deleted: deleted, start: start}
+	if !deleted {
+		w.digest = digest(value)
+	}
+
+	h := v.keys[key]
+	if h == nil {
+		h = &keyHistory{}
+		v.keys[key] = h
+	}
+	h.writes = append(h.writes, w)
+	return w
+}
+
+// endWrite 记录写入结束
+func (v *writeVerifier) endWrite(key string, w *writeRecord, ok bool, end time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	w.end = end
+	w.acked = ok
+	if ok && !w.deleted {
+		v.report.AckedWrites++
+	}
+	if h := v.keys[key]; h != nil {
+		v.prune(h)
+	}
+}
+
+// beginRead 记录读取开始，返回读取ID
+func (v *writeVerifier) beginRead(start time.Time) uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.readSeq++
+	v.inflight[v.readSeq] = start
+	return v.readSeq
+}
+
+// endRead 校验读取结果，不一致时返回包装了 core.ErrDataInconsistent 的错误
+func (v *writeVerifier) endRead(id uint64, key string, value []byte, found bool, end time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	start := v.inflight[id]
+	delete(v.inflight, id)
+
+	h := v.keys[key]
+	if h == nil {
+		return nil
+	}
+
+	hasFloor := false
+	for _, w := range h.writes {
+		if w.acked && w.end.Before(start) {
+			hasFloor = true
+			break
+		}
+	}
+	if !hasFloor {
+		return nil
+	}
+	v.report.VerifiedReads++
+
+	var d uint64
+	if found {
+		d = digest(value)
+	}
+
+	var newestAcked *writeRecord
+	var superseded []*writeRecord
+	for _, w := range h.writes {
+		if !w.start.Before(end) {
+			continue // 读取结束后才开始的写入
+		}
+		if supersededBefore(h, w, start) {
+			superseded = append(superseded, w)
+			continue
+		}
+		if (found && !w.deleted && w.digest == d) || (!found && w.deleted) {
+			v.report.ConsistentReads++
+			return nil
+		}
+		if w.acked && !w.deleted && (newestAcked == nil || w.version > newestAcked.version) {
+			newestAcked = w
+		}
+	}
+
+	if !found {
+		if newestAcked != nil {
+			if _, counted := v.lost[newestAcked.version]; !counted {
+				v.lost[newestAcked.version] = struct{}{}
+				v.report.LostWrites++
+			}
+			return fmt.Errorf("%w: lost write on key %s (version %d)", core.ErrDataInconsistent, key, newestAcked.version)
+		}
+		v.report.LostWrites++
+		return fmt.Errorf("%w: lost write on key %s", core.ErrDataInconsistent, key)
+	}
+
+	for _, w := range superseded {
+		if !w.deleted && w.digest == d {
+			v.report.StaleReads++
+			return fmt.Errorf("%w: stale read on key %s (version %d)", core.ErrDataInconsistent, key, w.version)
+		}
+	}
+	for _, old := range h.overwritten {
+		if old == d {
+			v.report.StaleReads++
+			return fmt.Errorf("%w: stale read on key %s", core.ErrDataInconsistent, key)
+		}
+	}
+
+	v.report.CorruptedReads++
+	return fmt.Errorf("%w: corrupted value on key %s", core.ErrDataInconsistent, key)
+}
+
+// supersededBefore 写入w是否在 before 之前已被另一个已确认的写入确定覆盖
+// （另一个写入在w结束后才开始，并在 before 之前确认）
+func supersededBefore(h *keyHistory, w *writeRecord, before time.Time) bool {
+	if w.end.IsZero() {
+		return false
+	}
+	for _, x := range h.writes {
+		if x.acked && x.start.After(w.end) && x.end.Before(before) {
+			return true
+		}
+	}
+	return false
+}
+
+// prune 移除对所有进行中和之后的读取都已确定被覆盖的写入，只保留其摘要
+func (v *writeVerifier) prune(h *keyHistory) {
+	horizon := time.Now()
+	for _, start := range v.inflight {
+		if start.Before(horizon) {
+			horizon = start
+		}
+	}
+
+	var pruned map[*writeRecord]bool
+	for _, w := range h.writes {
+		if supersededBefore(h, w, horizon) {
+			if pruned == nil {
+				pruned = make(map[*writeRecord]bool)
+			}
+			pruned[w] = true
+		}
+	}
+	if len(pruned) == 0 {
+		return
+	}
+
+	kept := make([]*writeRecord, 0, len(h.writes)-len(pruned))
+	for _, w := range h.writes {
+		if !pruned[w] {
+			kept = append(kept, w)
+		} else if !w.deleted {
+			h.overwritten = append(h.overwritten, w.digest)
+		}
+	}
+	h.writes = kept
+
+	if n := len(h.overwritten); n > maxOverwrittenDigests {
+		h.overwritten = append(h.overwritten[:0], h.overwritten[n-maxOverwrittenDigests:]...)
+	}
+}
+
+// snapshot 返回校验统计
+func (v *writeVerifier) snapshot() *core.ConsistencyReport {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	report := v.report
+	return &report
+}
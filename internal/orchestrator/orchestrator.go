@@ -0,0 +1,658 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// 编排器状态
+const (
+	StateIdle      = "idle"
+	StateRunning   = "running"
+	StatePaused    = "paused"
+	StateStopped   = "stopped"
+	StateCompleted = "completed"
+)
+
+var (
+	// ErrAlreadyRunning 编排器已在运行
+	ErrAlreadyRunning = errors.New("orchestrator already running")
+
+	// ErrNotRunning 编排器未在运行
+	ErrNotRunning = errors.New("orchestrator not running")
+
+	// ErrNotPaused 编排器未暂停
+	ErrNotPaused = errors.New("orchestrator not paused")
+)
+
+// OperationGenerator 操作生成器
+// seq 为全局递增的操作序号，返回nil表示跳过本次调度
+type OperationGenerator interface {
+	Next(seq int64) core.Operation
+}
+
+// OperationGeneratorFunc 函数形式的操作生成器
+type OperationGeneratorFunc func(seq int64) core.Operation
+
+// Next 生成下一个操作
+func (f OperationGeneratorFunc) Next(seq int64) core.Operation {
+	return f(seq)
+}
+
+// job 分发给worker的操作，intended 为开环模式下的计划发送时间（闭环为零值）
+type job struct {
+	seq      int64
+	intended time.Time
+}
+
+// Scenario 与负载并行执行的混沌场景
+// record 记录时间线事件；ctx取消后应清除已注入的故障并尽快返回
+type Scenario interface {
+	Run(ctx context.Context, record func(core.Event)) error
+}
+
+// RateSchedule 开环模式的目标速率曲线，时间均为扣除暂停后的运行时长
+type RateSchedule interface {
+	// Next 返回上一个操作计划在from发送时，下一个操作的计划发送时间
+	Next(from time.Duration) time.Duration
+	// Average 返回 [from, to) 内的平均目标速率 (ops/s)
+	Average(from, to time.Duration) float64
+}
+
+// constantRate 恒定速率，未设置 RateSchedule 时使用
+type constantRate float64
+
+func (r constantRate) Next(from time.Duration) time.Duration {
+	return from + time.Duration(float64(time.Second)/float64(r))
+}
+
+func (r constantRate) Average(from, to time.Duration) float64 {
+	return float64(r)
+}
+
+// pauseSpan 一次暂停的起止时间
+type pauseSpan struct {
+	start, end time.Time
+}
+
+// Orchestrator 并发测试编排器
+// 使用大小为 TestConfig.Concurrency 的worker池执行操作，支持从其他goroutine暂停、恢复和停止。
+//
+// 闭环模式按 Duration/Operations 的节奏调度，worker全部忙碌时调度随之停顿；
+// 开环模式（TestConfig.Rate > 0）按 RateSchedule（默认恒定速率）计算每个操作的计划发送时间，
+// 服务端停顿时不会跳过计划，延迟从计划发送时间开始计算，避免协调遗漏（coordinated omission）低估延迟。
+type Orchestrator struct {
+	client    core.MiddlewareClient
+	collector core.MetricsCollector
+	generator OperationGenerator
+	scenario  Scenario
+	schedule  RateSchedule
+
+	mu          sync.Mutex
+	state       string
+	startTime   time.Time
+	endTime     time.Time
+	pausedAt    time.Time
+	pausedTotal time.Duration
+	pauses      []pauseSpan // 已结束的暂停，用于换算时间序列的目标速率
+	duration    time.Duration
+	totalOps    int64
+	resumeCh    chan struct{} // 暂停时创建，恢复时关闭
+	cancel      context.CancelFunc
+	events      []core.Event
+	phase       string
+
+	completedOps atomic.Int64
+
+	// 开环模式的调度落后统计
+	lateOps atomic.Int64
+	maxLag  atomic.Int64
+}
+
+// NewOrchestrator 创建新的测试编排器
+func NewOrchestrator(
+	client core.MiddlewareClient,
+	collector core.MetricsCollector,
+	generator OperationGenerator,
+) *Orchestrator {
+	return &Orchestrator{
+		client:    client,
+		collector: collector,
+		generator: generator,
+		state:     StateIdle,
+	}
+}
+
+// SetScenario 设置混沌场景，需在 Run 之前调用
+func (o *Orchestrator) SetScenario(scenario Scenario) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.scenario = scenario
+}
+
+// SetRateSchedule 设置开环模式的目标速率曲线，需在 Run 之前调用
+// 只在 TestConfig.Rate > 0 时生效，未设置时按 Rate 恒定速率发送
+func (o *Orchestrator) SetRateSchedule(schedule RateSchedule) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.schedule = schedule
+}
+
+// Run 运行测试，阻塞直到测试完成、被停止或ctx取消
+// 设置了场景时，场景在连接成功后与负载并行执行，负载结束时场景随之结束
+func (o *Orchestrator) Run(ctx context.Context, config core.Config) (*core.StabilityMetrics, error) {
+	testCfg := config.GetTestConfig()
+	if testCfg == nil || (testCfg.Duration <= 0 && testCfg.Operations <= 0) {
+		return nil, fmt.Errorf("%w: duration or operations must be positive", core.ErrInvalidConfig)
+	}
+
+	concurrency := testCfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	o.mu.Lock()
+	if o.state == StateRunning || o.state == StatePaused {
+		o.mu.Unlock()
+		return nil, ErrAlreadyRunning
+	}
+	o.state = StateRunning
+	o.startTime = time.Now()
+	o.endTime = time.Time{}
+	o.pausedTotal = 0
+	o.pauses = nil
+	o.duration = testCfg.Duration
+	o.totalOps = int64(testCfg.Operations)
+	o.resumeCh = nil
+	o.cancel = cancel
+	o.events = nil
+	o.phase = ""
+	scenario := o.scenario
+	schedule := o.schedule
+	o.mu.Unlock()
+	if schedule == nil && testCfg.Rate > 0 {
+		schedule = constantRate(testCfg.Rate)
+	}
+	o.completedOps.Store(0)
+	o.lateOps.Store(0)
+	o.maxLag.Store(0)
+
+	// 客户端自动重连的尝试记入指标
+	if rc, ok := o.client.(core.Reconnectable); ok {
+		rc.SetReconnectObserver(o.collector)
+	}
+
+	// 连接
+	startConnect := time.Now()
+	if err := o.client.Connect(ctx); err != nil {
+		o.collector.RecordConnectionAttempt(false, time.Since(startConnect))
+		o.mu.Lock()
+		o.state = StateStopped
+		o.mu.Unlock()
+		o.finish()
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.GetMiddlewareType(), err)
+	}
+	o.collector.RecordConnectionAttempt(true, time.Since(startConnect))
+	defer o.client.Disconnect(context.Background())
+
+	// 场景与负载并行执行
+	var scenarioDone chan error
+	stopScenario := func() {}
+	if scenario != nil {
+		var scenarioCtx context.Context
+		scenarioCtx, stopScenario = context.WithCancel(runCtx)
+		defer stopScenario()
+
+		scenarioDone = make(chan error, 1)
+		go func() {
+			scenarioDone <- scenario.Run(scenarioCtx, o.recordEvent)
+		}()
+	}
+
+	// 调度节奏：在Duration内均匀完成Operations次操作
+	var interval time.Duration
+	if testCfg.Duration > 0 && testCfg.Operations > 0 {
+		interval = testCfg.Duration / time.Duration(testCfg.Operations)
+	}
+
+	jobs := make(chan job, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.worker(ctx, jobs)
+		}()
+	}
+
+	if testCfg.Rate > 0 {
+		o.dispatchOpenLoop(runCtx, jobs, schedule)
+	} else {
+		o.dispatch(runCtx, jobs, interval)
+	}
+	close(jobs)
+	wg.Wait()
+
+	var scenarioErr error
+	if scenarioDone != nil {
+		stopScenario()
+		scenarioErr = <-scenarioDone
+	}
+
+	// 外部取消视为停止
+	if ctx.Err() != nil {
+		o.mu.Lock()
+		o.state = StateStopped
+		o.mu.Unlock()
+	}
+	o.finish()
+
+	metrics := o.collector.GetMetrics()
+	metrics.Events = o.Events()
+	if testCfg.Rate > 0 {
+		o.applyTargetRates(metrics, schedule)
+		metrics.LateOperations = o.lateOps.Load()
+		metrics.MaxScheduleLag = time.Duration(o.maxLag.Load())
+	}
+	if verifier, ok := o.client.(core.ConsistencyVerifier); ok {
+		if report := verifier.ConsistencyReport(); report != nil {
+			report.Apply(metrics)
+		}
+	}
+	if verifier, ok := o.client.(core.DeliveryVerifier); ok {
+		if report := verifier.DeliveryReport(ctx); report != nil {
+			report.Apply(metrics)
+		}
+	}
+	if scenarioErr != nil {
+		return metrics, fmt.Errorf("scenario failed: %w", scenarioErr)
+	}
+	return metrics, nil
+}
+
+// recordEvent 记录时间线事件并跟踪当前阶段
+func (o *Orchestrator) recordEvent(event core.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.Offset = event.Time.Sub(o.startTime)
+	o.events = append(o.events, event)
+
+	switch event.Type {
+	case core.EventPhaseStart:
+		o.phase = event.Phase
+	case core.EventPhaseEnd:
+		if o.phase == event.Phase {
+			o.phase = ""
+		}
+	}
+}
+
+// Events 返回按时间排序的时间线事件
+func (o *Orchestrator) Events() []core.Event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.events) == 0 {
+		return nil
+	}
+	events := append([]core.Event(nil), o.events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
+
+// dispatch 按节奏分发操作序号，直到达到操作数、时长或被停止
+func (o *Orchestrator) dispatch(ctx context.Context, jobs chan<- job, interval time.Duration) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	deadline := time.NewTimer(time.Hour)
+	defer deadline.Stop()
+
+	for seq := int64(0); ; seq++ {
+		if o.totalOps > 0 && seq >= o.totalOps {
+			return
+		}
+
+		if err := o.waitIfPaused(ctx); err != nil {
+			return
+		}
+
+		var deadlineC <-chan time.Time
+		if o.duration > 0 {
+			remaining := o.duration - o.activeElapsed()
+			if remaining <= 0 {
+				return
+			}
+			deadline.Reset(remaining)
+			deadlineC = deadline.C
+		}
+
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-deadlineC:
+				return
+			case <-tick:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadlineC:
+			return
+		case jobs <- job{seq: seq}:
+		}
+		deadline.Stop()
+	}
+}
+
+// dispatchOpenLoop 按目标速率曲线分发操作，直到达到操作数、时长或被停止
+// 操作计划在运行时长（不含暂停）达到 schedule 给出的时间点时发送；worker全部忙碌时分发阻塞，
+// 但计划发送时间不变，恢复后积压的操作立即发出，排队时间计入延迟
+func (o *Orchestrator) dispatchOpenLoop(ctx context.Context, jobs chan<- job, schedule RateSchedule) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	var due time.Duration
+	for seq := int64(0); ; seq, due = seq+1, schedule.Next(due) {
+		if o.totalOps > 0 && seq >= o.totalOps {
+			return
+		}
+		if o.duration > 0 && due >= o.duration {
+			return
+		}
+
+		// 等待到计划时间，期间暂停的时间不计入
+		for {
+			if err := o.waitIfPaused(ctx); err != nil {
+				return
+			}
+			wait := due - o.activeElapsed()
+			if wait <= 0 {
+				break
+			}
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+		intended := time.Now().Add(due - o.activeElapsed())
+
+		var deadlineC <-chan time.Time
+		if o.duration > 0 {
+			timer.Reset(max(o.duration-o.activeElapsed(), 0))
+			deadlineC = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadlineC:
+			return
+		case jobs <- job{seq: seq, intended: intended}:
+		}
+		timer.Stop()
+	}
+}
+
+// worker 执行分发到的操作并记录结果
+// 使用父ctx执行操作，使停止调度时进行中的操作可以正常完成
+func (o *Orchestrator) worker(ctx context.Context, jobs <-chan job) {
+	for j := range jobs {
+		op := o.generator.Next(j.seq)
+		if op == nil {
+			continue
+		}
+
+		if !j.intended.IsZero() {
+			o.recordScheduleLag(time.Since(j.intended))
+		}
+
+		result, err := o.client.Execute(ctx, op)
+		if result == nil {
+			if err == nil {
+				continue
+			}
+			result = core.NewResult(false, 0, err)
+		}
+		result.Operation = core.OperationName(op)
+		result.OpType = op.Type()
+		if !j.intended.IsZero() {
+			// 开环模式：延迟从计划发送时间开始计算
+			completed := result.Timestamp
+			if completed.IsZero() {
+				completed = time.Now()
+			}
+			result.Duration = max(completed.Sub(j.intended), result.Duration)
+		}
+
+		o.collector.RecordOperation(result)
+		o.completedOps.Add(1)
+	}
+}
+
+// recordScheduleLag 记录开环模式下操作开始执行时落后计划的时间
+func (o *Orchestrator) recordScheduleLag(lag time.Duration) {
+	if lag > core.LateStartThreshold {
+		o.lateOps.Add(1)
+	}
+	for {
+		cur := o.maxLag.Load()
+		if int64(lag) <= cur || o.maxLag.CompareAndSwap(cur, int64(lag)) {
+			return
+		}
+	}
+}
+
+// waitIfPaused 暂停时阻塞，直到恢复或ctx取消
+func (o *Orchestrator) waitIfPaused(ctx context.Context) error {
+	o.mu.Lock()
+	resumeCh := o.resumeCh
+	o.mu.Unlock()
+
+	if resumeCh == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumeCh:
+		return nil
+	}
+}
+
+// activeElapsed 返回扣除暂停时间后的运行时长
+func (o *Orchestrator) activeElapsed() time.Duration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.activeElapsedLocked()
+}
+
+func (o *Orchestrator) activeElapsedLocked() time.Duration {
+	if o.startTime.IsZero() {
+		return 0
+	}
+	now := time.Now()
+	switch {
+	case !o.endTime.IsZero():
+		now = o.endTime
+	case o.state == StatePaused:
+		now = o.pausedAt
+	}
+	return now.Sub(o.startTime) - o.pausedTotal
+}
+
+// endPause 结束当前暂停，调用方需持有锁
+func (o *Orchestrator) endPause() {
+	now := time.Now()
+	o.pausedTotal += now.Sub(o.pausedAt)
+	o.pauses = append(o.pauses, pauseSpan{start: o.pausedAt, end: now})
+}
+
+// activeAt 返回时间点t对应的运行时长（扣除之前的暂停），限制在 [0, 结束时的运行时长] 内
+// 只在运行结束后调用
+func (o *Orchestrator) activeAt(t time.Time) time.Duration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	d := t.Sub(o.startTime)
+	for _, p := range o.pauses {
+		if t.After(p.start) {
+			d -= minTime(t, p.end).Sub(p.start)
+		}
+	}
+	return min(max(d, 0), o.activeElapsedLocked())
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// applyTargetRates 记录开环模式的平均目标速率和时间序列各区间的目标速率
+func (o *Orchestrator) applyTargetRates(metrics *core.StabilityMetrics, schedule RateSchedule) {
+	if active := o.activeElapsed(); active > 0 {
+		metrics.TargetRate = schedule.Average(0, active)
+	}
+	for i := range metrics.Series {
+		im := &metrics.Series[i]
+		from, to := o.activeAt(im.Start), o.activeAt(im.Start.Add(im.Duration))
+		if to > from && im.Duration > 0 {
+			im.TargetRate = schedule.Average(from, to) * float64(to-from) / float64(im.Duration)
+		}
+	}
+}
+
+// finish 标记运行结束
+func (o *Orchestrator) finish() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state == StatePaused {
+		o.endPause()
+	}
+	if o.state != StateStopped {
+		o.state = StateCompleted
+	}
+	o.endTime = time.Now()
+	o.resumeCh = nil
+	o.cancel = nil
+}
+
+// Pause 暂停测试
+func (o *Orchestrator) Pause() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state != StateRunning {
+		return ErrNotRunning
+	}
+	o.state = StatePaused
+	o.pausedAt = time.Now()
+	o.resumeCh = make(chan struct{})
+	return nil
+}
+
+// Resume 恢复测试
+func (o *Orchestrator) Resume() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state != StatePaused {
+		return ErrNotPaused
+	}
+	o.endPause()
+	o.state = StateRunning
+	close(o.resumeCh)
+	o.resumeCh = nil
+	return nil
+}
+
+// Stop 停止测试，已分发的操作会执行完毕
+func (o *Orchestrator) Stop() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state != StateRunning && o.state != StatePaused {
+		return ErrNotRunning
+	}
+	if o.state == StatePaused {
+		o.endPause()
+		close(o.resumeCh)
+		o.resumeCh = nil
+	}
+	o.state = StateStopped
+	if o.cancel != nil {
+		o.cancel()
+	}
+	return nil
+}
+
+// Phase 返回当前场景阶段，未使用场景或不在阶段内时为空
+func (o *Orchestrator) Phase() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.phase
+}
+
+// Elapsed 返回扣除暂停时间后的运行时长，暂停期间保持不变
+func (o *Orchestrator) Elapsed() time.Duration {
+	return o.activeElapsed()
+}
+
+// GetStatus 获取测试状态
+// ElapsedTime 不包含暂停时间；Progress 取时间进度和操作进度中的较大者
+func (o *Orchestrator) GetStatus() *core.OrchestratorStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	elapsed := o.activeElapsedLocked()
+	completed := o.completedOps.Load()
+
+	var progress float64
+	if o.duration > 0 {
+		progress = float64(elapsed) / float64(o.duration)
+	}
+	if o.totalOps > 0 {
+		if p := float64(completed) / float64(o.totalOps); p > progress {
+			progress = p
+		}
+	}
+	if progress > 1 || o.state == StateCompleted {
+		progress = 1
+	}
+
+	return &core.OrchestratorStatus{
+		State:       o.state,
+		Progress:    progress,
+		ElapsedTime: elapsed,
+		Operations:  completed,
+		Phase:       o.phase,
+	}
+}
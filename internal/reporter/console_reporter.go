@@ -10,7 +10,10 @@ import (
 )
 
 // ConsoleReporter 控制台报告生成器
+// 设置了自定义模板（SetTemplate）时按模板输出，数据模型见 TemplateData
 type ConsoleReporterImpl struct {
+	reportTemplate
+	localized
 	colorEnabled bool
 }
 
@@ -32,122 +35,232 @@ func (r *ConsoleReporterImpl) GenerateReport(
 	evaluation *core.EvaluationResult,
 	output io.Writer,
 ) error {
+	if ok, err := r.render(r.lang, metrics, evaluation, output); ok {
+		return err
+	}
+
 	var sb strings.Builder
+	t := r.t
 
 	// 标题
 	sb.WriteString("==========================================\n")
-	sb.WriteString("   中间件稳定性测试报告\n")
+	sb.WriteString("   " + t("report.title") + "\n")
 	sb.WriteString("==========================================\n\n")
 
 	// 测试信息
-	sb.WriteString(fmt.Sprintf("测试时长: %v\n", metrics.Duration.Round(time.Second)))
-	sb.WriteString(fmt.Sprintf("测试完成: %s\n\n", evaluation.EvaluatedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("%s: %v\n", t("report.duration"), metrics.Duration.Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("%s: %s\n\n", t("report.completed"), evaluation.EvaluatedAt.Format("2006-01-02 15:04:05")))
 
 	// 总体评分
 	sb.WriteString("------------------------------------------\n")
 	statusSymbol := r.getStatusSymbol(evaluation.Status)
-	sb.WriteString(fmt.Sprintf("  总体评分: %.1f/100 (%s) %s\n",
-		evaluation.Score, evaluation.Grade, statusSymbol))
+	sb.WriteString(fmt.Sprintf("  %s: %.1f/100 (%s) %s\n",
+		t("report.overall_score"), evaluation.Score, evaluation.Grade, statusSymbol))
 	sb.WriteString("------------------------------------------\n\n")
 
 	// 各维度得分
-	sb.WriteString("各维度得分:\n")
-	sb.WriteString(fmt.Sprintf("  %s 可用性   %.1f/30  (%.1f%%)  - 权重30%%\n",
-		r.getCheckmark(evaluation.Scores.Availability >= 20),
-		evaluation.Scores.Availability,
-		evaluation.Scores.Availability/30*100))
-	sb.WriteString(fmt.Sprintf("  %s 性能     %.1f/25  (%.1f%%)  - 权重25%%\n",
-		r.getCheckmark(evaluation.Scores.Performance >= 17),
-		evaluation.Scores.Performance,
-		evaluation.Scores.Performance/25*100))
-	sb.WriteString(fmt.Sprintf("  %s 可靠性   %.1f/25  (%.1f%%)  - 权重25%%\n",
-		r.getCheckmark(evaluation.Scores.Reliability >= 17),
-		evaluation.Scores.Reliability,
-		evaluation.Scores.Reliability/25*100))
-	sb.WriteString(fmt.Sprintf("  %s 恢复力   %.1f/20  (%.1f%%)  - 权重20%%\n\n",
-		r.getCheckmark(evaluation.Scores.Resilience >= 14),
-		evaluation.Scores.Resilience,
-		evaluation.Scores.Resilience/20*100))
+	sb.WriteString(t("report.dimension_scores") + ":\n")
+	dimensions := []struct {
+		name  string
+		score float64
+		max   int
+		pass  float64
+	}{
+		{t("dimension.availability"), evaluation.Scores.Availability, 30, 20},
+		{t("dimension.performance"), evaluation.Scores.Performance, 25, 17},
+		{t("dimension.reliability"), evaluation.Scores.Reliability, 25, 17},
+		{t("dimension.resilience"), evaluation.Scores.Resilience, 20, 14},
+	}
+	nameWidth := 0
+	for _, d := range dimensions {
+		nameWidth = max(nameWidth, displayWidth(d.name))
+	}
+	for _, d := range dimensions {
+		sb.WriteString(fmt.Sprintf("  %s %s%.1f/%d  (%.1f%%)  - %s\n",
+			r.getCheckmark(d.score >= d.pass), padRight(d.name, nameWidth+3),
+			d.score, d.max, d.score/float64(d.max)*100, t("report.weight", d.max)))
+	}
+	sb.WriteString("\n")
 
 	// 核心指标
 	sb.WriteString("------------------------------------------\n")
-	sb.WriteString("  核心指标\n")
+	sb.WriteString("  " + t("report.key_metrics") + "\n")
 	sb.WriteString("------------------------------------------\n")
 
 	// 可用性
-	sb.WriteString(fmt.Sprintf("可用性: %.2f%% %s\n",
+	sb.WriteString(fmt.Sprintf("%s: %.2f%% %s\n",
+		t("metric.availability"),
 		metrics.Availability*100,
 		r.getCheckmark(metrics.Availability >= 0.95)))
-	sb.WriteString(fmt.Sprintf("  - 总操作数: %d\n", metrics.TotalOperations))
-	sb.WriteString(fmt.Sprintf("  - 成功操作: %d\n", metrics.SuccessfulOperations))
-	sb.WriteString(fmt.Sprintf("  - 失败操作: %d\n", metrics.FailedOperations))
-	sb.WriteString(fmt.Sprintf("  - 错误率: %.2f%%\n\n", metrics.ErrorRate*100))
+	sb.WriteString(fmt.Sprintf("  - %s: %d\n", t("metric.total_operations"), metrics.TotalOperations))
+	sb.WriteString(fmt.Sprintf("  - %s: %d\n", t("metric.successful_operations"), metrics.SuccessfulOperations))
+	sb.WriteString(fmt.Sprintf("  - %s: %d\n", t("metric.failed_operations"), metrics.FailedOperations))
+	sb.WriteString(fmt.Sprintf("  - %s: %.2f%%\n", t("metric.error_rate"), metrics.ErrorRate*100))
+	if types := sortedErrorTypes(metrics.ErrorsByType); len(types) > 0 {
+		sb.WriteString(fmt.Sprintf("  - %s:\n", t("report.errors_by_type")))
+		for _, et := range types {
+			n := metrics.ErrorsByType[et]
+			sb.WriteString(fmt.Sprintf("      %s: %d (%.1f%%)\n",
+				errorTypeLabel(r.lang, et), n, float64(n)/float64(max(metrics.FailedOperations, 1))*100))
+		}
+	}
+	sb.WriteString("\n")
 
 	// 性能指标
-	sb.WriteString("性能指标:\n")
-	sb.WriteString(fmt.Sprintf("  - P50 延迟: %v %s\n",
+	sb.WriteString(t("report.performance") + ":\n")
+	sb.WriteString(fmt.Sprintf("  - %s: %v %s\n", t("metric.latency", "P50"),
 		metrics.P50Latency.Round(time.Millisecond),
 		r.getCheckmark(metrics.P50Latency <= 50*time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("  - P95 延迟: %v %s\n",
+	sb.WriteString(fmt.Sprintf("  - %s: %v %s\n", t("metric.latency", "P95"),
 		metrics.P95Latency.Round(time.Millisecond),
 		r.getCheckmark(metrics.P95Latency <= 200*time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("  - P99 延迟: %v %s\n",
+	sb.WriteString(fmt.Sprintf("  - %s: %v %s\n", t("metric.latency", "P99"),
 		metrics.P99Latency.Round(time.Millisecond),
 		r.getCheckmark(metrics.P99Latency <= 500*time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("  - 平均吞吐: %.0f ops/s\n\n", metrics.Throughput))
+	sb.WriteString(fmt.Sprintf("  - %s: P99.9 %v / P99.99 %v / Max %v\n", t("metric.tail_latency"),
+		metrics.P999Latency.Round(time.Millisecond), metrics.P9999Latency.Round(time.Millisecond),
+		metrics.MaxLatency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("  - %s: %v (%s)\n", t("metric.avg_latency"),
+		metrics.AvgLatency.Round(time.Microsecond), t("metric.stddev", metrics.StdDevLatency.Round(time.Microsecond))))
+	sb.WriteString(fmt.Sprintf("  - %s: %.0f ops/s\n", t("metric.avg_throughput"), metrics.Throughput))
+	if metrics.TargetRate > 0 {
+		sb.WriteString(fmt.Sprintf("  - %s: %s %s\n", t("metric.open_loop"),
+			t("report.open_loop_detail", metrics.TargetRate, metrics.LateOperations, core.LateStartThreshold,
+				metrics.MaxScheduleLag.Round(time.Millisecond)),
+			r.getCheckmark(metrics.LateOperations == 0)))
+	}
+	if d := metrics.Delivery; d != nil {
+		sb.WriteString(fmt.Sprintf("  - %s: P50 %v / P95 %v / P99 %v / Max %v\n", t("metric.produce_latency"),
+			d.ProduceLatency.P50.Round(time.Millisecond), d.ProduceLatency.P95.Round(time.Millisecond),
+			d.ProduceLatency.P99.Round(time.Millisecond), d.ProduceLatency.Max.Round(time.Millisecond)))
+		sb.WriteString(fmt.Sprintf("  - %s: P50 %v / P95 %v / P99 %v / Max %v\n", t("metric.e2e_latency"),
+			d.EndToEndLatency.P50.Round(time.Millisecond), d.EndToEndLatency.P95.Round(time.Millisecond),
+			d.EndToEndLatency.P99.Round(time.Millisecond), d.EndToEndLatency.Max.Round(time.Millisecond)))
+	}
+	sb.WriteString("\n")
+
+	// 按操作统计，表头按显示宽度与数据列对齐
+	if len(metrics.Operations) > 0 {
+		sb.WriteString(t("report.by_operation") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s %s %s %s %s %9s %9s %9s %9s\n",
+			padRight(t("column.operation"), 12), padRight(t("column.type"), 7), padLeft(t("column.operations"), 13),
+			padLeft(t("column.availability"), 10), padLeft(t("column.throughput"), 12), "P50", "P95", "P99", "P99.9"))
+		for _, op := range sortedOperations(metrics.Operations) {
+			sb.WriteString(fmt.Sprintf("  %-12s %-7s %13d %9.2f%% %6.0f ops/s %9v %9v %9v %9v\n",
+				op.Name, op.Type, op.Operations, op.Availability*100, op.Throughput,
+				op.P50Latency.Round(time.Millisecond), op.P95Latency.Round(time.Millisecond),
+				op.P99Latency.Round(time.Millisecond), op.P999Latency.Round(time.Millisecond)))
+		}
+		sb.WriteString("\n")
+	}
 
 	// 可靠性
-	sb.WriteString("可靠性:\n")
-	sb.WriteString(fmt.Sprintf("  - 数据丢失率: %.4f%% %s\n",
+	sb.WriteString(t("report.reliability") + ":\n")
+	sb.WriteString(fmt.Sprintf("  - %s: %.4f%% %s\n", t("metric.data_loss_rate"),
 		metrics.DataLossRate*100,
 		r.getCheckmark(metrics.DataLossRate == 0)))
+	if c := metrics.Consistency; c != nil {
+		sb.WriteString(fmt.Sprintf("  - %s: %.4f%% (%d/%d) %s\n", t("metric.data_consistency"),
+			metrics.DataConsistency*100, c.ConsistentReads, c.VerifiedReads,
+			r.getCheckmark(c.Inconsistencies() == 0)))
+		sb.WriteString(fmt.Sprintf("  - %s: %d, %s: %d, %s: %d\n",
+			t("metric.lost_writes"), c.LostWrites, t("metric.stale_reads"), c.StaleReads,
+			t("metric.corrupted_reads"), c.CorruptedReads))
+	}
+	if d := metrics.Delivery; d != nil {
+		sb.WriteString(fmt.Sprintf("  - %s: %s %s\n", t("metric.delivery"),
+			t("report.delivery_detail", d.Acked, d.Received, d.Lost), r.getCheckmark(d.Lost == 0)))
+		sb.WriteString(fmt.Sprintf("  - %s: %.4f%% (%d) %s\n", t("metric.duplicate_rate"),
+			metrics.DuplicateRate*100, d.Duplicates, r.getCheckmark(d.Duplicates == 0)))
+		sb.WriteString(fmt.Sprintf("  - %s: %.4f%% (%d) %s\n", t("metric.out_of_order_rate"),
+			metrics.OutOfOrderRate*100, d.OutOfOrder, r.getCheckmark(d.OutOfOrder == 0)))
+	}
 
 	// 恢复性
+	if metrics.OutageCount > 0 || metrics.MTTR > 0 || metrics.ReconnectSuccessRate > 0 {
+		sb.WriteString("\n" + t("report.recovery") + ":\n")
+	}
+	if metrics.OutageCount > 0 {
+		sb.WriteString(fmt.Sprintf("  - %s: %d\n", t("metric.outages"), metrics.OutageCount))
+		sb.WriteString(fmt.Sprintf("  - %s: %v\n", t("metric.total_downtime"), metrics.TotalDowntime.Round(time.Millisecond)))
+		sb.WriteString(fmt.Sprintf("  - %s: %v\n", t("metric.longest_outage"), metrics.LongestOutage.Round(time.Millisecond)))
+		if metrics.MTBF > 0 {
+			sb.WriteString(fmt.Sprintf("  - MTBF: %v\n", metrics.MTBF.Round(time.Millisecond)))
+		}
+	}
 	if metrics.MTTR > 0 {
-		sb.WriteString("\n恢复性:\n")
 		sb.WriteString(fmt.Sprintf("  - MTTR: %v %s\n",
-			metrics.MTTR.Round(time.Second),
+			metrics.MTTR.Round(time.Millisecond),
 			r.getCheckmark(metrics.MTTR <= 300*time.Second)))
 	}
-	if metrics.ReconnectSuccessRate > 0 {
-		sb.WriteString(fmt.Sprintf("  - 重连成功率: %.0f%% %s\n",
+	if metrics.HasUnrecoveredOutage() {
+		sb.WriteString(fmt.Sprintf("  - %s %s\n", t("report.unrecovered"), r.getCheckmark(false)))
+	}
+	if metrics.TotalReconnectAttempts > 0 {
+		sb.WriteString(fmt.Sprintf("  - %s: %.0f%% (%d/%d) %s\n", t("metric.reconnect_rate"),
+			metrics.ReconnectSuccessRate*100,
+			metrics.SuccessfulReconnects, metrics.TotalReconnectAttempts,
+			r.getCheckmark(metrics.ReconnectSuccessRate >= 0.95)))
+	} else if metrics.ReconnectSuccessRate > 0 {
+		sb.WriteString(fmt.Sprintf("  - %s: %.0f%% %s\n", t("metric.reconnect_rate"),
 			metrics.ReconnectSuccessRate*100,
 			r.getCheckmark(metrics.ReconnectSuccessRate >= 0.95)))
 	}
 
+	// 指标趋势
+	if len(metrics.Series) > 1 {
+		lines, per := timelineLines(r.lang, metrics.Series)
+		sb.WriteString("\n------------------------------------------\n")
+		sb.WriteString(fmt.Sprintf("  %s (%s)\n", t("report.trends"), t("report.trends_per_cell", per)))
+		sb.WriteString("------------------------------------------\n")
+		for _, line := range lines {
+			sb.WriteString(fmt.Sprintf("  %s  %s\n", line[1], line[0]))
+		}
+	}
+
+	// 时间线
+	if len(metrics.Events) > 0 {
+		sb.WriteString("\n------------------------------------------\n")
+		sb.WriteString("  " + t("report.timeline") + "\n")
+		sb.WriteString("------------------------------------------\n")
+		for _, e := range metrics.Events {
+			sb.WriteString(fmt.Sprintf("  %-9s %s  %s\n", formatOffset(e.Offset), eventLabel(r.lang, e.Type), eventDetail(e)))
+		}
+	}
+
 	// 发现的问题
 	if len(evaluation.Issues) > 0 {
 		sb.WriteString("\n------------------------------------------\n")
-		sb.WriteString(fmt.Sprintf("  发现的问题 (%d个)\n", len(evaluation.Issues)))
+		sb.WriteString("  " + t("report.issues", len(evaluation.Issues)) + "\n")
 		sb.WriteString("------------------------------------------\n")
 		for _, issue := range evaluation.Issues {
 			sb.WriteString(fmt.Sprintf("[%s] %s\n", issue.Severity, issue.Type))
-			sb.WriteString(fmt.Sprintf("  指标: %s\n", issue.Metric))
-			sb.WriteString(fmt.Sprintf("  当前值: %.2f\n", issue.Current))
-			sb.WriteString(fmt.Sprintf("  期望值: %.2f\n", issue.Expected))
-			sb.WriteString(fmt.Sprintf("  说明: %s\n\n", issue.Message))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", t("report.metric"), issue.Metric))
+			sb.WriteString(fmt.Sprintf("  %s: %.2f\n", t("report.current"), issue.Current))
+			sb.WriteString(fmt.Sprintf("  %s: %.2f\n", t("report.expected"), issue.Expected))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n\n", t("report.description"), issue.Message))
 		}
 	}
 
 	// 改进建议
 	if len(evaluation.Recommendations) > 0 {
 		sb.WriteString("------------------------------------------\n")
-		sb.WriteString("  改进建议 (按优先级排序)\n")
+		sb.WriteString("  " + t("report.recommendations_sorted") + "\n")
 		sb.WriteString("------------------------------------------\n\n")
 		for i, rec := range evaluation.Recommendations {
 			sb.WriteString(fmt.Sprintf("[%s] %s\n", rec.Priority, rec.Title))
-			sb.WriteString(fmt.Sprintf("分类: %s\n", rec.Category))
+			sb.WriteString(fmt.Sprintf("%s: %s\n", t("report.category"), rec.Category))
 			if rec.Message != "" {
-				sb.WriteString(fmt.Sprintf("说明: %s\n", rec.Message))
+				sb.WriteString(fmt.Sprintf("%s: %s\n", t("report.description"), rec.Message))
 			}
 			if len(rec.Actions) > 0 {
-				sb.WriteString("具体行动:\n")
+				sb.WriteString(t("report.actions") + ":\n")
 				for j, action := range rec.Actions {
 					sb.WriteString(fmt.Sprintf("  %d. %s\n", j+1, action))
 				}
 			}
 			if len(rec.References) > 0 {
-				sb.WriteString("\n参考文档:\n")
+				sb.WriteString("\n" + t("report.references") + ":\n")
 				for _, ref := range rec.References {
 					sb.WriteString(fmt.Sprintf("  - %s\n", ref))
 				}
@@ -161,7 +274,7 @@ func (r *ConsoleReporterImpl) GenerateReport(
 
 	// 结论
 	sb.WriteString("------------------------------------------\n")
-	sb.WriteString("  结论\n")
+	sb.WriteString("  " + t("report.conclusion") + "\n")
 	sb.WriteString("------------------------------------------\n")
 	sb.WriteString(evaluation.Rationale)
 	sb.WriteString("\n==========================================\n")
@@ -0,0 +1,206 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// 刷新间隔：终端中原地刷新面板，非终端输出（重定向到文件、CI日志）时定期输出一行
+const (
+	DashboardRefreshInterval = time.Second
+	DashboardLogInterval     = 10 * time.Second
+)
+
+// progressBarWidth 进度条宽度
+const progressBarWidth = 30
+
+// StatusSource 运行状态来源，由 orchestrator.Orchestrator 实现
+type StatusSource interface {
+	GetStatus() *core.OrchestratorStatus
+}
+
+// MetricsSource 实时指标来源，由 collector.MetricsCollector 实现
+type MetricsSource interface {
+	GetMetrics() *core.StabilityMetrics
+}
+
+// Dashboard 测试运行期间的实时进度面板
+//
+// 交互模式下每秒原地刷新，显示进度、当前吞吐量、最近一个时间序列区间的延迟百分位、
+// 按类型的错误数、当前场景阶段和吞吐量趋势；非交互模式每10秒输出一行摘要。
+type Dashboard struct {
+	localized
+
+	out         io.Writer
+	status      StatusSource
+	metrics     MetricsSource
+	interactive bool
+
+	lines      int // 上一帧的行数，交互模式下用于覆盖
+	lastOps    int64
+	lastTime   time.Time
+	throughput []float64 // 每次刷新时的吞吐量，最多保留 timelineWidth 个
+}
+
+// NewDashboard 创建进度面板，interactive 为true时使用终端控制序列原地刷新
+func NewDashboard(out io.Writer, status StatusSource, metrics MetricsSource, interactive bool) *Dashboard {
+	return &Dashboard{
+		out:         out,
+		status:      status,
+		metrics:     metrics,
+		interactive: interactive,
+		lastTime:    time.Now(),
+	}
+}
+
+// IsTerminal 判断文件是否为终端（字符设备）
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Run 定期刷新直到ctx取消，返回前输出最后一次状态
+func (d *Dashboard) Run(ctx context.Context) {
+	interval := DashboardLogInterval
+	if d.interactive {
+		interval = DashboardRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.Update(time.Now())
+			return
+		case now := <-ticker.C:
+			d.Update(now)
+		}
+	}
+}
+
+// Update 采样当前状态并输出一次
+func (d *Dashboard) Update(now time.Time) {
+	status := d.status.GetStatus()
+	metrics := d.metrics.GetMetrics()
+
+	var current float64
+	if elapsed := now.Sub(d.lastTime); elapsed > 0 {
+		current = float64(metrics.TotalOperations-d.lastOps) / elapsed.Seconds()
+	}
+	d.lastOps, d.lastTime = metrics.TotalOperations, now
+	d.throughput = append(d.throughput, current)
+	if len(d.throughput) > timelineWidth {
+		d.throughput = d.throughput[len(d.throughput)-timelineWidth:]
+	}
+
+	if !d.interactive {
+		fmt.Fprintln(d.out, d.logLine(status, metrics, current))
+		return
+	}
+
+	lines := d.frame(status, metrics, current)
+	var sb strings.Builder
+	if d.lines > 0 {
+		// 光标移回上一帧的第一行并清除到屏幕末尾
+		sb.WriteString(fmt.Sprintf("\033[%dF\033[J", d.lines))
+	}
+	for _, line := range lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	io.WriteString(d.out, sb.String())
+	d.lines = len(lines)
+}
+
+// frame 生成交互模式的一帧
+func (d *Dashboard) frame(status *core.OrchestratorStatus, metrics *core.StabilityMetrics, current float64) []string {
+	filled := min(int(status.Progress*progressBarWidth), progressBarWidth)
+	lines := []string{
+		"------------------------------------------",
+		fmt.Sprintf("  %s [%s%s] %3.0f%%  %s",
+			stateLabel(d.lang, status.State), strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled),
+			status.Progress*100, d.t("progress.elapsed", status.ElapsedTime.Round(time.Second))),
+		"  " + d.t("progress.summary", metrics.TotalOperations, metrics.Availability*100, current, metrics.Throughput),
+	}
+
+	if im, ok := latestInterval(metrics); ok {
+		lines = append(lines, fmt.Sprintf("  %s: P50 %v / P95 %v / P99 %v / Max %v",
+			d.t("progress.latency", im.Duration), im.P50Latency.Round(time.Microsecond), im.P95Latency.Round(time.Microsecond),
+			im.P99Latency.Round(time.Microsecond), im.MaxLatency.Round(time.Microsecond)))
+	}
+	lines = append(lines, fmt.Sprintf("  %s: %s", d.t("progress.errors"), errorSummary(d.lang, metrics.ErrorsByType, "  ")))
+	if status.Phase != "" {
+		lines = append(lines, fmt.Sprintf("  %s: %s", d.t("progress.phase"), status.Phase))
+	}
+
+	var peak float64
+	for _, v := range d.throughput {
+		peak = max(peak, v)
+	}
+	columns := make([]timelineColumn, len(d.throughput))
+	for i, v := range d.throughput {
+		columns[i] = timelineColumn{ops: int64(math.Round(v)), duration: time.Second}
+	}
+	spark := sparkline(columns, func(c timelineColumn) (float64, bool) {
+		return float64(c.ops), true
+	}, peak)
+	lines = append(lines, "  "+d.t("progress.trend", spark, peak))
+	lines = append(lines, "------------------------------------------")
+	return lines
+}
+
+// logLine 生成非交互模式的一行摘要
+func (d *Dashboard) logLine(status *core.OrchestratorStatus, metrics *core.StabilityMetrics, current float64) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%v] %s %.0f%% | %s",
+		status.ElapsedTime.Round(time.Second), stateLabel(d.lang, status.State), status.Progress*100,
+		d.t("progress.log", metrics.TotalOperations, metrics.Availability*100, current)))
+	if im, ok := latestInterval(metrics); ok {
+		sb.WriteString(fmt.Sprintf(" | P50/P95/P99 %v/%v/%v",
+			im.P50Latency.Round(time.Microsecond), im.P95Latency.Round(time.Microsecond),
+			im.P99Latency.Round(time.Microsecond)))
+	}
+	sb.WriteString(fmt.Sprintf(" | %s %s", d.t("progress.errors"), errorSummary(d.lang, metrics.ErrorsByType, " ")))
+	if status.Phase != "" {
+		sb.WriteString(fmt.Sprintf(" | %s %s", d.t("progress.phase"), status.Phase))
+	}
+	return sb.String()
+}
+
+// latestInterval 返回最近一个已结束的时间序列区间（最后一个区间仍在进行中）
+func latestInterval(metrics *core.StabilityMetrics) (core.IntervalMetrics, bool) {
+	switch n := len(metrics.Series); {
+	case n >= 2:
+		return metrics.Series[n-2], true
+	case n == 1:
+		return metrics.Series[0], true
+	}
+	return core.IntervalMetrics{}, false
+}
+
+// errorSummary 按错误数降序列出各类错误，没有错误时返回"无"
+func errorSummary(lang i18n.Lang, errorsByType map[core.ErrorType]int64, sep string) string {
+	types := sortedErrorTypes(errorsByType)
+	if len(types) == 0 {
+		return lang.T("progress.no_errors")
+	}
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s %d", errorTypeLabel(lang, t), errorsByType[t]))
+	}
+	return strings.Join(parts, sep)
+}
+
+// stateLabel 编排器状态的显示名称
+func stateLabel(lang i18n.Lang, state string) string {
+	return lang.Name("state", state)
+}
@@ -0,0 +1,30 @@
+package reporter
+
+import (
+	"sort"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// errorTypeLabel 错误类型的显示名称
+func errorTypeLabel(lang i18n.Lang, errorType core.ErrorType) string {
+	return lang.Name("error_type", string(errorType))
+}
+
+// sortedErrorTypes 按错误数降序返回有错误的类型
+func sortedErrorTypes(errorsByType map[core.ErrorType]int64) []core.ErrorType {
+	types := make([]core.ErrorType, 0, len(errorsByType))
+	for t, n := range errorsByType {
+		if n > 0 {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if errorsByType[types[i]] != errorsByType[types[j]] {
+			return errorsByType[types[i]] > errorsByType[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
@@ -0,0 +1,38 @@
+package reporter
+
+import (
+	"fmt"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// eventLabel 时间线事件的显示名称
+func eventLabel(lang i18n.Lang, eventType core.EventType) string {
+	return lang.Name("event", string(eventType))
+}
+
+// eventDetail 时间线事件的详细描述
+func eventDetail(e core.Event) string {
+	detail := e.Phase
+	if e.Fault != "" {
+		detail = fmt.Sprintf("%s [%s]", detail, e.Fault)
+	}
+	if e.Message != "" {
+		detail = fmt.Sprintf("%s - %s", detail, e.Message)
+	}
+	return detail
+}
+
+// formatOffset 格式化相对测试开始的时间
+func formatOffset(offset time.Duration) string {
+	return "+" + offset.Round(100*time.Millisecond).String()
+}
+
+func yesNo(lang i18n.Lang, b bool) string {
+	if b {
+		return lang.T("report.yes")
+	}
+	return lang.T("report.no")
+}
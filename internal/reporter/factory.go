@@ -0,0 +1,58 @@
+package reporter
+
+import (
+	"fmt"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// Formats 支持的报告格式
+var Formats = []string{"console", "json", "markdown", "html", "junit"}
+
+// LangReporter 支持多语言的报告生成器
+type LangReporter interface {
+	core.Reporter
+	// SetLang 设置报告语言，为空时使用默认语言
+	SetLang(lang i18n.Lang)
+}
+
+// NewReporter 按格式和语言创建报告生成器，空格式为控制台报告，md 为 markdown 的别名；
+// JUnit报告固定为英文
+func NewReporter(format string, lang i18n.Lang) (core.Reporter, error) {
+	var r core.Reporter
+	switch format {
+	case "json":
+		r = NewJSONReporter()
+	case "markdown", "md":
+		r = NewMarkdownReporter()
+	case "html":
+		r = NewHTMLReporter()
+	case "junit":
+		r = NewJUnitReporter()
+	case "console", "":
+		r = NewConsoleReporter()
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+	if lr, ok := r.(LangReporter); ok {
+		lr.SetLang(lang)
+	}
+	return r, nil
+}
+
+// ContentType 报告格式对应的MIME类型
+func ContentType(format string) string {
+	switch format {
+	case "json":
+		return "application/json; charset=utf-8"
+	case "markdown", "md":
+		return "text/markdown; charset=utf-8"
+	case "html":
+		return "text/html; charset=utf-8"
+	case "junit":
+		return "application/xml; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
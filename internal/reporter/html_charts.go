@@ -0,0 +1,343 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// 图表尺寸（SVG坐标），绘图区四周留出坐标轴标签的空间
+const (
+	chartWidth     = 760
+	chartHeight    = 220
+	chartPadLeft   = 64
+	chartPadRight  = 16
+	chartPadTop    = 12
+	chartPadBottom = 28
+	chartYTicks    = 4
+)
+
+// 图表颜色
+const (
+	colorP50        = "#60a5fa"
+	colorP95        = "#2563eb"
+	colorP99        = "#1e3a8a"
+	colorThroughput = "#16a34a"
+	colorTarget     = "#9ca3af"
+	colorFault      = "#f59e0b"
+	colorOutage     = "#ef4444"
+)
+
+// errorTypeColors 错误类型的颜色，未列出的类型使用 other 的颜色
+var errorTypeColors = map[core.ErrorType]string{
+	core.ErrorTypeTimeout:        "#f59e0b",
+	core.ErrorTypeNetwork:        "#ef4444",
+	core.ErrorTypeAuthentication: "#8b5cf6",
+	core.ErrorTypeDataLoss:       "#0ea5e9",
+	core.ErrorTypeOther:          "#6b7280",
+}
+
+// chartTickSteps 时间轴刻度的候选间隔
+var chartTickSteps = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour,
+}
+
+// htmlChart 一张图表及其图例
+type htmlChart struct {
+	Title  string
+	SVG    template.HTML
+	Legend []htmlLegend
+}
+
+// htmlLegend 图例项
+type htmlLegend struct {
+	Label string
+	Color string
+	Band  bool // 区域（故障窗口）而不是线条
+}
+
+// timeWindow 时间轴上的一段区间（相对测试开始），Start==End 表示瞬时事件
+type timeWindow struct {
+	Start, End time.Duration
+	Label      string
+	Color      string
+}
+
+// faultWindows 从时间线事件中提取注入故障的区间，同一阶段的故障开始和结束配对，
+// 测试结束时仍未结束的故障延续到测试结束；瞬时故障（如重置连接）为一条竖线
+func faultWindows(events []core.Event, span time.Duration) []timeWindow {
+	var windows []timeWindow
+	open := make(map[string]int) // 阶段+故障 -> windows 下标
+	for _, e := range events {
+		key := e.Phase + "\x00" + e.Fault
+		switch e.Type {
+		case core.EventFaultStart:
+			open[key] = len(windows)
+			windows = append(windows, timeWindow{Start: e.Offset, End: span, Label: e.Fault, Color: colorFault})
+		case core.EventFaultStop:
+			if i, ok := open[key]; ok {
+				windows[i].End = e.Offset
+				delete(open, key)
+			}
+		case core.EventFaultInject:
+			windows = append(windows, timeWindow{Start: e.Offset, End: e.Offset, Label: e.Fault, Color: colorFault})
+		}
+	}
+	return windows
+}
+
+// outageWindows 检测到的故障窗口
+func outageWindows(lang i18n.Lang, metrics *core.StabilityMetrics) []timeWindow {
+	windows := make([]timeWindow, 0, len(metrics.Outages))
+	for _, o := range metrics.Outages {
+		start := o.Start.Sub(metrics.StartTime)
+		windows = append(windows, timeWindow{
+			Start: start,
+			End:   start + o.Duration,
+			Label: lang.T("chart.outage", o.Duration.Round(time.Millisecond), o.Failures),
+			Color: colorOutage,
+		})
+	}
+	return windows
+}
+
+// seriesSpan 时间轴的总长度
+func seriesSpan(metrics *core.StabilityMetrics) time.Duration {
+	var span time.Duration
+	for _, im := range metrics.Series {
+		span = max(span, im.Start.Sub(metrics.StartTime)+im.Duration)
+	}
+	return max(span, metrics.Duration)
+}
+
+// svgChart 共享时间轴的SVG图表
+type svgChart struct {
+	sb   strings.Builder
+	span time.Duration
+	maxY float64
+}
+
+// newSVGChart 创建图表并绘制故障区间、网格和坐标轴，yLabel 格式化纵轴刻度
+func newSVGChart(span time.Duration, maxY float64, windows []timeWindow, yLabel func(float64) string) *svgChart {
+	c := &svgChart{span: max(span, time.Second), maxY: niceCeil(maxY)}
+	c.sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="chart" role="img">`,
+		chartWidth, chartHeight))
+
+	top, bottom := float64(chartPadTop), float64(chartHeight-chartPadBottom)
+	for _, w := range windows {
+		x1, x2 := c.x(w.Start), c.x(w.End)
+		title := fmt.Sprintf("<title>%s %s</title>", template.HTMLEscapeString(w.Label), formatOffset(w.Start))
+		if x2-x1 < 1 {
+			c.sb.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2" stroke-dasharray="4 2">%s</line>`,
+				x1, top, x1, bottom, w.Color, title))
+			continue
+		}
+		c.sb.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="0.15">%s</rect>`,
+			x1, top, x2-x1, bottom-top, w.Color, title))
+	}
+
+	for i := 0; i <= chartYTicks; i++ {
+		v := c.maxY * float64(i) / chartYTicks
+		y := c.y(v)
+		c.sb.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="gridline"/>`,
+			chartPadLeft, y, chartWidth-chartPadRight, y))
+		c.sb.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" class="axis" text-anchor="end">%s</text>`,
+			chartPadLeft-6, y+4, template.HTMLEscapeString(yLabel(v))))
+	}
+
+	step := chartTickSteps[len(chartTickSteps)-1]
+	for _, s := range chartTickSteps {
+		if c.span/s <= 8 {
+			step = s
+			break
+		}
+	}
+	for t := time.Duration(0); t <= c.span; t += step {
+		c.sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" class="axis" text-anchor="middle">%s</text>`,
+			c.x(t), chartHeight-8, formatOffset(t)))
+	}
+	c.sb.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="baseline"/>`,
+		chartPadLeft, bottom, chartWidth-chartPadRight, bottom))
+	return c
+}
+
+// x 相对测试开始的时间对应的横坐标
+func (c *svgChart) x(offset time.Duration) float64 {
+	offset = min(max(offset, 0), c.span)
+	return chartPadLeft + float64(offset)/float64(c.span)*(chartWidth-chartPadLeft-chartPadRight)
+}
+
+// y 数值对应的纵坐标
+func (c *svgChart) y(v float64) float64 {
+	bottom := float64(chartHeight - chartPadBottom)
+	if c.maxY <= 0 {
+		return bottom
+	}
+	return bottom - min(v/c.maxY, 1)*(bottom-chartPadTop)
+}
+
+// line 绘制折线，value 返回false的点处断开
+func (c *svgChart) line(metrics *core.StabilityMetrics, color string, dashed bool,
+	value func(core.IntervalMetrics) (float64, bool)) {
+	var path strings.Builder
+	move := true
+	for _, im := range metrics.Series {
+		v, ok := value(im)
+		if !ok {
+			move = true
+			continue
+		}
+		cmd := "L"
+		if move {
+			cmd, move = "M", false
+		}
+		mid := im.Start.Sub(metrics.StartTime) + im.Duration/2
+		path.WriteString(fmt.Sprintf("%s%.1f %.1f ", cmd, c.x(mid), c.y(v)))
+	}
+	if path.Len() == 0 {
+		return
+	}
+	dash := ""
+	if dashed {
+		dash = ` stroke-dasharray="6 4"`
+	}
+	c.sb.WriteString(fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-width="1.5"%s/>`,
+		strings.TrimSpace(path.String()), color, dash))
+}
+
+// bar 在区间 [start, start+d) 上绘制从 from 到 to 的矩形
+func (c *svgChart) bar(start, d time.Duration, from, to float64, color, title string) {
+	x1, x2 := c.x(start), c.x(start+d)
+	width := max(x2-x1-1, 1)
+	c.sb.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s</title></rect>`,
+		x1, c.y(to), width, c.y(from)-c.y(to), color, template.HTMLEscapeString(title)))
+}
+
+// html 结束图表并返回SVG
+func (c *svgChart) html() template.HTML {
+	c.sb.WriteString("</svg>")
+	return template.HTML(c.sb.String())
+}
+
+// niceCeil 向上取整到 1、2、5 乘以10的幂，作为纵轴最大值
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if v <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// windowLegend 故障区间的图例
+func windowLegend(lang i18n.Lang, faults, outages []timeWindow) []htmlLegend {
+	var legend []htmlLegend
+	if len(faults) > 0 {
+		legend = append(legend, htmlLegend{Label: lang.T("chart.legend_fault"), Color: colorFault, Band: true})
+	}
+	if len(outages) > 0 {
+		legend = append(legend, htmlLegend{Label: lang.T("chart.legend_outage"), Color: colorOutage, Band: true})
+	}
+	return legend
+}
+
+// htmlCharts 生成延迟百分位、吞吐量和按类型错误数的图表，均标出故障区间
+func htmlCharts(lang i18n.Lang, metrics *core.StabilityMetrics) []htmlChart {
+	if len(metrics.Series) == 0 {
+		return nil
+	}
+	span := seriesSpan(metrics)
+	faults := faultWindows(metrics.Events, span)
+	outages := outageWindows(lang, metrics)
+	windows := append(append([]timeWindow(nil), faults...), outages...)
+	bands := windowLegend(lang, faults, outages)
+
+	var maxLatency time.Duration
+	var maxThroughput, maxErrors float64
+	for _, im := range metrics.Series {
+		maxLatency = max(maxLatency, im.P99Latency)
+		maxThroughput = max(maxThroughput, im.Throughput(), im.TargetRate)
+		maxErrors = max(maxErrors, float64(im.Failures))
+	}
+	hasOps := func(im core.IntervalMetrics) bool { return im.Operations > 0 }
+
+	latency := newSVGChart(span, float64(maxLatency), windows, func(v float64) string {
+		return time.Duration(v).Round(time.Microsecond).String()
+	})
+	latency.line(metrics, colorP50, false, func(im core.IntervalMetrics) (float64, bool) {
+		return float64(im.P50Latency), hasOps(im)
+	})
+	latency.line(metrics, colorP95, false, func(im core.IntervalMetrics) (float64, bool) {
+		return float64(im.P95Latency), hasOps(im)
+	})
+	latency.line(metrics, colorP99, false, func(im core.IntervalMetrics) (float64, bool) {
+		return float64(im.P99Latency), hasOps(im)
+	})
+
+	throughput := newSVGChart(span, maxThroughput, windows, func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	})
+	throughputLegend := []htmlLegend{{Label: lang.T("chart.legend_throughput"), Color: colorThroughput}}
+	if metrics.TargetRate > 0 {
+		throughput.line(metrics, colorTarget, true, func(im core.IntervalMetrics) (float64, bool) {
+			return im.TargetRate, true
+		})
+		throughputLegend = append(throughputLegend, htmlLegend{Label: lang.T("chart.legend_target_rate"), Color: colorTarget})
+	}
+	throughput.line(metrics, colorThroughput, false, func(im core.IntervalMetrics) (float64, bool) {
+		return im.Throughput(), true
+	})
+
+	types := sortedErrorTypes(metrics.ErrorsByType)
+	errorsChart := newSVGChart(span, maxErrors, windows, func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	})
+	var errorLegend []htmlLegend
+	for _, t := range types {
+		errorLegend = append(errorLegend, htmlLegend{Label: errorTypeLabel(lang, t), Color: errorTypeColor(t)})
+	}
+	for _, im := range metrics.Series {
+		start := im.Start.Sub(metrics.StartTime)
+		var stacked float64
+		for _, t := range types {
+			n := float64(im.ErrorsByType[t])
+			if n == 0 {
+				continue
+			}
+			errorsChart.bar(start, im.Duration, stacked, stacked+n, errorTypeColor(t),
+				fmt.Sprintf("%s %s: %.0f", formatOffset(start), errorTypeLabel(lang, t), n))
+			stacked += n
+		}
+	}
+
+	return []htmlChart{
+		{
+			Title: lang.T("chart.latency"),
+			SVG:   latency.html(),
+			Legend: append([]htmlLegend{
+				{Label: "P50", Color: colorP50}, {Label: "P95", Color: colorP95}, {Label: "P99", Color: colorP99},
+			}, bands...),
+		},
+		{Title: lang.T("chart.throughput"), SVG: throughput.html(), Legend: append(throughputLegend, bands...)},
+		{Title: lang.T("chart.errors"), SVG: errorsChart.html(), Legend: append(errorLegend, bands...)},
+	}
+}
+
+// errorTypeColor 错误类型的颜色
+func errorTypeColor(t core.ErrorType) string {
+	if color, ok := errorTypeColors[t]; ok {
+		return color
+	}
+	return errorTypeColors[core.ErrorTypeOther]
+}
@@ -0,0 +1,334 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// HTMLReporterImpl HTML报告生成器
+//
+// 生成单个离线可用的HTML文件：样式和SVG图表全部内联，不引用外部脚本、样式或字体。
+type HTMLReporterImpl struct {
+	localized
+	title string
+}
+
+// NewHTMLReporter 创建新的HTML报告生成器
+func NewHTMLReporter() *HTMLReporterImpl {
+	return &HTMLReporterImpl{}
+}
+
+// SetTitle 设置报告标题，为空时使用报告语言的默认标题
+func (r *HTMLReporterImpl) SetTitle(title string) {
+	r.title = title
+}
+
+// htmlScore 一个评分维度
+type htmlScore struct {
+	Name    string
+	Score   float64
+	Max     float64
+	Percent float64
+}
+
+// htmlErrorRow 错误类型统计
+type htmlErrorRow struct {
+	Label   string
+	Color   string
+	Count   int64
+	Percent float64 // 占失败操作的比例
+}
+
+// htmlReport 模板数据
+type htmlReport struct {
+	Lang        i18n.Lang
+	Title       string
+	Metrics     *core.StabilityMetrics
+	Evaluation  *core.EvaluationResult
+	StatusClass string
+	Scores      []htmlScore
+	Errors      []htmlErrorRow
+	Operations  []*core.OperationMetrics
+	Charts      []htmlChart
+}
+
+// GenerateReport 生成HTML报告
+func (r *HTMLReporterImpl) GenerateReport(
+	metrics *core.StabilityMetrics,
+	evaluation *core.EvaluationResult,
+	output io.Writer,
+) error {
+	title := r.title
+	if title == "" {
+		title = r.t("report.title")
+	}
+	data := htmlReport{
+		Lang:        r.language(),
+		Title:       title,
+		Metrics:     metrics,
+		Evaluation:  evaluation,
+		StatusClass: strings.ToLower(string(evaluation.Status)),
+		Scores: []htmlScore{
+			{Name: r.t("dimension.availability"), Score: evaluation.Scores.Availability, Max: 30},
+			{Name: r.t("dimension.performance"), Score: evaluation.Scores.Performance, Max: 25},
+			{Name: r.t("dimension.reliability"), Score: evaluation.Scores.Reliability, Max: 25},
+			{Name: r.t("dimension.resilience"), Score: evaluation.Scores.Resilience, Max: 20},
+		},
+		Operations: sortedOperations(metrics.Operations),
+		Charts:     htmlCharts(r.lang, metrics),
+	}
+	for i := range data.Scores {
+		data.Scores[i].Percent = data.Scores[i].Score / data.Scores[i].Max * 100
+	}
+	for _, t := range sortedErrorTypes(metrics.ErrorsByType) {
+		n := metrics.ErrorsByType[t]
+		data.Errors = append(data.Errors, htmlErrorRow{
+			Label:   errorTypeLabel(r.lang, t),
+			Color:   errorTypeColor(t),
+			Count:   n,
+			Percent: float64(n) / float64(max(metrics.FailedOperations, 1)) * 100,
+		})
+	}
+
+	tmpl, err := htmlTemplate.Clone()
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(htmlFuncs(r.lang)).Execute(output, data)
+}
+
+// htmlTemplate 报告模板，执行前克隆并绑定报告语言的函数
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs(i18n.Default)).Parse(htmlReportTemplate))
+
+// htmlFuncs 报告模板的函数，t 按报告语言翻译消息
+func htmlFuncs(lang i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"t":      lang.T,
+		"pct":    func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+		"mul100": func(v float64) float64 { return v * 100 },
+		"ms":     func(d time.Duration) time.Duration { return d.Round(time.Millisecond) },
+		"us":     func(d time.Duration) time.Duration { return d.Round(time.Microsecond) },
+		"offset": func(d time.Duration) string { return formatOffset(d) },
+		"since":  func(t, start time.Time) string { return formatOffset(t.Sub(start)) },
+		"errorList": func(errorsByType map[core.ErrorType]int64) string {
+			parts := make([]string, 0, len(errorsByType))
+			for _, t := range sortedErrorTypes(errorsByType) {
+				parts = append(parts, fmt.Sprintf("%s %d", errorTypeLabel(lang, t), errorsByType[t]))
+			}
+			return strings.Join(parts, ", ")
+		},
+		"eventLabel": func(t core.EventType) string { return eventLabel(lang, t) },
+		"yesNo":      func(b bool) string { return yesNo(lang, b) },
+		"lower":      strings.ToLower,
+		"threshold":  func() time.Duration { return core.LateStartThreshold },
+	}
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 0; background: #f3f4f6; color: #111827; }
+main { max-width: 960px; margin: 0 auto; padding: 24px; }
+section { background: #fff; border-radius: 8px; padding: 16px 20px; margin-bottom: 16px; box-shadow: 0 1px 2px rgba(0,0,0,.06); }
+h1 { font-size: 24px; margin: 0 0 4px; }
+h2 { font-size: 18px; margin: 0 0 12px; }
+h3 { font-size: 15px; margin: 16px 0 8px; }
+.meta { color: #6b7280; font-size: 13px; }
+.score { display: flex; align-items: baseline; gap: 12px; margin-top: 12px; }
+.score .value { font-size: 40px; font-weight: 600; }
+.badge { display: inline-block; padding: 2px 10px; border-radius: 12px; font-size: 13px; font-weight: 600; color: #fff; }
+.badge.pass { background: #16a34a; }
+.badge.warning { background: #d97706; }
+.badge.fail { background: #dc2626; }
+.badge.critical, .badge.high { background: #dc2626; }
+.badge.medium { background: #d97706; }
+.badge.low { background: #6b7280; }
+.bar { background: #e5e7eb; border-radius: 4px; height: 10px; width: 240px; display: inline-block; vertical-align: middle; }
+.bar span { display: block; height: 100%; border-radius: 4px; background: #2563eb; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #e5e7eb; }
+th { color: #6b7280; font-weight: 500; }
+td.num, th.num { text-align: right; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(200px, 1fr)); gap: 8px 16px; font-size: 14px; }
+.grid div span { color: #6b7280; display: block; font-size: 12px; }
+svg.chart { width: 100%; height: auto; }
+svg.chart .gridline { stroke: #e5e7eb; }
+svg.chart .baseline { stroke: #9ca3af; }
+svg.chart .axis { fill: #6b7280; font-size: 11px; }
+.legend { font-size: 12px; color: #374151; margin: 4px 0 12px; }
+.legend span { margin-right: 14px; white-space: nowrap; }
+.legend i { display: inline-block; width: 14px; height: 3px; vertical-align: middle; margin-right: 4px; }
+.legend i.band { height: 10px; opacity: .35; }
+.swatch { display: inline-block; width: 10px; height: 10px; border-radius: 2px; margin-right: 6px; }
+.issue, .rec { border-left: 3px solid #e5e7eb; padding: 4px 0 4px 12px; margin-bottom: 12px; }
+.rationale { white-space: pre-wrap; font-size: 14px; }
+</style>
+</head>
+<body>
+<main>
+<section>
+<h1>{{.Title}}</h1>
+<div class="meta">{{t "report.duration"}} {{ms .Metrics.Duration}} · {{t "report.completed"}} {{.Evaluation.EvaluatedAt.Format "2006-01-02 15:04:05"}}</div>
+<div class="score"><span class="value">{{printf "%.1f" .Evaluation.Score}}</span><span>/100 ({{.Evaluation.Grade}})</span>
+<span class="badge {{.StatusClass}}">{{.Evaluation.Status}}</span></div>
+</section>
+
+<section>
+<h2>{{t "report.dimension_scores"}}</h2>
+<table>
+<tr><th>{{t "column.dimension"}}</th><th class="num">{{t "column.score"}}</th><th></th><th class="num">{{t "column.percent"}}</th></tr>
+{{- range .Scores}}
+<tr><td>{{.Name}}</td><td class="num">{{printf "%.1f" .Score}}/{{.Max}}</td>
+<td><span class="bar"><span style="width: {{printf "%.1f" .Percent}}%"></span></span></td>
+<td class="num">{{printf "%.1f" .Percent}}%</td></tr>
+{{- end}}
+</table>
+</section>
+
+<section>
+<h2>{{t "report.key_metrics"}}</h2>
+<div class="grid">
+<div><span>{{t "metric.availability"}}</span>{{pct .Metrics.Availability}}</div>
+<div><span>{{t "metric.total_operations"}}</span>{{.Metrics.TotalOperations}}</div>
+<div><span>{{t "metric.success_failure"}}</span>{{.Metrics.SuccessfulOperations}} / {{.Metrics.FailedOperations}}</div>
+<div><span>{{t "metric.error_rate"}}</span>{{pct .Metrics.ErrorRate}}</div>
+<div><span>{{t "metric.avg_throughput"}}</span>{{printf "%.0f" .Metrics.Throughput}} ops/s</div>
+<div><span>P50 / P95 / P99</span>{{ms .Metrics.P50Latency}} / {{ms .Metrics.P95Latency}} / {{ms .Metrics.P99Latency}}</div>
+<div><span>P99.9 / P99.99 / Max</span>{{ms .Metrics.P999Latency}} / {{ms .Metrics.P9999Latency}} / {{ms .Metrics.MaxLatency}}</div>
+<div><span>{{t "metric.avg_latency"}}</span>{{us .Metrics.AvgLatency}} ({{t "metric.stddev" (us .Metrics.StdDevLatency)}})</div>
+{{- if gt .Metrics.TargetRate 0.0}}
+<div><span>{{t "metric.open_loop"}}</span>{{t "report.open_loop_detail" .Metrics.TargetRate .Metrics.LateOperations threshold (ms .Metrics.MaxScheduleLag)}}</div>
+{{- end}}
+<div><span>{{t "metric.data_loss_rate"}}</span>{{printf "%.4f%%" (mul100 .Metrics.DataLossRate)}}</div>
+{{- with .Metrics.Consistency}}
+<div><span>{{t "metric.data_consistency"}}</span>{{pct $.Metrics.DataConsistency}} ({{.ConsistentReads}}/{{.VerifiedReads}})</div>
+<div><span>{{t "metric.lost_writes"}} / {{t "metric.stale_reads"}} / {{t "metric.corrupted_reads"}}</span>{{.LostWrites}} / {{.StaleReads}} / {{.CorruptedReads}}</div>
+{{- end}}
+{{- with .Metrics.Delivery}}
+<div><span>{{t "metric.delivery"}}</span>{{t "report.delivery_detail" .Acked .Received .Lost}}</div>
+<div><span>{{t "metric.duplicates_out_of_order"}}</span>{{.Duplicates}} / {{.OutOfOrder}}</div>
+<div><span>{{t "metric.e2e_latency"}} P95 / P99</span>{{ms .EndToEndLatency.P95}} / {{ms .EndToEndLatency.P99}}</div>
+{{- end}}
+{{- if gt .Metrics.OutageCount 0}}
+<div><span>{{t "metric.outages"}} / {{t "metric.total_downtime"}}</span>{{.Metrics.OutageCount}} / {{ms .Metrics.TotalDowntime}}</div>
+<div><span>{{t "metric.longest_outage"}}</span>{{ms .Metrics.LongestOutage}}{{if .Metrics.HasUnrecoveredOutage}}{{t "report.unrecovered_note"}}{{end}}</div>
+{{- end}}
+{{- if gt .Metrics.MTTR 0}}
+<div><span>MTTR</span>{{ms .Metrics.MTTR}}</div>
+{{- end}}
+{{- if gt .Metrics.MTBF 0}}
+<div><span>MTBF</span>{{ms .Metrics.MTBF}}</div>
+{{- end}}
+{{- if gt .Metrics.TotalReconnectAttempts 0}}
+<div><span>{{t "metric.reconnect_rate"}}</span>{{printf "%.0f%%" (mul100 .Metrics.ReconnectSuccessRate)}} ({{.Metrics.SuccessfulReconnects}}/{{.Metrics.TotalReconnectAttempts}})</div>
+{{- end}}
+</div>
+</section>
+
+{{- if .Charts}}
+<section>
+<h2>{{t "report.trends"}}</h2>
+{{- range .Charts}}
+<h3>{{.Title}}</h3>
+{{.SVG}}
+<div class="legend">{{range .Legend}}<span><i class="{{if .Band}}band{{end}}" style="background: {{.Color}}"></i>{{.Label}}</span>{{end}}</div>
+{{- end}}
+</section>
+{{- end}}
+
+{{- if .Errors}}
+<section>
+<h2>{{t "report.errors_by_type"}}</h2>
+<table>
+<tr><th>{{t "column.error_type"}}</th><th class="num">{{t "column.count"}}</th><th class="num">{{t "column.share_of_failures"}}</th></tr>
+{{- range .Errors}}
+<tr><td><span class="swatch" style="background: {{.Color}}"></span>{{.Label}}</td><td class="num">{{.Count}}</td><td class="num">{{printf "%.1f" .Percent}}%</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+
+{{- if .Operations}}
+<section>
+<h2>{{t "report.by_operation"}}</h2>
+<table>
+<tr><th>{{t "column.operation"}}</th><th>{{t "column.type"}}</th><th class="num">{{t "column.operations"}}</th><th class="num">{{t "column.availability"}}</th><th class="num">{{t "column.throughput"}}</th><th class="num">P50</th><th class="num">P95</th><th class="num">P99</th><th class="num">P99.9</th><th>{{t "column.errors"}}</th></tr>
+{{- range .Operations}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td class="num">{{.Operations}}</td><td class="num">{{pct .Availability}}</td><td class="num">{{printf "%.0f" .Throughput}} ops/s</td><td class="num">{{ms .P50Latency}}</td><td class="num">{{ms .P95Latency}}</td><td class="num">{{ms .P99Latency}}</td><td class="num">{{ms .P999Latency}}</td><td>{{errorList .ErrorsByType}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+
+{{- if .Metrics.Outages}}
+<section>
+<h2>{{t "report.outage_windows"}}</h2>
+<table>
+<tr><th>{{t "column.start"}}</th><th class="num">{{t "column.duration"}}</th><th class="num">{{t "column.failed_operations"}}</th><th>{{t "column.recovered"}}</th></tr>
+{{- range .Metrics.Outages}}
+<tr><td>{{since .Start $.Metrics.StartTime}}</td><td class="num">{{ms .Duration}}</td><td class="num">{{.Failures}}</td><td>{{yesNo .Recovered}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+
+{{- if .Metrics.Events}}
+<section>
+<h2>{{t "report.timeline"}}</h2>
+<table>
+<tr><th>{{t "column.time"}}</th><th>{{t "column.event"}}</th><th>{{t "column.phase"}}</th><th>{{t "column.fault"}}</th><th>{{t "column.description"}}</th></tr>
+{{- range .Metrics.Events}}
+<tr><td>{{offset .Offset}}</td><td>{{eventLabel .Type}}</td><td>{{.Phase}}</td><td>{{.Fault}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+
+{{- if .Evaluation.Issues}}
+<section>
+<h2>{{t "report.issues" (len .Evaluation.Issues)}}</h2>
+{{- range .Evaluation.Issues}}
+<div class="issue"><span class="badge {{lower .Severity}}">{{.Severity}}</span> <strong>{{.Type}}</strong>
+<div>{{.Message}}</div>
+<div class="meta">{{t "report.metric"}} {{.Metric}} · {{t "report.current"}} {{printf "%.2f" .Current}} · {{t "report.expected"}} {{printf "%.2f" .Expected}}</div></div>
+{{- end}}
+</section>
+{{- end}}
+
+{{- if .Evaluation.Recommendations}}
+<section>
+<h2>{{t "report.recommendations"}}</h2>
+{{- range .Evaluation.Recommendations}}
+<div class="rec"><span class="badge {{lower .Priority}}">{{.Priority}}</span> <strong>{{.Title}}</strong> <span class="meta">{{.Category}}</span>
+{{- if .Message}}
+<div>{{.Message}}</div>
+{{- end}}
+{{- if .Actions}}
+<ul>{{range .Actions}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .References}}
+<div class="meta">{{t "report.references"}}: {{range $i, $ref := .References}}{{if $i}}, {{end}}{{$ref}}{{end}}</div>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+
+<section>
+<h2>{{t "report.conclusion"}}</h2>
+<div class="rationale">{{.Evaluation.Rationale}}</div>
+</section>
+</main>
+</body>
+</html>
+`
@@ -9,6 +9,7 @@ import (
 
 // JSONReporterImpl JSON报告生成器
 type JSONReporterImpl struct {
+	localized
 	indent string
 }
 
@@ -30,6 +31,11 @@ func (r *JSONReporterImpl) GenerateReport(
 	evaluation *core.EvaluationResult,
 	output io.Writer,
 ) error {
+	errorsByType := make(map[string]int64, len(metrics.ErrorsByType))
+	for t, n := range metrics.ErrorsByType {
+		errorsByType[string(t)] = n
+	}
+
 	report := map[string]interface{}{
 		"test_info": map[string]interface{}{
 			"duration":     metrics.Duration.String(),
@@ -54,27 +60,176 @@ func (r *JSONReporterImpl) GenerateReport(
 				"successful_operations": metrics.SuccessfulOperations,
 				"failed_operations":   metrics.FailedOperations,
 				"error_rate":          metrics.ErrorRate,
+				"errors_by_type":      errorsByType,
 			},
 			"performance": map[string]interface{}{
-				"p50_latency_ms": metrics.P50Latency.Milliseconds(),
-				"p95_latency_ms": metrics.P95Latency.Milliseconds(),
-				"p99_latency_ms": metrics.P99Latency.Milliseconds(),
-				"avg_latency_ms": metrics.AvgLatency.Milliseconds(),
-				"throughput":     metrics.Throughput,
+				"p50_latency_ms":    metrics.P50Latency.Milliseconds(),
+				"p95_latency_ms":    metrics.P95Latency.Milliseconds(),
+				"p99_latency_ms":    metrics.P99Latency.Milliseconds(),
+				"p999_latency_ms":   metrics.P999Latency.Milliseconds(),
+				"p9999_latency_ms":  metrics.P9999Latency.Milliseconds(),
+				"avg_latency_ms":    metrics.AvgLatency.Milliseconds(),
+				"stddev_latency_ms": metrics.StdDevLatency.Milliseconds(),
+				"throughput":        metrics.Throughput,
+				"latency_us": map[string]interface{}{
+					"p50":   metrics.P50Latency.Microseconds(),
+					"p95":   metrics.P95Latency.Microseconds(),
+					"p99":   metrics.P99Latency.Microseconds(),
+					"p999":  metrics.P999Latency.Microseconds(),
+					"p9999": metrics.P9999Latency.Microseconds(),
+					"avg":   metrics.AvgLatency.Microseconds(),
+					"max":   metrics.MaxLatency.Microseconds(),
+				},
 			},
 			"reliability": map[string]interface{}{
 				"data_loss_rate": metrics.DataLossRate,
 			},
 		},
+		"lang":            r.language(),
 		"issues":          evaluation.Issues,
 		"recommendations": evaluation.Recommendations,
 	}
 
+	// 添加开环负载的调度情况（如果启用）
+	if metrics.TargetRate > 0 {
+		performance := report["metrics"].(map[string]interface{})["performance"].(map[string]interface{})
+		performance["target_rate"] = metrics.TargetRate
+		performance["late_operations"] = metrics.LateOperations
+		performance["max_schedule_lag_ms"] = metrics.MaxScheduleLag.Milliseconds()
+	}
+
+	// 添加一致性校验结果（如果启用）
+	if c := metrics.Consistency; c != nil {
+		reliability := report["metrics"].(map[string]interface{})["reliability"].(map[string]interface{})
+		reliability["data_consistency"] = metrics.DataConsistency
+		reliability["consistency"] = map[string]interface{}{
+			"acked_writes":     c.AckedWrites,
+			"verified_reads":   c.VerifiedReads,
+			"consistent_reads": c.ConsistentReads,
+			"stale_reads":      c.StaleReads,
+			"lost_writes":      c.LostWrites,
+			"corrupted_reads":  c.CorruptedReads,
+		}
+	}
+
+	// 添加消息投递校验结果（如果启用）
+	if d := metrics.Delivery; d != nil {
+		reliability := report["metrics"].(map[string]interface{})["reliability"].(map[string]interface{})
+		reliability["duplicate_rate"] = metrics.DuplicateRate
+		reliability["out_of_order_rate"] = metrics.OutOfOrderRate
+		reliability["delivery"] = map[string]interface{}{
+			"produced":     d.Produced,
+			"acked":        d.Acked,
+			"received":     d.Received,
+			"lost":         d.Lost,
+			"duplicates":   d.Duplicates,
+			"out_of_order": d.OutOfOrder,
+		}
+		performance := report["metrics"].(map[string]interface{})["performance"].(map[string]interface{})
+		performance["produce_latency_ms"] = latencySummaryMillis(d.ProduceLatency)
+		performance["e2e_latency_ms"] = latencySummaryMillis(d.EndToEndLatency)
+		performance["consumer_lag_ms"] = metrics.ConsumerLag.Milliseconds()
+	}
+
 	// 添加恢复性指标（如果有）
-	if metrics.MTTR > 0 || metrics.ReconnectSuccessRate > 0 {
+	if metrics.MTTR > 0 || metrics.ReconnectSuccessRate > 0 || metrics.OutageCount > 0 {
+		outages := make([]map[string]interface{}, 0, len(metrics.Outages))
+		for _, o := range metrics.Outages {
+			outages = append(outages, map[string]interface{}{
+				"start":       o.Start.Format("2006-01-02T15:04:05.000Z07:00"),
+				"end":         o.End.Format("2006-01-02T15:04:05.000Z07:00"),
+				"duration_ms": o.Duration.Milliseconds(),
+				"failures":    o.Failures,
+				"recovered":   o.Recovered,
+			})
+		}
 		report["metrics"].(map[string]interface{})["resilience"] = map[string]interface{}{
-			"mttr_seconds":          metrics.MTTR.Seconds(),
+			"mttr_seconds":           metrics.MTTR.Seconds(),
+			"mtbf_seconds":           metrics.MTBF.Seconds(),
+			"outage_count":           metrics.OutageCount,
+			"longest_outage_seconds": metrics.LongestOutage.Seconds(),
+			"total_downtime_seconds": metrics.TotalDowntime.Seconds(),
+			"outages":                outages,
 			"reconnect_success_rate": metrics.ReconnectSuccessRate,
+			"reconnect_attempts":     metrics.TotalReconnectAttempts,
+			"successful_reconnects":  metrics.SuccessfulReconnects,
+		}
+	}
+
+	// 添加时间线事件（如果有）
+	if len(metrics.Events) > 0 {
+		events := make([]map[string]interface{}, 0, len(metrics.Events))
+		for _, e := range metrics.Events {
+			events = append(events, map[string]interface{}{
+				"time":      e.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+				"offset_ms": e.Offset.Milliseconds(),
+				"type":      e.Type,
+				"phase":     e.Phase,
+				"fault":     e.Fault,
+				"message":   e.Message,
+			})
+		}
+		report["events"] = events
+	}
+
+	// 添加按操作分类的指标（如果有）
+	if len(metrics.Operations) > 0 {
+		operations := make(map[string]interface{}, len(metrics.Operations))
+		for name, op := range metrics.Operations {
+			opErrors := make(map[string]int64, len(op.ErrorsByType))
+			for t, n := range op.ErrorsByType {
+				opErrors[string(t)] = n
+			}
+			operations[name] = map[string]interface{}{
+				"type":            op.Type,
+				"operations":      op.Operations,
+				"successes":       op.Successes,
+				"failures":        op.Failures,
+				"availability":    op.Availability,
+				"error_rate":      op.ErrorRate,
+				"throughput":      op.Throughput,
+				"errors_by_type":  opErrors,
+				"p50_latency_ms":  op.P50Latency.Milliseconds(),
+				"p95_latency_ms":  op.P95Latency.Milliseconds(),
+				"p99_latency_ms":  op.P99Latency.Milliseconds(),
+				"p999_latency_ms": op.P999Latency.Milliseconds(),
+				"avg_latency_ms":  op.AvgLatency.Milliseconds(),
+				"max_latency_ms":  op.MaxLatency.Milliseconds(),
+			}
+		}
+		report["metrics"].(map[string]interface{})["operations"] = operations
+	}
+
+	// 添加指标时间序列（如果有）
+	if len(metrics.Series) > 0 {
+		intervals := make([]map[string]interface{}, 0, len(metrics.Series))
+		for _, im := range metrics.Series {
+			errorsByType := make(map[string]int64, len(im.ErrorsByType))
+			for t, n := range im.ErrorsByType {
+				errorsByType[string(t)] = n
+			}
+			interval := map[string]interface{}{
+				"offset_ms":      im.Start.Sub(metrics.StartTime).Milliseconds(),
+				"operations":     im.Operations,
+				"successes":      im.Successes,
+				"failures":       im.Failures,
+				"errors_by_type": errorsByType,
+				"p50_latency_ms": im.P50Latency.Milliseconds(),
+				"p95_latency_ms": im.P95Latency.Milliseconds(),
+				"p99_latency_ms": im.P99Latency.Milliseconds(),
+				"max_latency_ms": im.MaxLatency.Milliseconds(),
+				"p50_latency_us": im.P50Latency.Microseconds(),
+				"p95_latency_us": im.P95Latency.Microseconds(),
+				"p99_latency_us": im.P99Latency.Microseconds(),
+			}
+			if metrics.TargetRate > 0 {
+				interval["target_rate"] = im.TargetRate
+			}
+			intervals = append(intervals, interval)
+		}
+		report["series"] = map[string]interface{}{
+			"interval_ms": metrics.SeriesInterval.Milliseconds(),
+			"intervals":   intervals,
 		}
 	}
 
@@ -82,3 +237,13 @@ func (r *JSONReporterImpl) GenerateReport(
 	encoder.SetIndent("", r.indent)
 	return encoder.Encode(report)
 }
+
+// latencySummaryMillis 将延迟摘要转换为毫秒
+func latencySummaryMillis(l core.LatencySummary) map[string]interface{} {
+	return map[string]interface{}{
+		"p50": l.P50.Milliseconds(),
+		"p95": l.P95.Milliseconds(),
+		"p99": l.P99.Milliseconds(),
+		"max": l.Max.Milliseconds(),
+	}
+}
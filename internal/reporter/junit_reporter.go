@@ -0,0 +1,241 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// JUnitReporterImpl JUnit XML报告生成器
+//
+// 生成一个testsuite，供CI系统按测试结果判断是否通过：
+//   - overall: 按 EvaluationResult.Status，FAIL为failure，WARNING为skipped
+//   - 各阈值维度（可用性、P95/P99延迟、错误率、MTTR、数据丢失）: 评估器对该指标报告了问题时，
+//     状态为FAIL或问题为CRITICAL时为failure，否则为skipped；未测量的维度（没有故障、未启用数据校验）为skipped
+//   - 不属于上述维度的问题（如按操作的问题）各为一个用例: CRITICAL为failure，HIGH在状态为FAIL时为failure，
+//     否则与MEDIUM/LOW一样为skipped
+//
+// 总分、等级、状态和各维度得分写入testsuite的properties。
+// 报告固定为英文，问题描述取自评估结果，评估时应使用英文（i18n.En）。
+type JUnitReporterImpl struct {
+	suiteName string
+}
+
+// NewJUnitReporter 创建新的JUnit报告生成器
+func NewJUnitReporter() *JUnitReporterImpl {
+	return &JUnitReporterImpl{suiteName: "middleware-chaos-testing"}
+}
+
+// SetSuiteName 设置testsuite名称
+func (r *JUnitReporterImpl) SetSuiteName(name string) {
+	r.suiteName = name
+}
+
+// junitTestSuites 根元素
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite 测试套件
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+// junitProperty 属性
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase 测试用例，Failure和Skipped都为nil表示通过
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Skipped   *junitSkipped `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitFailure 失败原因
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitSkipped 跳过原因
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// junitDimension 一个阈值维度
+type junitDimension struct {
+	metric   string // 与 core.Issue.Metric 对应
+	value    string // 实测值
+	measured bool   // 是否有测量数据
+	reason   string // 未测量的原因
+}
+
+// JUnit classname
+const (
+	junitClassStatus    = "mct.status"
+	junitClassThreshold = "mct.thresholds"
+	junitClassIssue     = "mct.issues"
+)
+
+// GenerateReport 生成JUnit XML报告
+func (r *JUnitReporterImpl) GenerateReport(
+	metrics *core.StabilityMetrics,
+	evaluation *core.EvaluationResult,
+	output io.Writer,
+) error {
+	suite := junitTestSuite{
+		Name: r.suiteName,
+		Time: junitSeconds(metrics.Duration),
+		Properties: []junitProperty{
+			{Name: "score", Value: fmt.Sprintf("%.1f", evaluation.Score)},
+			{Name: "grade", Value: string(evaluation.Grade)},
+			{Name: "status", Value: string(evaluation.Status)},
+			{Name: "score.availability", Value: fmt.Sprintf("%.1f", evaluation.Scores.Availability)},
+			{Name: "score.performance", Value: fmt.Sprintf("%.1f", evaluation.Scores.Performance)},
+			{Name: "score.reliability", Value: fmt.Sprintf("%.1f", evaluation.Scores.Reliability)},
+			{Name: "score.resilience", Value: fmt.Sprintf("%.1f", evaluation.Scores.Resilience)},
+			{Name: "total_operations", Value: fmt.Sprintf("%d", metrics.TotalOperations)},
+		},
+	}
+	if !metrics.StartTime.IsZero() {
+		suite.Timestamp = metrics.StartTime.Format("2006-01-02T15:04:05")
+	}
+
+	// 总体状态
+	overall := junitTestCase{
+		Name:      "overall",
+		ClassName: junitClassStatus,
+		Time:      suite.Time,
+		SystemOut: fmt.Sprintf("score %.1f/100 (%s) %s\n%s",
+			evaluation.Score, evaluation.Grade, evaluation.Status, evaluation.Rationale),
+	}
+	status := fmt.Sprintf("%s: score %.1f/100 (%s)", evaluation.Status, evaluation.Score, evaluation.Grade)
+	switch evaluation.Status {
+	case core.StatusFail:
+		overall.Failure = &junitFailure{Message: status, Type: string(evaluation.Status), Text: evaluation.Rationale}
+	case core.StatusWarning:
+		overall.Skipped = &junitSkipped{Message: status}
+	}
+	suite.TestCases = append(suite.TestCases, overall)
+
+	// 阈值维度
+	verified := metrics.Consistency != nil || metrics.Delivery != nil
+	dimensions := []junitDimension{
+		{metric: "availability", value: fmt.Sprintf("%.4f%%", metrics.Availability*100), measured: true},
+		{metric: "p95_latency", value: metrics.P95Latency.String(), measured: true},
+		{metric: "p99_latency", value: metrics.P99Latency.String(), measured: true},
+		{metric: "error_rate", value: fmt.Sprintf("%.4f%%", metrics.ErrorRate*100), measured: true},
+		{metric: "mttr", value: metrics.MTTR.String(), measured: metrics.OutageCount > 0 || metrics.MTTR > 0,
+			reason: "no outage detected"},
+		{metric: "data_loss_rate", value: fmt.Sprintf("%.4f%%", metrics.DataLossRate*100), measured: verified,
+			reason: "data verification not enabled"},
+	}
+	dimensionMetrics := make(map[string]bool, len(dimensions))
+	for _, d := range dimensions {
+		dimensionMetrics[d.metric] = true
+		tc := junitTestCase{
+			Name:      d.metric,
+			ClassName: junitClassThreshold,
+			Time:      "0",
+			SystemOut: d.metric + " = " + d.value,
+		}
+		if !d.measured {
+			tc.SystemOut = ""
+			tc.Skipped = &junitSkipped{Message: d.reason}
+		}
+		for _, issue := range evaluation.Issues {
+			if issue.Metric == d.metric {
+				tc.Skipped = nil
+				applyIssueOutcome(&tc, issue, evaluation.Status == core.StatusFail || issue.Severity == "CRITICAL")
+				break
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	// 不属于阈值维度的问题，属于维度的问题已在维度用例中报告
+	for _, issue := range evaluation.Issues {
+		if dimensionMetrics[issue.Metric] {
+			continue
+		}
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s [%s]", issue.Type, issue.Metric),
+			ClassName: junitClassIssue,
+			Time:      "0",
+		}
+		failed := issue.Severity == "CRITICAL" || (issue.Severity == "HIGH" && evaluation.Status == core.StatusFail)
+		applyIssueOutcome(&tc, issue, failed)
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	for _, tc := range suite.TestCases {
+		suite.Tests++
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+
+	doc := junitTestSuites{
+		Name:     r.suiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(output, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(output)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(output, "\n")
+	return err
+}
+
+// applyIssueOutcome 按问题设置用例结果：failed为true时为failure（类型为严重程度），否则为skipped
+func applyIssueOutcome(tc *junitTestCase, issue core.Issue, failed bool) {
+	detail := fmt.Sprintf("%s\nmetric: %s\ncurrent: %.2f\nexpected: %.2f",
+		issue.Message, issue.Metric, issue.Current, issue.Expected)
+	if failed {
+		tc.Failure = &junitFailure{Message: issue.Message, Type: issue.Severity, Text: detail}
+		return
+	}
+	tc.Skipped = &junitSkipped{Message: fmt.Sprintf("[%s] %s", issue.Severity, issue.Message)}
+	tc.SystemOut = detail
+}
+
+// junitSeconds 以秒为单位格式化时长
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
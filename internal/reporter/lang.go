@@ -0,0 +1,54 @@
+package reporter
+
+import (
+	"strings"
+	"unicode"
+
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// localized 报告语言设置，各报告生成器共用
+type localized struct {
+	lang i18n.Lang
+}
+
+// SetLang 设置报告语言，为空时使用默认语言
+func (l *localized) SetLang(lang i18n.Lang) {
+	l.lang = lang
+}
+
+// language 报告语言，未设置时为默认语言
+func (l *localized) language() i18n.Lang {
+	if l.lang == "" {
+		return i18n.Default
+	}
+	return l.lang
+}
+
+// t 按报告语言返回消息
+func (l *localized) t(key string, args ...any) string {
+	return l.lang.T(key, args...)
+}
+
+// displayWidth 终端显示宽度，中文等全角字符占两列
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || (r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// padRight 按显示宽度在右侧补空格
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-displayWidth(s), 0))
+}
+
+// padLeft 按显示宽度在左侧补空格
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-displayWidth(s), 0)) + s
+}
@@ -10,8 +10,10 @@ import (
 )
 
 // MarkdownReporterImpl Markdown报告生成器
+// 设置了自定义模板（SetTemplate）时按模板输出，数据模型见 TemplateData
 type MarkdownReporterImpl struct {
-	template string
+	reportTemplate
+	localized
 }
 
 // NewMarkdownReporter 创建新的Markdown报告生成器
@@ -19,114 +21,241 @@ func NewMarkdownReporter() *MarkdownReporterImpl {
 	return &MarkdownReporterImpl{}
 }
 
-// SetTemplate 设置自定义模板
-func (r *MarkdownReporterImpl) SetTemplate(template string) {
-	r.template = template
-}
-
 // GenerateReport 生成Markdown报告
 func (r *MarkdownReporterImpl) GenerateReport(
 	metrics *core.StabilityMetrics,
 	evaluation *core.EvaluationResult,
 	output io.Writer,
 ) error {
+	if ok, err := r.render(r.lang, metrics, evaluation, output); ok {
+		return err
+	}
+
 	var sb strings.Builder
+	t := r.t
 
 	// 标题
-	sb.WriteString("# 中间件稳定性测试报告\n\n")
+	sb.WriteString("# " + t("report.title") + "\n\n")
 
 	// 测试信息
-	sb.WriteString("## 测试信息\n\n")
-	sb.WriteString(fmt.Sprintf("- **测试时长**: %v\n", metrics.Duration.Round(time.Second)))
-	sb.WriteString(fmt.Sprintf("- **测试完成**: %s\n\n",
+	sb.WriteString("## " + t("report.test_info") + "\n\n")
+	sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("report.duration"), metrics.Duration.Round(time.Second)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %s\n\n", t("report.completed"),
 		evaluation.EvaluatedAt.Format("2006-01-02 15:04:05")))
 
 	// 总体评分
-	sb.WriteString("## 总体评分\n\n")
+	sb.WriteString("## " + t("report.overall_score") + "\n\n")
 	statusSymbol := r.getStatusSymbol(evaluation.Status)
 	sb.WriteString(fmt.Sprintf("**%.1f/100** (%s) %s\n\n", evaluation.Score, evaluation.Grade, statusSymbol))
 
 	// 各维度得分
-	sb.WriteString("### 各维度得分\n\n")
-	sb.WriteString("| 维度 | 得分 | 百分比 | 权重 |\n")
+	sb.WriteString("### " + t("report.dimension_scores") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+		t("column.dimension"), t("column.score"), t("column.percent"), t("column.weight")))
 	sb.WriteString("|------|------|--------|------|\n")
-	sb.WriteString(fmt.Sprintf("| 可用性 | %.1f/30 | %.1f%% | 30%% |\n",
-		evaluation.Scores.Availability,
-		evaluation.Scores.Availability/30*100))
-	sb.WriteString(fmt.Sprintf("| 性能 | %.1f/25 | %.1f%% | 25%% |\n",
-		evaluation.Scores.Performance,
-		evaluation.Scores.Performance/25*100))
-	sb.WriteString(fmt.Sprintf("| 可靠性 | %.1f/25 | %.1f%% | 25%% |\n",
-		evaluation.Scores.Reliability,
-		evaluation.Scores.Reliability/25*100))
-	sb.WriteString(fmt.Sprintf("| 恢复力 | %.1f/20 | %.1f%% | 20%% |\n\n",
-		evaluation.Scores.Resilience,
-		evaluation.Scores.Resilience/20*100))
+	for _, d := range []struct {
+		name  string
+		score float64
+		max   int
+	}{
+		{t("dimension.availability"), evaluation.Scores.Availability, 30},
+		{t("dimension.performance"), evaluation.Scores.Performance, 25},
+		{t("dimension.reliability"), evaluation.Scores.Reliability, 25},
+		{t("dimension.resilience"), evaluation.Scores.Resilience, 20},
+	} {
+		sb.WriteString(fmt.Sprintf("| %s | %.1f/%d | %.1f%% | %d%% |\n",
+			d.name, d.score, d.max, d.score/float64(d.max)*100, d.max))
+	}
+	sb.WriteString("\n")
 
 	// 核心指标
-	sb.WriteString("## 核心指标\n\n")
+	sb.WriteString("## " + t("report.key_metrics") + "\n\n")
 
 	// 可用性
-	sb.WriteString("### 可用性\n\n")
-	sb.WriteString(fmt.Sprintf("- **可用性率**: %.2f%%\n", metrics.Availability*100))
-	sb.WriteString(fmt.Sprintf("- **总操作数**: %d\n", metrics.TotalOperations))
-	sb.WriteString(fmt.Sprintf("- **成功操作**: %d\n", metrics.SuccessfulOperations))
-	sb.WriteString(fmt.Sprintf("- **失败操作**: %d\n", metrics.FailedOperations))
-	sb.WriteString(fmt.Sprintf("- **错误率**: %.2f%%\n\n", metrics.ErrorRate*100))
+	sb.WriteString("### " + t("metric.availability") + "\n\n")
+	sb.WriteString(fmt.Sprintf("- **%s**: %.2f%%\n", t("metric.availability_rate"), metrics.Availability*100))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.total_operations"), metrics.TotalOperations))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.successful_operations"), metrics.SuccessfulOperations))
+	sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.failed_operations"), metrics.FailedOperations))
+	sb.WriteString(fmt.Sprintf("- **%s**: %.2f%%\n\n", t("metric.error_rate"), metrics.ErrorRate*100))
+
+	if types := sortedErrorTypes(metrics.ErrorsByType); len(types) > 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+			t("column.error_type"), t("column.count"), t("column.share_of_failures")))
+		sb.WriteString("|----------|------|------------|\n")
+		for _, et := range types {
+			n := metrics.ErrorsByType[et]
+			sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% |\n",
+				errorTypeLabel(r.lang, et), n, float64(n)/float64(max(metrics.FailedOperations, 1))*100))
+		}
+		sb.WriteString("\n")
+	}
 
 	// 性能指标
-	sb.WriteString("### 性能指标\n\n")
-	sb.WriteString(fmt.Sprintf("- **P50 延迟**: %v\n", metrics.P50Latency.Round(time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("- **P95 延迟**: %v\n", metrics.P95Latency.Round(time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("- **P99 延迟**: %v\n", metrics.P99Latency.Round(time.Millisecond)))
-	sb.WriteString(fmt.Sprintf("- **平均吞吐**: %.0f ops/s\n\n", metrics.Throughput))
+	sb.WriteString("### " + t("report.performance") + "\n\n")
+	sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.latency", "P50"), metrics.P50Latency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.latency", "P95"), metrics.P95Latency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.latency", "P99"), metrics.P99Latency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %v / %v\n", t("metric.latency", "P99.9 / P99.99"),
+		metrics.P999Latency.Round(time.Millisecond), metrics.P9999Latency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.max_latency"), metrics.MaxLatency.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("- **%s**: %v (%s)\n", t("metric.avg_latency"),
+		metrics.AvgLatency.Round(time.Microsecond), t("metric.stddev", metrics.StdDevLatency.Round(time.Microsecond))))
+	sb.WriteString(fmt.Sprintf("- **%s**: %.0f ops/s\n", t("metric.avg_throughput"), metrics.Throughput))
+	if metrics.TargetRate > 0 {
+		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", t("metric.open_loop"),
+			t("report.open_loop_detail", metrics.TargetRate, metrics.LateOperations, core.LateStartThreshold,
+				metrics.MaxScheduleLag.Round(time.Millisecond))))
+	}
+	sb.WriteString("\n")
+	if d := metrics.Delivery; d != nil {
+		sb.WriteString(fmt.Sprintf("| %s | P50 | P95 | P99 | Max |\n", t("column.latency")))
+		sb.WriteString("|------|-----|-----|-----|-----|\n")
+		for _, row := range []struct {
+			name    string
+			latency core.LatencySummary
+		}{
+			{t("column.produce_ack"), d.ProduceLatency},
+			{t("column.end_to_end"), d.EndToEndLatency},
+		} {
+			sb.WriteString(fmt.Sprintf("| %s | %v | %v | %v | %v |\n", row.name,
+				row.latency.P50.Round(time.Millisecond), row.latency.P95.Round(time.Millisecond),
+				row.latency.P99.Round(time.Millisecond), row.latency.Max.Round(time.Millisecond)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// 按操作统计
+	if len(metrics.Operations) > 0 {
+		sb.WriteString("### " + t("report.by_operation") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | P50 | P95 | P99 | P99.9 | %s |\n",
+			t("column.operation"), t("column.type"), t("column.operations"), t("column.availability"),
+			t("column.throughput"), t("column.errors")))
+		sb.WriteString("|------|------|--------|--------|------|-----|-----|-----|-------|------|\n")
+		for _, op := range sortedOperations(metrics.Operations) {
+			errs := make([]string, 0, len(op.ErrorsByType))
+			for _, et := range sortedErrorTypes(op.ErrorsByType) {
+				errs = append(errs, fmt.Sprintf("%s %d", errorTypeLabel(r.lang, et), op.ErrorsByType[et]))
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %.2f%% | %.0f ops/s | %v | %v | %v | %v | %s |\n",
+				op.Name, op.Type, op.Operations, op.Availability*100, op.Throughput,
+				op.P50Latency.Round(time.Millisecond), op.P95Latency.Round(time.Millisecond),
+				op.P99Latency.Round(time.Millisecond), op.P999Latency.Round(time.Millisecond),
+				strings.Join(errs, ", ")))
+		}
+		sb.WriteString("\n")
+	}
 
 	// 可靠性
-	sb.WriteString("### 可靠性\n\n")
-	sb.WriteString(fmt.Sprintf("- **数据丢失率**: %.4f%%\n\n", metrics.DataLossRate*100))
+	sb.WriteString("### " + t("report.reliability") + "\n\n")
+	sb.WriteString(fmt.Sprintf("- **%s**: %.4f%%\n", t("metric.data_loss_rate"), metrics.DataLossRate*100))
+	if c := metrics.Consistency; c != nil {
+		sb.WriteString(fmt.Sprintf("- **%s**: %.4f%% (%d/%d)\n", t("metric.data_consistency"),
+			metrics.DataConsistency*100, c.ConsistentReads, c.VerifiedReads))
+		sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.lost_writes"), c.LostWrites))
+		sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.stale_reads"), c.StaleReads))
+		sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.corrupted_reads"), c.CorruptedReads))
+	}
+	if d := metrics.Delivery; d != nil {
+		sb.WriteString(fmt.Sprintf("- **%s**: %s\n", t("metric.delivery"), t("report.delivery_detail", d.Acked, d.Received, d.Lost)))
+		sb.WriteString(fmt.Sprintf("- **%s**: %.4f%% (%d)\n", t("metric.duplicate_rate"), metrics.DuplicateRate*100, d.Duplicates))
+		sb.WriteString(fmt.Sprintf("- **%s**: %.4f%% (%d)\n", t("metric.out_of_order_rate"), metrics.OutOfOrderRate*100, d.OutOfOrder))
+	}
+	sb.WriteString("\n")
 
 	// 恢复性
-	if metrics.MTTR > 0 || metrics.ReconnectSuccessRate > 0 {
-		sb.WriteString("### 恢复性\n\n")
+	if metrics.OutageCount > 0 || metrics.MTTR > 0 || metrics.ReconnectSuccessRate > 0 {
+		sb.WriteString("### " + t("report.recovery") + "\n\n")
+		if metrics.OutageCount > 0 {
+			sb.WriteString(fmt.Sprintf("- **%s**: %d\n", t("metric.outages"), metrics.OutageCount))
+			sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.total_downtime"), metrics.TotalDowntime.Round(time.Millisecond)))
+			sb.WriteString(fmt.Sprintf("- **%s**: %v\n", t("metric.longest_outage"), metrics.LongestOutage.Round(time.Millisecond)))
+			if metrics.MTBF > 0 {
+				sb.WriteString(fmt.Sprintf("- **MTBF**: %v\n", metrics.MTBF.Round(time.Millisecond)))
+			}
+		}
 		if metrics.MTTR > 0 {
-			sb.WriteString(fmt.Sprintf("- **MTTR**: %v\n", metrics.MTTR.Round(time.Second)))
+			sb.WriteString(fmt.Sprintf("- **MTTR**: %v\n", metrics.MTTR.Round(time.Millisecond)))
+		}
+		if metrics.HasUnrecoveredOutage() {
+			sb.WriteString(fmt.Sprintf("- **%s** ⚠️\n", t("report.unrecovered")))
+		}
+		if metrics.TotalReconnectAttempts > 0 {
+			sb.WriteString(fmt.Sprintf("- **%s**: %.0f%% (%d/%d)\n", t("metric.reconnect_rate"),
+				metrics.ReconnectSuccessRate*100, metrics.SuccessfulReconnects, metrics.TotalReconnectAttempts))
+		} else if metrics.ReconnectSuccessRate > 0 {
+			sb.WriteString(fmt.Sprintf("- **%s**: %.0f%%\n", t("metric.reconnect_rate"), metrics.ReconnectSuccessRate*100))
+		}
+		sb.WriteString("\n")
+	}
+
+	// 故障窗口
+	if len(metrics.Outages) > 0 {
+		sb.WriteString("### " + t("report.outage_windows") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			t("column.start"), t("column.duration"), t("column.failed_operations"), t("column.recovered")))
+		sb.WriteString("|------|----------|----------|--------|\n")
+		for _, o := range metrics.Outages {
+			sb.WriteString(fmt.Sprintf("| %s | %v | %d | %s |\n",
+				formatOffset(o.Start.Sub(metrics.StartTime)),
+				o.Duration.Round(time.Millisecond), o.Failures, yesNo(r.lang, o.Recovered)))
 		}
-		if metrics.ReconnectSuccessRate > 0 {
-			sb.WriteString(fmt.Sprintf("- **重连成功率**: %.0f%%\n", metrics.ReconnectSuccessRate*100))
+		sb.WriteString("\n")
+	}
+
+	// 指标趋势
+	if len(metrics.Series) > 1 {
+		lines, per := timelineLines(r.lang, metrics.Series)
+		sb.WriteString(fmt.Sprintf("## %s\n\n%s\n\n```\n", t("report.trends"), t("report.trends_note", per)))
+		for _, line := range lines {
+			sb.WriteString(fmt.Sprintf("%s  %s\n", line[1], line[0]))
+		}
+		sb.WriteString("```\n\n")
+	}
+
+	// 时间线
+	if len(metrics.Events) > 0 {
+		sb.WriteString("## " + t("report.timeline") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			t("column.time"), t("column.event"), t("column.phase"), t("column.fault"), t("column.description")))
+		sb.WriteString("|------|------|------|------|------|\n")
+		for _, e := range metrics.Events {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				formatOffset(e.Offset), eventLabel(r.lang, e.Type), e.Phase, e.Fault, e.Message))
 		}
 		sb.WriteString("\n")
 	}
 
 	// 发现的问题
 	if len(evaluation.Issues) > 0 {
-		sb.WriteString(fmt.Sprintf("## 发现的问题 (%d个)\n\n", len(evaluation.Issues)))
+		sb.WriteString("## " + t("report.issues", len(evaluation.Issues)) + "\n\n")
 		for _, issue := range evaluation.Issues {
 			sb.WriteString(fmt.Sprintf("### [%s] %s\n\n", issue.Severity, issue.Type))
-			sb.WriteString(fmt.Sprintf("- **指标**: %s\n", issue.Metric))
-			sb.WriteString(fmt.Sprintf("- **当前值**: %.2f\n", issue.Current))
-			sb.WriteString(fmt.Sprintf("- **期望值**: %.2f\n", issue.Expected))
-			sb.WriteString(fmt.Sprintf("- **说明**: %s\n\n", issue.Message))
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", t("report.metric"), issue.Metric))
+			sb.WriteString(fmt.Sprintf("- **%s**: %.2f\n", t("report.current"), issue.Current))
+			sb.WriteString(fmt.Sprintf("- **%s**: %.2f\n", t("report.expected"), issue.Expected))
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n\n", t("report.description"), issue.Message))
 		}
 	}
 
 	// 改进建议
 	if len(evaluation.Recommendations) > 0 {
-		sb.WriteString("## 改进建议\n\n")
+		sb.WriteString("## " + t("report.recommendations") + "\n\n")
 		for _, rec := range evaluation.Recommendations {
 			sb.WriteString(fmt.Sprintf("### [%s] %s\n\n", rec.Priority, rec.Title))
-			sb.WriteString(fmt.Sprintf("**分类**: %s\n\n", rec.Category))
+			sb.WriteString(fmt.Sprintf("**%s**: %s\n\n", t("report.category"), rec.Category))
 			if rec.Message != "" {
 				sb.WriteString(fmt.Sprintf("%s\n\n", rec.Message))
 			}
 			if len(rec.Actions) > 0 {
-				sb.WriteString("**具体行动**:\n\n")
+				sb.WriteString(fmt.Sprintf("**%s**:\n\n", t("report.actions")))
 				for _, action := range rec.Actions {
 					sb.WriteString(fmt.Sprintf("- %s\n", action))
 				}
 				sb.WriteString("\n")
 			}
 			if len(rec.References) > 0 {
-				sb.WriteString("**参考文档**:\n\n")
+				sb.WriteString(fmt.Sprintf("**%s**:\n\n", t("report.references")))
 				for _, ref := range rec.References {
 					sb.WriteString(fmt.Sprintf("- %s\n", ref))
 				}
@@ -136,7 +265,7 @@ func (r *MarkdownReporterImpl) GenerateReport(
 	}
 
 	// 结论
-	sb.WriteString("## 结论\n\n")
+	sb.WriteString("## " + t("report.conclusion") + "\n\n")
 	sb.WriteString(evaluation.Rationale)
 	sb.WriteString("\n")
 
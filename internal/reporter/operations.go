@@ -0,0 +1,22 @@
+package reporter
+
+import (
+	"sort"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// sortedOperations 按操作数降序返回各操作的指标，操作数相同时按名称排序
+func sortedOperations(operations map[string]*core.OperationMetrics) []*core.OperationMetrics {
+	ops := make([]*core.OperationMetrics, 0, len(operations))
+	for _, op := range operations {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Operations != ops[j].Operations {
+			return ops[i].Operations > ops[j].Operations
+		}
+		return ops[i].Name < ops[j].Name
+	})
+	return ops
+}
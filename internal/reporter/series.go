@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// timelineWidth 趋势图的最大列数，更长的时间序列会合并相邻区间
+const timelineWidth = 60
+
+// sparkBlocks 趋势图的字符，从低到高
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// timelineColumn 趋势图的一列，由若干相邻区间合并而成
+type timelineColumn struct {
+	ops        int64
+	successes  int64
+	duration   time.Duration
+	p99Latency time.Duration // 各区间P99的最大值
+	targetOps  float64       // 按目标速率计划的操作数
+}
+
+// timelineColumns 将时间序列合并为不超过width列，返回各列及每列代表的时长
+func timelineColumns(series []core.IntervalMetrics, width int) ([]timelineColumn, time.Duration) {
+	per := (len(series) + width - 1) / width
+	columns := make([]timelineColumn, 0, width)
+	for i := 0; i < len(series); i += per {
+		var col timelineColumn
+		for _, im := range series[i:min(i+per, len(series))] {
+			col.ops += im.Operations
+			col.successes += im.Successes
+			col.duration += im.Duration
+			col.p99Latency = max(col.p99Latency, im.P99Latency)
+			col.targetOps += im.TargetRate * im.Duration.Seconds()
+		}
+		columns = append(columns, col)
+	}
+	return columns, series[0].Duration * time.Duration(per)
+}
+
+// sparkline 按 value/maxValue 绘制趋势图，value 返回false的列显示为空格
+func sparkline(columns []timelineColumn, value func(timelineColumn) (float64, bool), maxValue float64) string {
+	var sb strings.Builder
+	for _, col := range columns {
+		v, ok := value(col)
+		if !ok {
+			sb.WriteRune(' ')
+			continue
+		}
+		level := 0
+		switch {
+		case maxValue <= 0:
+		case v >= maxValue:
+			level = len(sparkBlocks) - 1
+		default:
+			// 只有达到最大值才显示为最高，避免99.9%的可用性与100%无法区分
+			level = min(max(int(v/maxValue*float64(len(sparkBlocks)-1)), 0), len(sparkBlocks)-2)
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+// timelineLines 生成可用性、吞吐量和P99延迟的趋势图，每行为 {名称, 图形}
+// 可用性只有全部成功时显示为最高，没有操作的列显示为空格；
+// 开环模式下增加目标速率，与吞吐量使用相同的刻度以便对比
+func timelineLines(lang i18n.Lang, series []core.IntervalMetrics) ([][2]string, time.Duration) {
+	columns, per := timelineColumns(series, timelineWidth)
+
+	var maxThroughput, maxTarget float64
+	var maxLatency time.Duration
+	for _, col := range columns {
+		maxThroughput = max(maxThroughput, float64(col.ops)/col.duration.Seconds())
+		maxTarget = max(maxTarget, col.targetOps/col.duration.Seconds())
+		maxLatency = max(maxLatency, col.p99Latency)
+	}
+	scale := max(maxThroughput, maxTarget)
+
+	availability := sparkline(columns, func(c timelineColumn) (float64, bool) {
+		return float64(c.successes) / float64(max(c.ops, 1)), c.ops > 0
+	}, 1)
+	throughput := sparkline(columns, func(c timelineColumn) (float64, bool) {
+		return float64(c.ops) / c.duration.Seconds(), true
+	}, scale)
+	latency := sparkline(columns, func(c timelineColumn) (float64, bool) {
+		return float64(c.p99Latency), c.ops > 0
+	}, float64(maxLatency))
+
+	lines := [][2]string{
+		{lang.T("trend.availability"), availability},
+		{lang.T("trend.throughput", maxThroughput), throughput},
+	}
+	if maxTarget > 0 {
+		target := sparkline(columns, func(c timelineColumn) (float64, bool) {
+			return c.targetOps / c.duration.Seconds(), true
+		}, scale)
+		lines = append(lines, [2]string{lang.T("trend.target_rate", maxTarget), target})
+	}
+	lines = append(lines, [2]string{lang.T("trend.p99_latency", maxLatency.Round(time.Millisecond)), latency})
+	return lines, per
+}
@@ -0,0 +1,188 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// TemplateData 自定义报告模板（Markdown和控制台）的数据模型
+type TemplateData struct {
+	Lang        i18n.Lang                // 报告语言
+	Metrics     *core.StabilityMetrics   // 稳定性指标
+	Evaluation  *core.EvaluationResult   // 评估结果
+	Config      *config.Config           // 测试配置（不含密码），未提供时为nil
+	Events      []core.Event             // 时间线事件（阶段切换、故障注入），按时间排序
+	Operations  []*core.OperationMetrics // 各操作的指标，按操作数降序
+	Errors      []TemplateErrorType      // 各错误类型的统计，按错误数降序
+	Scores      []TemplateScore          // 各维度得分，按可用性、性能、可靠性、恢复力排列
+	GeneratedAt time.Time                // 报告生成时间
+}
+
+// TemplateErrorType 一种错误类型的统计
+type TemplateErrorType struct {
+	Type    core.ErrorType
+	Label   string  // 按报告语言的显示名称，如 超时
+	Count   int64   // 错误数
+	Percent float64 // 占失败操作的比例（0-1）
+}
+
+// TemplateScore 一个评分维度的得分
+type TemplateScore struct {
+	Name  string  // 按报告语言的显示名称，如 可用性
+	Score float64 // 得分
+	Max   float64 // 满分
+}
+
+// newTemplateData 组装模板数据
+func newTemplateData(
+	lang i18n.Lang,
+	metrics *core.StabilityMetrics,
+	evaluation *core.EvaluationResult,
+	cfg *config.Config,
+) *TemplateData {
+	data := &TemplateData{
+		Lang:        lang,
+		Metrics:     metrics,
+		Evaluation:  evaluation,
+		Config:      cfg,
+		Events:      metrics.Events,
+		Operations:  sortedOperations(metrics.Operations),
+		GeneratedAt: time.Now(),
+		Scores: []TemplateScore{
+			{Name: lang.T("dimension.availability"), Score: evaluation.Scores.Availability, Max: 30},
+			{Name: lang.T("dimension.performance"), Score: evaluation.Scores.Performance, Max: 25},
+			{Name: lang.T("dimension.reliability"), Score: evaluation.Scores.Reliability, Max: 25},
+			{Name: lang.T("dimension.resilience"), Score: evaluation.Scores.Resilience, Max: 20},
+		},
+	}
+	for _, t := range sortedErrorTypes(metrics.ErrorsByType) {
+		n := metrics.ErrorsByType[t]
+		data.Errors = append(data.Errors, TemplateErrorType{
+			Type:    t,
+			Label:   errorTypeLabel(lang, t),
+			Count:   n,
+			Percent: float64(n) / float64(max(metrics.FailedOperations, 1)),
+		})
+	}
+	return data
+}
+
+// formatDuration 按量级保留精度：1s以上保留到10ms，1ms以上保留到10µs，其余保留到µs
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second || d <= -time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond || d <= -time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// templateFuncs 自定义报告模板可用的函数，t、eventLabel、errorLabel、yesNo 按报告语言输出
+func templateFuncs(lang i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"t":           lang.T,
+		"duration":    formatDuration,
+		"ms":          func(d time.Duration) time.Duration { return d.Round(time.Millisecond) },
+		"us":          func(d time.Duration) time.Duration { return d.Round(time.Microsecond) },
+		"seconds":     func(d time.Duration) float64 { return d.Seconds() },
+		"pct":         func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+		"percent":     func(digits int, v float64) string { return fmt.Sprintf("%.*f%%", digits, v*100) },
+		"offset":      formatOffset,
+		"date":        func(layout string, t time.Time) string { return t.Format(layout) },
+		"eventLabel":  func(t core.EventType) string { return eventLabel(lang, t) },
+		"eventDetail": eventDetail,
+		"errorLabel":  func(t core.ErrorType) string { return errorTypeLabel(lang, t) },
+		"yesNo":       func(b bool) string { return yesNo(lang, b) },
+		"lower":       strings.ToLower,
+		"upper":       strings.ToUpper,
+		"join":        strings.Join,
+		"repeat":      strings.Repeat,
+	}
+}
+
+// ParseTemplate 解析自定义报告模板，语法为 text/template
+func ParseTemplate(text string) (*template.Template, error) {
+	return parseTemplate(i18n.Default, text)
+}
+
+// parseTemplate 按报告语言解析自定义报告模板
+func parseTemplate(lang i18n.Lang, text string) (*template.Template, error) {
+	tmpl, err := template.New("report").Funcs(templateFuncs(lang)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid report template: %v", core.ErrInvalidConfig, err)
+	}
+	return tmpl, nil
+}
+
+// LoadTemplate 读取并解析模板文件，返回模板内容
+func LoadTemplate(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read report template: %w", err)
+	}
+	if _, err := ParseTemplate(string(data)); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// TemplateReporter 支持自定义模板的报告生成器
+type TemplateReporter interface {
+	core.Reporter
+	// SetTemplate 设置自定义模板，为空时使用内置格式
+	SetTemplate(template string)
+	// SetConfig 设置模板数据中的测试配置
+	SetConfig(cfg *config.Config)
+}
+
+// reportTemplate 自定义模板设置，Markdown和控制台报告共用
+type reportTemplate struct {
+	template string
+	config   *config.Config
+}
+
+// SetTemplate 设置自定义模板，为空时使用内置格式
+func (t *reportTemplate) SetTemplate(template string) {
+	t.template = template
+}
+
+// SetConfig 设置模板数据中的测试配置，密码不会出现在报告中
+func (t *reportTemplate) SetConfig(cfg *config.Config) {
+	if cfg == nil {
+		t.config = nil
+		return
+	}
+	redacted := *cfg
+	redacted.Connection.Password = ""
+	t.config = &redacted
+}
+
+// render 设置了模板时按报告语言和模板输出并返回true
+func (t *reportTemplate) render(
+	lang i18n.Lang,
+	metrics *core.StabilityMetrics,
+	evaluation *core.EvaluationResult,
+	output io.Writer,
+) (bool, error) {
+	if t.template == "" {
+		return false, nil
+	}
+	tmpl, err := parseTemplate(lang, t.template)
+	if err != nil {
+		return true, err
+	}
+	if err := tmpl.Execute(output, newTemplateData(lang, metrics, evaluation, t.config)); err != nil {
+		return true, fmt.Errorf("failed to render report template: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"middleware-chaos-testing/internal/chaos"
+	"middleware-chaos-testing/internal/core"
+)
+
+// FaultInjector 故障注入目标，由 chaos.Proxy 实现
+type FaultInjector interface {
+	Faults() chaos.Faults
+	SetFaults(f chaos.Faults)
+	ResetConnections() int
+}
+
+// Runner 场景执行器
+// 实现 orchestrator.Scenario，由编排器在负载运行期间执行
+type Runner struct {
+	scenario *Scenario
+	injector FaultInjector
+	clock    func() time.Duration
+}
+
+// clockPoll 等待阶段结束时重新读取时钟的最长间隔，时钟暂停后恢复时阶段最多因此延长这么久
+const clockPoll = 50 * time.Millisecond
+
+// NewRunner 创建场景执行器
+func NewRunner(scenario *Scenario, injector FaultInjector) *Runner {
+	return &Runner{
+		scenario: scenario,
+		injector: injector,
+	}
+}
+
+// SetClock 设置阶段计时使用的时钟，需在 Run 之前调用
+// clock 返回扣除暂停后的运行时长（如 Orchestrator.Elapsed），暂停期间时钟停止，阶段也随之停止计时；
+// 未设置时使用墙上时钟
+func (r *Runner) SetClock(clock func() time.Duration) {
+	r.clock = clock
+}
+
+// Run 按时间线执行各阶段，直到全部完成或ctx取消
+// 每个阶段的开始/结束和故障的开始/结束都通过 record 记录为事件；
+// 阶段故障叠加在场景开始前已有的故障（如 --fault）之上，阶段结束或ctx取消时恢复为原有故障
+func (r *Runner) Run(ctx context.Context, record func(core.Event)) error {
+	clock := r.clock
+	if clock == nil {
+		start := time.Now()
+		clock = func() time.Duration { return time.Since(start) }
+	}
+	for _, phase := range r.scenario.Phases {
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.runPhase(ctx, phase, clock, record)
+	}
+	return nil
+}
+
+func (r *Runner) runPhase(ctx context.Context, phase Phase, clock func() time.Duration, record func(core.Event)) {
+	emit := func(eventType core.EventType, fault, message string) {
+		record(core.Event{
+			Time:    time.Now(),
+			Type:    eventType,
+			Phase:   phase.Name,
+			Fault:   fault,
+			Message: message,
+		})
+	}
+
+	end := clock() + phase.Duration
+	emit(core.EventPhaseStart, "", "")
+
+	if phase.ResetConnections {
+		n := r.injector.ResetConnections()
+		emit(core.EventFaultInject, "reset_connections", fmt.Sprintf("%d connection(s) reset", n))
+	}
+
+	faults := phase.Faults()
+	base := r.injector.Faults()
+	if !faults.IsZero() {
+		r.injector.SetFaults(base.Overlay(faults))
+		emit(core.EventFaultStart, faults.String(), "")
+	}
+
+	message := ""
+	if !waitUntil(ctx, clock, end) {
+		message = "interrupted"
+	}
+
+	if !faults.IsZero() {
+		r.injector.SetFaults(base)
+		emit(core.EventFaultStop, faults.String(), message)
+	}
+	emit(core.EventPhaseEnd, "", message)
+}
+
+// waitUntil 等待时钟到达end，ctx取消时返回false
+// 时钟可能暂停，因此每隔 clockPoll 重新读取一次
+func waitUntil(ctx context.Context, clock func() time.Duration, end time.Duration) bool {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	for {
+		remaining := end - clock()
+		if remaining <= 0 {
+			return true
+		}
+		timer.Reset(min(remaining, clockPoll))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"middleware-chaos-testing/internal/chaos"
+	"middleware-chaos-testing/internal/core"
+)
+
+// Scenario 混沌场景：按顺序执行的阶段时间线
+//
+// 示例（60s基线，注入200ms延迟30s，t=90s重置所有连接，60s恢复）：
+//
+//	name: latency-then-reset
+//	phases:
+//	  - name: baseline
+//	    duration: 60s
+//	  - name: latency
+//	    duration: 30s
+//	    fault: latency=200ms,jitter=20ms
+//	  - name: recovery
+//	    duration: 60s
+//	    reset_connections: true
+type Scenario struct {
+	Name        string  `yaml:"name"`
+	Description string  `yaml:"description"`
+	Phases      []Phase `yaml:"phases"`
+}
+
+// Phase 场景阶段
+// Fault 在整个阶段内生效，格式同 chaos.ParseFaults；
+// ResetConnections 在阶段开始时重置所有连接
+type Phase struct {
+	Name             string        `yaml:"name"`
+	Duration         time.Duration `yaml:"duration"`
+	Fault            string        `yaml:"fault"`
+	ResetConnections bool          `yaml:"reset_connections"`
+
+	faults chaos.Faults
+}
+
+// Faults 返回解析后的故障配置
+func (p *Phase) Faults() chaos.Faults {
+	return p.faults
+}
+
+// Load 从文件加载场景
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse 解析并验证场景（YAML或JSON）
+func Parse(data []byte) (*Scenario, error) {
+	s := &Scenario{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil {
+		return nil, fmt.Errorf("%w: %v", core.ErrInvalidConfig, err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate 验证场景并解析各阶段的故障描述
+func (s *Scenario) Validate() error {
+	if len(s.Phases) == 0 {
+		return fmt.Errorf("%w: scenario has no phases", core.ErrInvalidConfig)
+	}
+
+	for i := range s.Phases {
+		p := &s.Phases[i]
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("phase-%d", i+1)
+		}
+		if p.Duration <= 0 {
+			return fmt.Errorf("%w: phases[%d] (%s): duration must be positive", core.ErrInvalidConfig, i, p.Name)
+		}
+
+		faults, err := chaos.ParseFaults(p.Fault)
+		if err != nil {
+			return fmt.Errorf("phases[%d] (%s): %w", i, p.Name, err)
+		}
+		p.faults = faults
+	}
+	return nil
+}
+
+// Duration 返回场景总时长
+func (s *Scenario) Duration() time.Duration {
+	var total time.Duration
+	for _, p := range s.Phases {
+		total += p.Duration
+	}
+	return total
+}
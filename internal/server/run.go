@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/orchestrator"
+)
+
+// 运行状态，其余与编排器状态相同（running、paused、stopped、completed）
+const (
+	// StatePending 已提交，编排器尚未开始运行
+	StatePending = "pending"
+	// StateFailed 运行失败且没有结果（如连接失败）
+	StateFailed = "failed"
+)
+
+// run 一次通过API提交的测试
+type run struct {
+	id          string
+	cfg         *config.Config
+	orch        *orchestrator.Orchestrator
+	cancel      context.CancelFunc
+	submittedAt time.Time
+	done        chan struct{} // 运行和评估结束后关闭
+
+	mu         sync.Mutex
+	finishedAt time.Time
+	metrics    *core.StabilityMetrics
+	evaluation *core.EvaluationResult
+	err        error
+}
+
+// finish 记录运行结果
+func (r *run) finish(metrics *core.StabilityMetrics, evaluation *core.EvaluationResult, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finishedAt = time.Now()
+	r.metrics = metrics
+	r.evaluation = evaluation
+	r.err = err
+}
+
+// finished 运行是否已结束
+func (r *run) finished() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// result 返回运行结果，运行未结束或没有结果时返回 ErrNoResult
+func (r *run) result() (*core.StabilityMetrics, *core.EvaluationResult, error) {
+	if !r.finished() {
+		return nil, nil, errStillRunning
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.metrics == nil {
+		return nil, nil, errFailed(r.err)
+	}
+	return r.metrics, r.evaluation, nil
+}
+
+// runStatus 运行状态的JSON表示
+type runStatus struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Middleware  string            `json:"middleware"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	State       string            `json:"state"`
+	Progress    float64           `json:"progress"`
+	Elapsed     string            `json:"elapsed"`
+	ElapsedMs   int64             `json:"elapsed_ms"`
+	Operations  int64             `json:"operations"`
+	Phase       string            `json:"phase,omitempty"`
+	SubmittedAt time.Time         `json:"submitted_at"`
+	FinishedAt  *time.Time        `json:"finished_at,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Result      *runResult        `json:"result,omitempty"`
+}
+
+// runResult 评估结果摘要
+type runResult struct {
+	Score  float64 `json:"score"`
+	Grade  string  `json:"grade"`
+	Status string  `json:"status"`
+}
+
+// status 当前状态，编排器状态取自 GetStatus
+func (r *run) status() *runStatus {
+	st := r.orch.GetStatus()
+	s := &runStatus{
+		ID:          r.id,
+		Name:        r.cfg.Name,
+		Middleware:  r.cfg.GetMiddlewareType(),
+		Labels:      r.cfg.Labels,
+		State:       st.State,
+		Progress:    st.Progress,
+		Elapsed:     st.ElapsedTime.Round(time.Millisecond).String(),
+		ElapsedMs:   st.ElapsedTime.Milliseconds(),
+		Operations:  st.Operations,
+		Phase:       st.Phase,
+		SubmittedAt: r.submittedAt,
+	}
+	if s.State == orchestrator.StateIdle {
+		s.State = StatePending
+	}
+
+	finished := r.finished()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !finished {
+		return s
+	}
+	finishedAt := r.finishedAt
+	s.FinishedAt = &finishedAt
+	if r.err != nil {
+		s.Error = r.err.Error()
+	}
+	if r.metrics == nil {
+		s.State = StateFailed
+	}
+	if e := r.evaluation; e != nil {
+		s.Result = &runResult{Score: e.Score, Grade: string(e.Grade), Status: string(e.Status)}
+	}
+	return s
+}
@@ -0,0 +1,457 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/evaluator"
+	"middleware-chaos-testing/internal/history"
+	"middleware-chaos-testing/internal/i18n"
+	"middleware-chaos-testing/internal/middleware"
+	"middleware-chaos-testing/internal/orchestrator"
+	"middleware-chaos-testing/internal/reporter"
+	"middleware-chaos-testing/internal/workload"
+)
+
+// APIPrefix REST API路径前缀
+const APIPrefix = "/api/v1"
+
+// MaxDefinitionSize 测试定义的最大字节数
+const MaxDefinitionSize = 1 << 20
+
+var (
+	// ErrRunNotFound 运行不存在
+	ErrRunNotFound = errors.New("run not found")
+
+	// ErrNoResult 运行尚未结束或失败，没有指标和评估结果
+	ErrNoResult = errors.New("run has no result")
+
+	// ErrTooManyRuns 同时进行的运行数已达上限
+	ErrTooManyRuns = errors.New("too many active runs")
+
+	errStillRunning = fmt.Errorf("%w: run not finished", ErrNoResult)
+)
+
+func errFailed(err error) error {
+	return fmt.Errorf("%w: run failed: %v", ErrNoResult, err)
+}
+
+// ClientFactory 根据测试定义创建中间件客户端
+type ClientFactory func(cfg *config.Config) (core.MiddlewareClient, error)
+
+// Server 测试控制API服务
+//
+//	POST /api/v1/runs                         提交测试定义（YAML或JSON配置），返回202和运行状态
+//	GET  /api/v1/runs                         按提交顺序列出运行
+//	GET  /api/v1/runs/{id}                    运行状态（编排器状态、进度，结束后含评估摘要）
+//	POST /api/v1/runs/{id}/pause|resume|stop  暂停、恢复、停止
+//	GET  /api/v1/runs/{id}/metrics            稳定性指标
+//	GET  /api/v1/runs/{id}/evaluation         评估结果
+//	GET  /api/v1/runs/{id}/report?format=     按格式生成报告（console|json|markdown|html|junit，默认json）
+//
+// 通过API提交的运行只施加负载，不支持故障注入代理和混沌场景（mct test 的 --proxy、--fault、--scenario），
+// 需要注入故障时在外部注入（如 toxiproxy、tc）。
+type Server struct {
+	newClient ClientFactory
+	history   *history.Store
+	maxActive int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mux    *http.ServeMux
+
+	mu   sync.Mutex
+	runs map[string]*run
+	ids  []string // 提交顺序
+}
+
+// NewServer 创建API服务，默认同时只允许一个运行
+func NewServer(newClient ClientFactory) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		newClient: newClient,
+		maxActive: 1,
+		ctx:       ctx,
+		cancel:    cancel,
+		mux:       http.NewServeMux(),
+		runs:      make(map[string]*run),
+	}
+
+	s.mux.HandleFunc("POST "+APIPrefix+"/runs", s.handleSubmit)
+	s.mux.HandleFunc("GET "+APIPrefix+"/runs", s.handleList)
+	s.mux.HandleFunc("GET "+APIPrefix+"/runs/{id}", s.handleStatus)
+	s.mux.HandleFunc("POST "+APIPrefix+"/runs/{id}/{action}", s.handleControl)
+	s.mux.HandleFunc("GET "+APIPrefix+"/runs/{id}/metrics", s.handleMetrics)
+	s.mux.HandleFunc("GET "+APIPrefix+"/runs/{id}/evaluation", s.handleEvaluation)
+	s.mux.HandleFunc("GET "+APIPrefix+"/runs/{id}/report", s.handleReport)
+	return s
+}
+
+// SetHistory 设置运行历史，每次运行结束后追加记录，需在处理请求之前调用
+func (s *Server) SetHistory(store *history.Store) {
+	s.history = store
+}
+
+// SetMaxActive 设置同时进行的运行数上限，0表示不限制，需在处理请求之前调用
+func (s *Server) SetMaxActive(n int) {
+	s.maxActive = n
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close 停止所有运行并等待结束
+func (s *Server) Close() error {
+	s.cancel()
+	s.mu.Lock()
+	for _, r := range s.runs {
+		_ = r.orch.Stop()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return nil
+}
+
+// Submit 提交测试定义并开始运行，返回运行ID
+// 定义为YAML或JSON配置；定义来自外部调用方，不展开 ${VAR}，也不使用 MCT_USERNAME、MCT_PASSWORD 等环境变量，
+// 以免泄露服务所在主机的环境变量和凭据；history.dir 和 output.template 被忽略，定义中不能包含故障和场景
+func (s *Server) Submit(definition []byte) (string, error) {
+	cfg, err := config.ParseLiteral(definition)
+	if err != nil {
+		return "", err
+	}
+	cfg.History.Dir = ""
+	cfg.Output.Template = ""
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return "", err
+	}
+
+	coll := collector.NewMetricsCollector()
+	coll.SetErrorClassifier(middleware.ClassifyError)
+	coll.SetOutageDetection(cfg.GetOutageDetection())
+	if cfg.Test.LatencyPrecision > 0 {
+		coll.SetLatencyPrecision(cfg.Test.LatencyPrecision)
+	}
+	if cfg.Test.SeriesInterval > 0 {
+		coll.SetSeriesInterval(cfg.Test.SeriesInterval)
+	}
+	testCfg := cfg.GetTestConfig()
+	generator, err := workload.NewGenerator(cfg.GetMiddlewareType(), testCfg.Workload, time.Now().UnixNano())
+	if err != nil {
+		return "", err
+	}
+	var profile *workload.RateProfile
+	if testCfg.Rate > 0 && testCfg.Profile != nil {
+		if profile, err = workload.NewRateProfile(testCfg.Rate, testCfg.Profile, testCfg.Duration); err != nil {
+			return "", err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return "", errors.New("server closed")
+	}
+	if s.maxActive > 0 {
+		active := 0
+		for _, r := range s.runs {
+			if !r.finished() {
+				active++
+			}
+		}
+		if active >= s.maxActive {
+			return "", fmt.Errorf("%w: limit is %d", ErrTooManyRuns, s.maxActive)
+		}
+	}
+
+	// 客户端在所有校验通过后创建，被拒绝的提交不会留下未关闭的客户端
+	client, err := s.newClient(cfg)
+	if err != nil {
+		return "", err
+	}
+	orch := orchestrator.NewOrchestrator(client, coll, generator)
+	if profile != nil {
+		orch.SetRateSchedule(profile)
+	}
+
+	submittedAt := time.Now()
+	id := fmt.Sprintf("%s-%04x", submittedAt.Format("20060102-150405"), rand.IntN(0x10000))
+	for s.runs[id] != nil {
+		id = fmt.Sprintf("%s-%04x", submittedAt.Format("20060102-150405"), rand.IntN(0x10000))
+	}
+
+	// 与 mct test 相同，按时长运行时最多等待30秒收尾
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if testCfg.Duration > 0 {
+		ctx, cancel = context.WithTimeout(s.ctx, testCfg.Duration+30*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(s.ctx)
+	}
+	r := &run{
+		id:          id,
+		cfg:         cfg,
+		orch:        orch,
+		cancel:      cancel,
+		submittedAt: submittedAt,
+		done:        make(chan struct{}),
+	}
+	s.runs[id] = r
+	s.ids = append(s.ids, id)
+
+	s.wg.Add(1)
+	go s.execute(ctx, r)
+	return id, nil
+}
+
+// execute 运行测试、评估并记录运行历史
+func (s *Server) execute(ctx context.Context, r *run) {
+	defer s.wg.Done()
+	defer close(r.done)
+	defer r.cancel()
+
+	metrics, err := r.orch.Run(ctx, r.cfg)
+	var evaluation *core.EvaluationResult
+	if metrics != nil {
+		evaluation = evaluator.EvaluateMiddleware(r.cfg.GetMiddlewareType(), r.cfg.GetThresholds(), r.cfg.ReportLang(), metrics)
+		if !r.cfg.GetOutputConfig().IncludeRecommendations {
+			evaluation.Recommendations = nil
+		}
+		if s.history != nil {
+			record := history.NewRecord(r.cfg, metrics, evaluation)
+			record.ID = r.id
+			if err := s.history.Append(record); err != nil {
+				log.Printf("run %s: failed to record history: %v", r.id, err)
+			}
+		}
+	}
+	r.finish(metrics, evaluation, err)
+}
+
+// lookup 按ID查找运行
+func (s *Server) lookup(id string) (*run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.runs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, id)
+	}
+	return r, nil
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, req *http.Request) {
+	// 只接受YAML和JSON，浏览器跨域发送的 text/plain 等简单请求被拒绝
+	if !definitionContentType(req.Header.Get("Content-Type")) {
+		writeError(w, http.StatusUnsupportedMediaType,
+			fmt.Errorf("%w: test definition must be sent as application/yaml or application/json", core.ErrInvalidConfig))
+		return
+	}
+	definition, err := io.ReadAll(http.MaxBytesReader(w, req.Body, MaxDefinitionSize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if len(bytes.TrimSpace(definition)) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: empty test definition", core.ErrInvalidConfig))
+		return
+	}
+
+	id, err := s.Submit(definition)
+	if err != nil {
+		writeError(w, statusCode(err), err)
+		return
+	}
+	r, _ := s.lookup(id)
+	w.Header().Set("Location", APIPrefix+"/runs/"+id)
+	writeJSON(w, http.StatusAccepted, r.status())
+}
+
+// definitionContentType 是否为测试定义接受的类型（YAML或JSON）
+func definitionContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml", "application/json":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Server) handleList(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	runs := make([]*run, len(s.ids))
+	for i, id := range s.ids {
+		runs[i] = s.runs[id]
+	}
+	s.mu.Unlock()
+
+	statuses := make([]*runStatus, len(runs))
+	for i, r := range runs {
+		statuses[i] = r.status()
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"runs": statuses})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, req *http.Request) {
+	r, err := s.lookup(req.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, r.status())
+}
+
+// handleControl 暂停、恢复或停止运行；停止尚未开始的运行时取消运行
+func (s *Server) handleControl(w http.ResponseWriter, req *http.Request) {
+	r, err := s.lookup(req.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	switch action := req.PathValue("action"); action {
+	case "pause":
+		err = r.orch.Pause()
+	case "resume":
+		err = r.orch.Resume()
+	case "stop":
+		if r.finished() {
+			err = orchestrator.ErrNotRunning
+			break
+		}
+		if stopErr := r.orch.Stop(); stopErr != nil {
+			r.cancel()
+		}
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown action %q (want pause, resume or stop)", action))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, r.status())
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, req *http.Request) {
+	s.withResult(w, req, func(metrics *core.StabilityMetrics, _ *core.EvaluationResult) {
+		writeJSON(w, http.StatusOK, metrics)
+	})
+}
+
+func (s *Server) handleEvaluation(w http.ResponseWriter, req *http.Request) {
+	s.withResult(w, req, func(_ *core.StabilityMetrics, evaluation *core.EvaluationResult) {
+		writeJSON(w, http.StatusOK, evaluation)
+	})
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, req *http.Request) {
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	// 报告语言与运行配置的 output.lang 一致，和评估结果中的问题描述、建议保持同一语言
+	lang := i18n.Default
+	r, err := s.lookup(req.PathValue("id"))
+	if err == nil {
+		lang = r.cfg.ReportLang()
+	}
+	rep, err := reporter.NewReporter(format, lang)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.withResult(w, req, func(metrics *core.StabilityMetrics, evaluation *core.EvaluationResult) {
+		// JUnit报告固定为英文，按英文重新评估以得到英文的问题描述
+		if format == "junit" && r != nil && lang != i18n.En {
+			evaluation = evaluator.EvaluateMiddleware(r.cfg.GetMiddlewareType(), r.cfg.GetThresholds(), i18n.En, metrics)
+		}
+		var buf bytes.Buffer
+		if err := rep.GenerateReport(metrics, evaluation, &buf); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", reporter.ContentType(format))
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// withResult 运行结束且有结果时调用 fn，否则返回404或409
+func (s *Server) withResult(w http.ResponseWriter, req *http.Request,
+	fn func(*core.StabilityMetrics, *core.EvaluationResult)) {
+	r, err := s.lookup(req.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	metrics, evaluation, err := r.result()
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	fn(metrics, evaluation)
+}
+
+// statusCode 提交错误对应的HTTP状态码
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrTooManyRuns):
+		return http.StatusTooManyRequests
+	case errors.Is(err, core.ErrInvalidConfig), errors.Is(err, core.ErrInvalidThresholds),
+		errors.Is(err, core.ErrUnsupportedOperation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorResponse 错误的JSON表示，配置校验错误逐字段列出
+type errorResponse struct {
+	Error  string       `json:"error"`
+	Fields []fieldError `json:"fields,omitempty"`
+}
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	resp := errorResponse{Error: err.Error()}
+	var ve config.ValidationErrors
+	if errors.As(err, &ve) {
+		for _, fe := range ve {
+			resp.Fields = append(resp.Fields, fieldError{Field: fe.Field, Message: fe.Message})
+		}
+	}
+	writeJSON(w, code, resp)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
@@ -0,0 +1,206 @@
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// DefaultValueSize 写操作未配置 ValueSize 时的默认值大小（字节）
+const DefaultValueSize = 64
+
+// Generator 按权重生成操作的工作负载生成器
+// 实现 orchestrator.OperationGenerator，可被多个worker并发调用
+type Generator struct {
+	middlewareType string
+	entries        []*entry
+	totalWeight    int
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// entry 单个工作负载条目
+type entry struct {
+	config   core.WorkloadConfig
+	expander *keyExpander
+	write    bool
+}
+
+// NewGenerator 创建工作负载生成器
+// workloads 为空时使用该中间件的默认工作负载
+func NewGenerator(middlewareType string, workloads []core.WorkloadConfig, seed int64) (*Generator, error) {
+	if len(workloads) == 0 {
+		workloads = DefaultWorkload(middlewareType)
+	}
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("%w: no workload for middleware %q", core.ErrInvalidConfig, middlewareType)
+	}
+
+	g := &Generator{
+		middlewareType: middlewareType,
+		rng:            rand.New(rand.NewSource(seed)),
+	}
+
+	for i, wl := range workloads {
+		if wl.Weight < 0 {
+			return nil, fmt.Errorf("%w: workload[%d] weight must not be negative", core.ErrInvalidConfig, i)
+		}
+		if wl.ValueSize < 0 {
+			return nil, fmt.Errorf("%w: workload[%d] value_size must not be negative", core.ErrInvalidConfig, i)
+		}
+
+		// 通过工厂校验操作名，并判断是否需要生成值
+		op, err := middleware.NewOperation(middlewareType, wl.Operation, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: workload[%d]: %v", core.ErrInvalidConfig, i, err)
+		}
+
+		keyPattern := wl.KeyPattern
+		if keyPattern == "" {
+			keyPattern = DefaultKeyPattern(middlewareType)
+		}
+		pattern, err := ParseKeyPattern(keyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("workload[%d]: %w", i, err)
+		}
+
+		if wl.Weight == 0 {
+			continue
+		}
+
+		g.entries = append(g.entries, &entry{
+			config:   wl,
+			expander: newKeyExpander(pattern, g.rng),
+			write:    op.Type() == core.OpTypeWrite,
+		})
+		g.totalWeight += wl.Weight
+	}
+
+	if g.totalWeight == 0 {
+		return nil, fmt.Errorf("%w: total workload weight must be positive", core.ErrInvalidConfig)
+	}
+
+	return g, nil
+}
+
+// Next 按权重选择操作，展开键并生成值
+func (g *Generator) Next(seq int64) core.Operation {
+	g.mu.Lock()
+	e := g.pick()
+	key := e.expander.expand(g.rng)
+	g.mu.Unlock()
+
+	var value []byte
+	if e.write {
+		size := e.config.ValueSize
+		if size == 0 {
+			size = DefaultValueSize
+		}
+		value = generateValue(seq, size)
+	}
+
+	op, err := middleware.NewOperation(g.middlewareType, e.config.Operation, key, value)
+	if err != nil {
+		// 构造时已校验过操作名
+		return nil
+	}
+	return op
+}
+
+// pick 按权重选择条目，调用方需持有锁
+func (g *Generator) pick() *entry {
+	if len(g.entries) == 1 {
+		return g.entries[0]
+	}
+	n := g.rng.Intn(g.totalWeight)
+	for _, e := range g.entries {
+		if n < e.config.Weight {
+			return e
+		}
+		n -= e.config.Weight
+	}
+	return g.entries[len(g.entries)-1]
+}
+
+// valueFiller 值填充字符
+const valueFiller = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// generateValue 生成指定大小的值，以操作序号开头便于排查
+func generateValue(seq int64, size int) []byte {
+	value := make([]byte, size)
+	prefix := strconv.FormatInt(seq, 10) + "-"
+	n := copy(value, prefix)
+	for i := n; i < size; i++ {
+		value[i] = valueFiller[(int(seq)+i)%len(valueFiller)]
+	}
+	return value
+}
+
+// DefaultKeyPattern 返回中间件的默认键模式，工作负载未配置 key_pattern 时使用
+func DefaultKeyPattern(middlewareType string) string {
+	if strings.ToLower(middlewareType) == "kafka" {
+		return "test-key-{seq}"
+	}
+	return "test:key:{seq}"
+}
+
+// DefaultWorkload 返回中间件的默认工作负载
+// Redis: SET/GET各半，读取顺序写入的键；Kafka: 生产/消费各半
+func DefaultWorkload(middlewareType string) []core.WorkloadConfig {
+	switch strings.ToLower(middlewareType) {
+	case "redis":
+		return []core.WorkloadConfig{
+			{Operation: "set", Weight: 50, KeyPattern: DefaultKeyPattern(middlewareType), ValueSize: DefaultValueSize},
+			{Operation: "get", Weight: 50, KeyPattern: DefaultKeyPattern(middlewareType)},
+		}
+	case "kafka":
+		return []core.WorkloadConfig{
+			{Operation: "produce", Weight: 50, KeyPattern: DefaultKeyPattern(middlewareType), ValueSize: DefaultValueSize},
+			{Operation: "consume", Weight: 50},
+		}
+	default:
+		return nil
+	}
+}
+
+// ParseSpec 解析命令行工作负载描述
+// 格式: operation=set,weight=40,key_pattern=user:{uniform:1000},value_size=1024
+func ParseSpec(spec string) (core.WorkloadConfig, error) {
+	var wl core.WorkloadConfig
+	wl.Weight = 1
+
+	for _, field := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return wl, fmt.Errorf("%w: workload field %q must be name=value", core.ErrInvalidConfig, field)
+		}
+
+		var err error
+		switch name {
+		case "operation", "op":
+			wl.Operation = value
+		case "weight":
+			wl.Weight, err = strconv.Atoi(value)
+		case "key_pattern", "key":
+			wl.KeyPattern = value
+		case "value_size", "size":
+			wl.ValueSize, err = strconv.Atoi(value)
+		default:
+			return wl, fmt.Errorf("%w: unknown workload field %q", core.ErrInvalidConfig, name)
+		}
+		if err != nil {
+			return wl, fmt.Errorf("%w: workload field %s: %v", core.ErrInvalidConfig, name, err)
+		}
+	}
+
+	if wl.Operation == "" {
+		return wl, fmt.Errorf("%w: workload %q has no operation", core.ErrInvalidConfig, spec)
+	}
+	return wl, nil
+}
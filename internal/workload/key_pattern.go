@@ -0,0 +1,185 @@
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// 键分布类型
+const (
+	DistSequential = "seq"     // 顺序递增
+	DistRandom     = "random"  // 区间内随机 [min, max]
+	DistUniform    = "uniform" // 键空间内均匀分布 [0, n)
+	DistZipfian    = "zipf"    // 键空间内zipfian分布 [0, n)，0最热
+)
+
+// defaultZipfExponent zipfian分布的默认指数（必须大于1）
+const defaultZipfExponent = 1.1
+
+// KeyPattern 解析后的键模式
+//
+// 占位符语法：
+//
+//	{id} 或 {seq}        每个工作负载条目独立的顺序计数器
+//	{random:MIN-MAX}     区间[MIN, MAX]内的随机整数
+//	{uniform:N}          [0, N)内的均匀随机整数
+//	{zipf:N} {zipf:N:S}  [0, N)内的zipfian分布整数，S为指数（默认1.1）
+//
+// 例如 "user:{zipf:10000}:profile"
+type KeyPattern struct {
+	raw      string
+	segments []segment
+}
+
+// segment 键模式片段：字面量或占位符
+type segment struct {
+	literal string
+
+	dist     string
+	min, max int64
+	exponent float64
+}
+
+// ParseKeyPattern 解析键模式
+func ParseKeyPattern(pattern string) (*KeyPattern, error) {
+	kp := &KeyPattern{raw: pattern}
+
+	rest := pattern
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			kp.segments = append(kp.segments, segment{literal: rest})
+			break
+		}
+		if open > 0 {
+			kp.segments = append(kp.segments, segment{literal: rest[:open]})
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated placeholder in key pattern %q",
+				core.ErrInvalidConfig, pattern)
+		}
+
+		seg, err := parsePlaceholder(rest[open+1 : open+end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: key pattern %q: %v", core.ErrInvalidConfig, pattern, err)
+		}
+		kp.segments = append(kp.segments, seg)
+		rest = rest[open+end+1:]
+	}
+
+	return kp, nil
+}
+
+// parsePlaceholder 解析占位符内容（不含花括号）
+func parsePlaceholder(body string) (segment, error) {
+	parts := strings.Split(body, ":")
+
+	switch parts[0] {
+	case "id", "seq":
+		if len(parts) != 1 {
+			return segment{}, fmt.Errorf("{%s} takes no arguments", parts[0])
+		}
+		return segment{dist: DistSequential}, nil
+
+	case DistRandom:
+		if len(parts) != 2 {
+			return segment{}, fmt.Errorf("expected {random:MIN-MAX}, got {%s}", body)
+		}
+		bounds := strings.SplitN(parts[1], "-", 2)
+		if len(bounds) != 2 {
+			return segment{}, fmt.Errorf("expected {random:MIN-MAX}, got {%s}", body)
+		}
+		min, err := strconv.ParseInt(bounds[0], 10, 64)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid min in {%s}", body)
+		}
+		max, err := strconv.ParseInt(bounds[1], 10, 64)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid max in {%s}", body)
+		}
+		if max < min {
+			return segment{}, fmt.Errorf("max < min in {%s}", body)
+		}
+		return segment{dist: DistRandom, min: min, max: max}, nil
+
+	case DistUniform, DistZipfian:
+		if len(parts) < 2 || (parts[0] == DistUniform && len(parts) > 2) || len(parts) > 3 {
+			return segment{}, fmt.Errorf("invalid placeholder {%s}", body)
+		}
+		n, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || n <= 0 {
+			return segment{}, fmt.Errorf("keyspace size must be a positive integer in {%s}", body)
+		}
+		seg := segment{dist: parts[0], max: n - 1}
+		if parts[0] == DistZipfian {
+			seg.exponent = defaultZipfExponent
+			if len(parts) == 3 {
+				s, err := strconv.ParseFloat(parts[2], 64)
+				if err != nil || s <= 1 {
+					return segment{}, fmt.Errorf("zipf exponent must be > 1 in {%s}", body)
+				}
+				seg.exponent = s
+			}
+		}
+		return seg, nil
+
+	default:
+		return segment{}, fmt.Errorf("unknown placeholder {%s}", body)
+	}
+}
+
+// String 返回原始模式
+func (kp *KeyPattern) String() string {
+	return kp.raw
+}
+
+// keyExpander 为单个工作负载条目展开键模式，非并发安全
+type keyExpander struct {
+	pattern *KeyPattern
+	counter int64
+	zipfs   []*rand.Zipf // 与segments一一对应
+}
+
+func newKeyExpander(pattern *KeyPattern, rng *rand.Rand) *keyExpander {
+	ke := &keyExpander{
+		pattern: pattern,
+		zipfs:   make([]*rand.Zipf, len(pattern.segments)),
+	}
+	for i, seg := range pattern.segments {
+		if seg.dist == DistZipfian {
+			ke.zipfs[i] = rand.NewZipf(rng, seg.exponent, 1, uint64(seg.max))
+		}
+	}
+	return ke
+}
+
+// expand 生成下一个键
+func (ke *keyExpander) expand(rng *rand.Rand) string {
+	if len(ke.pattern.segments) == 1 && ke.pattern.segments[0].dist == "" {
+		return ke.pattern.segments[0].literal
+	}
+
+	var b strings.Builder
+	seq := ke.counter
+	ke.counter++
+
+	for i, seg := range ke.pattern.segments {
+		switch seg.dist {
+		case "":
+			b.WriteString(seg.literal)
+		case DistSequential:
+			b.WriteString(strconv.FormatInt(seq, 10))
+		case DistRandom, DistUniform:
+			b.WriteString(strconv.FormatInt(seg.min+rng.Int63n(seg.max-seg.min+1), 10))
+		case DistZipfian:
+			b.WriteString(strconv.FormatUint(ke.zipfs[i].Uint64(), 10))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package workload
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"middleware-chaos-testing/internal/core"
+)
+
+// profileStep 按速率曲线计算发送时间和平均速率时的积分步长
+const profileStep = 10 * time.Millisecond
+
+// RateProfile 开环模式的目标速率曲线
+// 时间均为扣除暂停后的运行时长；profile为nil时为恒定速率
+type RateProfile struct {
+	base    float64
+	profile core.LoadProfile
+	shaped  bool
+}
+
+// NewRateProfile 创建目标速率曲线，rate为基准速率（TestConfig.Rate），duration为测试时长
+func NewRateProfile(rate float64, profile *core.LoadProfile, duration time.Duration) (*RateProfile, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("%w: load profile requires a positive rate", core.ErrInvalidConfig)
+	}
+	rp := &RateProfile{base: rate}
+	if profile == nil {
+		return rp, nil
+	}
+	rp.profile = *profile
+	rp.shaped = true
+
+	p := &rp.profile
+	switch p.Shape {
+	case core.ProfileRamp:
+		if p.StartRate < 0 {
+			return nil, fmt.Errorf("%w: ramp start_rate must not be negative", core.ErrInvalidConfig)
+		}
+		if p.RampTime <= 0 {
+			p.RampTime = duration
+		}
+		if p.RampTime <= 0 {
+			return nil, fmt.Errorf("%w: ramp requires ramp_time or a test duration", core.ErrInvalidConfig)
+		}
+	case core.ProfileStep:
+		if p.StartRate < 0 || p.StartRate > rate {
+			return nil, fmt.Errorf("%w: step start_rate must be between 0 and rate %g", core.ErrInvalidConfig, rate)
+		}
+		if p.StepRate <= 0 || p.StepTime <= 0 {
+			return nil, fmt.Errorf("%w: step requires positive step_rate and step_time", core.ErrInvalidConfig)
+		}
+	case core.ProfileSpike:
+		if p.SpikeRate < 0 || p.SpikeAt < 0 || p.SpikeTime <= 0 {
+			return nil, fmt.Errorf("%w: spike requires spike_rate, spike_at and a positive spike_time",
+				core.ErrInvalidConfig)
+		}
+		if p.Period != 0 && p.Period <= p.SpikeTime {
+			return nil, fmt.Errorf("%w: spike period must be longer than spike_time", core.ErrInvalidConfig)
+		}
+	case core.ProfileSine:
+		if p.Amplitude < 0 || p.Period <= 0 {
+			return nil, fmt.Errorf("%w: sine requires a positive period and non-negative amplitude",
+				core.ErrInvalidConfig)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown load profile shape %q (want ramp, step, spike or sine)",
+			core.ErrInvalidConfig, p.Shape)
+	}
+	return rp, nil
+}
+
+// Rate 返回运行时长为elapsed时的目标速率 (ops/s)
+func (rp *RateProfile) Rate(elapsed time.Duration) float64 {
+	if !rp.shaped {
+		return rp.base
+	}
+
+	p := &rp.profile
+	switch p.Shape {
+	case core.ProfileRamp:
+		if elapsed >= p.RampTime {
+			return rp.base
+		}
+		return p.StartRate + (rp.base-p.StartRate)*float64(elapsed)/float64(p.RampTime)
+	case core.ProfileStep:
+		return min(p.StartRate+float64(elapsed/p.StepTime)*p.StepRate, rp.base)
+	case core.ProfileSpike:
+		since := elapsed - p.SpikeAt
+		if since >= 0 && p.Period > 0 {
+			since %= p.Period
+		}
+		if since >= 0 && since < p.SpikeTime {
+			return p.SpikeRate
+		}
+		return rp.base
+	case core.ProfileSine:
+		phase := 2 * math.Pi * float64(elapsed%p.Period) / float64(p.Period)
+		return max(rp.base+p.Amplitude*math.Sin(phase), 0)
+	}
+	return rp.base
+}
+
+// Next 返回从from开始累计目标操作数达到1的时间点，即下一个操作的计划发送时间
+func (rp *RateProfile) Next(from time.Duration) time.Duration {
+	if !rp.shaped {
+		return from + time.Duration(float64(time.Second)/rp.base)
+	}
+
+	need := 1.0
+	for t := from; ; t += profileStep {
+		r := rp.Rate(t)
+		if r > 0 && need/r < profileStep.Seconds() {
+			return t + time.Duration(need/r*float64(time.Second))
+		}
+		need -= r * profileStep.Seconds()
+	}
+}
+
+// Average 返回 [from, to) 内的平均目标速率
+func (rp *RateProfile) Average(from, to time.Duration) float64 {
+	if to <= from {
+		return 0
+	}
+	if !rp.shaped {
+		return rp.base
+	}
+
+	var ops float64
+	for t := from; t < to; t += profileStep {
+		step := min(profileStep, to-t)
+		ops += rp.Rate(t+step/2) * step.Seconds()
+	}
+	return ops / (to - from).Seconds()
+}
+
+// ParseProfileSpec 解析命令行负载曲线描述
+// 格式: shape=ramp,start_rate=100,ramp_time=2m；只有形状时可简写为 ramp
+func ParseProfileSpec(spec string) (*core.LoadProfile, error) {
+	p := &core.LoadProfile{}
+	for i, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			if i == 0 {
+				p.Shape = field
+				continue
+			}
+			return nil, fmt.Errorf("%w: profile field %q must be name=value", core.ErrInvalidConfig, field)
+		}
+
+		var err error
+		switch name {
+		case "shape":
+			p.Shape = value
+		case "start_rate":
+			p.StartRate, err = strconv.ParseFloat(value, 64)
+		case "ramp_time":
+			p.RampTime, err = time.ParseDuration(value)
+		case "step_rate":
+			p.StepRate, err = strconv.ParseFloat(value, 64)
+		case "step_time":
+			p.StepTime, err = time.ParseDuration(value)
+		case "spike_rate":
+			p.SpikeRate, err = strconv.ParseFloat(value, 64)
+		case "spike_at":
+			p.SpikeAt, err = time.ParseDuration(value)
+		case "spike_time":
+			p.SpikeTime, err = time.ParseDuration(value)
+		case "amplitude":
+			p.Amplitude, err = strconv.ParseFloat(value, 64)
+		case "period":
+			p.Period, err = time.ParseDuration(value)
+		default:
+			return nil, fmt.Errorf("%w: unknown profile field %q", core.ErrInvalidConfig, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: profile field %s: %v", core.ErrInvalidConfig, name, err)
+		}
+	}
+
+	if p.Shape == "" {
+		return nil, fmt.Errorf("%w: profile %q has no shape", core.ErrInvalidConfig, spec)
+	}
+	return p, nil
+}
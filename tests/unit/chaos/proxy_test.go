@@ -0,0 +1,295 @@
+package chaos_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/chaos"
+	"middleware-chaos-testing/internal/core"
+)
+
+// echoServer 本地回显服务
+type echoServer struct {
+	ln     net.Listener
+	mu     sync.Mutex
+	closed int // 已被对端关闭的连接数
+	wg     sync.WaitGroup
+}
+
+func newEchoServer() (*echoServer, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &echoServer{ln: ln}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+				s.mu.Lock()
+				s.closed++
+				s.mu.Unlock()
+			}()
+		}
+	}()
+	return s, nil
+}
+
+func (s *echoServer) Addr() string { return s.ln.Addr().String() }
+
+func (s *echoServer) Closed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// ProxyTestSuite 故障注入代理测试套件
+type ProxyTestSuite struct {
+	suite.Suite
+	echo  *echoServer
+	proxy *chaos.Proxy
+}
+
+func (suite *ProxyTestSuite) SetupTest() {
+	var err error
+	suite.echo, err = newEchoServer()
+	suite.Require().NoError(err)
+
+	suite.proxy = chaos.NewProxy(suite.echo.Addr())
+	suite.Require().NoError(suite.proxy.Start("127.0.0.1:0"))
+}
+
+func (suite *ProxyTestSuite) TearDownTest() {
+	suite.NoError(suite.proxy.Close())
+	suite.echo.ln.Close()
+}
+
+func (suite *ProxyTestSuite) dial() net.Conn {
+	conn, err := net.Dial("tcp", suite.proxy.Addr())
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// roundTrip 发送数据并读取回显，返回耗时
+func (suite *ProxyTestSuite) roundTrip(conn net.Conn, payload []byte) time.Duration {
+	start := time.Now()
+	_, err := conn.Write(payload)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	got := make([]byte, len(payload))
+	_, err = io.ReadFull(conn, got)
+	suite.Require().NoError(err)
+	suite.Equal(payload, got)
+	return time.Since(start)
+}
+
+// TestPassthrough 测试无故障时透明转发
+func (suite *ProxyTestSuite) TestPassthrough() {
+	conn := suite.dial()
+	elapsed := suite.roundTrip(conn, []byte("PING"))
+	suite.Less(elapsed, 100*time.Millisecond)
+
+	stats := suite.proxy.Stats()
+	suite.Equal(1, stats.ActiveConnections)
+	suite.EqualValues(1, stats.TotalConnections)
+	suite.EqualValues(4, stats.BytesUpstream)
+	suite.EqualValues(4, stats.BytesDownstream)
+}
+
+// TestLatency 测试附加延迟（双向）
+func (suite *ProxyTestSuite) TestLatency() {
+	conn := suite.dial()
+	suite.roundTrip(conn, []byte("warmup"))
+
+	suite.proxy.SetFaults(chaos.Faults{Latency: 100 * time.Millisecond, Jitter: 20 * time.Millisecond})
+	elapsed := suite.roundTrip(conn, []byte("PING"))
+	suite.GreaterOrEqual(elapsed, 160*time.Millisecond)
+
+	suite.proxy.Heal()
+	elapsed = suite.roundTrip(conn, []byte("PING"))
+	suite.Less(elapsed, 100*time.Millisecond)
+}
+
+// TestStall 测试停顿
+func (suite *ProxyTestSuite) TestStall() {
+	conn := suite.dial()
+	suite.proxy.SetFaults(chaos.Faults{StallProbability: 1, StallDuration: 150 * time.Millisecond})
+
+	elapsed := suite.roundTrip(conn, []byte("PING"))
+	suite.GreaterOrEqual(elapsed, 300*time.Millisecond)
+}
+
+// TestBandwidth 测试带宽上限
+func (suite *ProxyTestSuite) TestBandwidth() {
+	conn := suite.dial()
+	suite.proxy.SetFaults(chaos.Faults{Bandwidth: 20000})
+
+	// 8000字节在20KB/s下单向约400ms
+	elapsed := suite.roundTrip(conn, bytes.Repeat([]byte("x"), 8000))
+	suite.GreaterOrEqual(elapsed, 350*time.Millisecond)
+}
+
+// TestResetConnections 测试重置现有连接
+func (suite *ProxyTestSuite) TestResetConnections() {
+	conn := suite.dial()
+	suite.roundTrip(conn, []byte("PING"))
+
+	suite.Equal(1, suite.proxy.ResetConnections())
+
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+	_, err := conn.Read(make([]byte, 1))
+	suite.Error(err)
+	suite.False(isTimeout(err), "Reset connection should fail immediately, got %v", err)
+	suite.EqualValues(1, suite.proxy.Stats().ResetConnections)
+}
+
+// TestResetFault 测试重置故障作用于新连接
+func (suite *ProxyTestSuite) TestResetFault() {
+	suite.proxy.SetFaults(chaos.Faults{Reset: true})
+
+	// 连接可能在拨号完成前就被重置
+	conn, err := net.Dial("tcp", suite.proxy.Addr())
+	if err == nil {
+		defer conn.Close()
+		suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+		_, err = conn.Read(make([]byte, 1))
+	}
+	suite.Error(err)
+	suite.False(isTimeout(err))
+
+	suite.proxy.Heal()
+	suite.roundTrip(suite.dial(), []byte("PING"))
+}
+
+// TestHalfOpen 测试半开连接：上游断开，客户端无响应也不报错
+func (suite *ProxyTestSuite) TestHalfOpen() {
+	conn := suite.dial()
+	suite.roundTrip(conn, []byte("PING"))
+
+	suite.proxy.SetFaults(chaos.Faults{HalfOpen: true})
+	suite.Eventually(func() bool { return suite.echo.Closed() == 1 }, time.Second, 10*time.Millisecond,
+		"Upstream connection should be closed")
+
+	_, err := conn.Write([]byte("PING"))
+	suite.NoError(err)
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond)))
+	_, err = conn.Read(make([]byte, 4))
+	suite.True(isTimeout(err), "Half-open connection should hang, got %v", err)
+
+	// 恢复后新连接正常
+	suite.proxy.Heal()
+	suite.roundTrip(suite.dial(), []byte("PING"))
+}
+
+// TestBlackhole 测试黑洞：数据挂起，恢复后继续投递
+func (suite *ProxyTestSuite) TestBlackhole() {
+	conn := suite.dial()
+	suite.roundTrip(conn, []byte("warmup"))
+
+	suite.proxy.SetFaults(chaos.Faults{Blackhole: true})
+	_, err := conn.Write([]byte("PING"))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond)))
+	got := make([]byte, 4)
+	_, err = conn.Read(got)
+	suite.True(isTimeout(err), "Blackholed connection should hang, got %v", err)
+
+	// 新连接同样挂起
+	other := suite.dial()
+	_, err = other.Write([]byte("PONG"))
+	suite.Require().NoError(err)
+	suite.Require().NoError(other.SetReadDeadline(time.Now().Add(100 * time.Millisecond)))
+	_, err = other.Read(make([]byte, 4))
+	suite.True(isTimeout(err))
+
+	suite.proxy.Heal()
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+	_, err = io.ReadFull(conn, got)
+	suite.Require().NoError(err)
+	suite.Equal("PING", string(got))
+}
+
+// TestDialContext 测试拨号函数接入
+func (suite *ProxyTestSuite) TestDialContext() {
+	conn, err := suite.proxy.DialContext(context.Background(), "tcp", suite.echo.Addr())
+	suite.Require().NoError(err)
+	defer conn.Close()
+
+	suite.proxy.SetFaults(chaos.Faults{Latency: 50 * time.Millisecond})
+	elapsed := suite.roundTrip(conn, []byte("PING"))
+	suite.GreaterOrEqual(elapsed, 100*time.Millisecond)
+}
+
+// TestClose 测试关闭代理
+func (suite *ProxyTestSuite) TestClose() {
+	conn := suite.dial()
+	suite.roundTrip(conn, []byte("PING"))
+
+	suite.NoError(suite.proxy.Close())
+	suite.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+	_, err := conn.Read(make([]byte, 1))
+	suite.Error(err)
+
+	suite.ErrorIs(suite.proxy.Start("127.0.0.1:0"), chaos.ErrProxyClosed)
+	_, err = suite.proxy.DialContext(context.Background(), "tcp", suite.echo.Addr())
+	suite.ErrorIs(err, chaos.ErrProxyClosed)
+}
+
+// TestParseFaults 测试故障描述解析
+func (suite *ProxyTestSuite) TestParseFaults() {
+	f, err := chaos.ParseFaults("latency=100ms,jitter=20ms,stall=0.05:500ms,bandwidth=64KB,reset,half_open,blackhole")
+	suite.Require().NoError(err)
+	suite.Equal(chaos.Faults{
+		Latency:          100 * time.Millisecond,
+		Jitter:           20 * time.Millisecond,
+		StallProbability: 0.05,
+		StallDuration:    500 * time.Millisecond,
+		Bandwidth:        64 * 1024,
+		Reset:            true,
+		HalfOpen:         true,
+		Blackhole:        true,
+	}, f)
+
+	roundTrip, err := chaos.ParseFaults(f.String())
+	suite.Require().NoError(err)
+	suite.Equal(f, roundTrip)
+
+	none, err := chaos.ParseFaults("none")
+	suite.NoError(err)
+	suite.True(none.IsZero())
+	suite.Equal("none", none.String())
+
+	for _, spec := range []string{"latency=fast", "stall=0.5", "stall=2:1s", "bandwidth=-1", "flood"} {
+		_, err := chaos.ParseFaults(spec)
+		suite.True(errors.Is(err, core.ErrInvalidConfig), "%s: got %v", spec, err)
+	}
+}
+
+// TestProxyTestSuite 运行测试套件
+func TestProxyTestSuite(t *testing.T) {
+	suite.Run(t, new(ProxyTestSuite))
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
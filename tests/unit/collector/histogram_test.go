@@ -0,0 +1,160 @@
+package collector_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/collector"
+)
+
+// HistogramTestSuite 延迟直方图测试套件
+type HistogramTestSuite struct {
+	suite.Suite
+}
+
+// TestEmpty 测试空直方图
+func (suite *HistogramTestSuite) TestEmpty() {
+	s := collector.NewHistogram(collector.DefaultLatencyPrecision).Snapshot()
+	suite.Zero(s.Count())
+	suite.Zero(s.Percentile(99))
+	suite.Zero(s.Min())
+	suite.Zero(s.Max())
+	suite.Zero(s.Mean())
+	suite.Zero(s.StdDev())
+}
+
+// TestSmallValuesExact 测试小值精确记录
+func (suite *HistogramTestSuite) TestSmallValuesExact() {
+	h := collector.NewHistogram(2)
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i))
+	}
+
+	s := h.Snapshot()
+	suite.Equal(int64(100), s.Count())
+	suite.Equal(time.Duration(51), s.Percentile(50))
+	suite.Equal(time.Duration(96), s.Percentile(95))
+	suite.Equal(time.Duration(100), s.Percentile(99))
+	suite.Equal(time.Duration(1), s.Min())
+	suite.Equal(time.Duration(100), s.Max())
+	suite.Equal(time.Duration(50), s.Mean())
+}
+
+// TestRelativeError 测试各精度下百分位的相对误差
+func (suite *HistogramTestSuite) TestRelativeError() {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]time.Duration, 20000)
+	for i := range values {
+		// 1µs到10s之间的对数均匀分布
+		values[i] = time.Duration(math.Exp(rng.Float64()*math.Log(1e10/1e3)) * 1e3)
+	}
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for precision, bound := range map[int]float64{1: 0.07, 2: 0.008, 3: 0.001} {
+		h := collector.NewHistogram(precision)
+		for _, v := range values {
+			h.Record(v)
+		}
+		s := h.Snapshot()
+		for _, q := range []float64{50, 90, 99, 99.9, 99.99} {
+			exact := float64(sorted[int(float64(len(sorted))*q/100)])
+			suite.InEpsilon(exact, float64(s.Percentile(q)), bound, "precision %d, P%g", precision, q)
+		}
+		suite.Equal(sorted[0], s.Min())
+		suite.Equal(sorted[len(sorted)-1], s.Max())
+	}
+}
+
+// TestTailPercentiles 测试P99.9和P99.99
+func (suite *HistogramTestSuite) TestTailPercentiles() {
+	h := collector.NewHistogram(3)
+	for i := 0; i < 9989; i++ {
+		h.Record(time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		h.Record(100 * time.Millisecond)
+	}
+	h.Record(time.Second)
+
+	s := h.Snapshot()
+	suite.InEpsilon(float64(time.Millisecond), float64(s.Percentile(99)), 0.001)
+	suite.InEpsilon(float64(100*time.Millisecond), float64(s.Percentile(99.9)), 0.001)
+	suite.Equal(time.Second, s.Percentile(99.99))
+}
+
+// TestStdDev 测试标准差
+func (suite *HistogramTestSuite) TestStdDev() {
+	h := collector.NewHistogram(3)
+	for i := 0; i < 500; i++ {
+		h.Record(10 * time.Millisecond)
+		h.Record(30 * time.Millisecond)
+	}
+
+	s := h.Snapshot()
+	suite.Equal(20*time.Millisecond, s.Mean())
+	suite.InEpsilon(float64(10*time.Millisecond), float64(s.StdDev()), 0.001)
+}
+
+// TestLargeValues 测试超出可区分范围的值
+func (suite *HistogramTestSuite) TestLargeValues() {
+	h := collector.NewHistogram(2)
+	h.Record(-time.Second)
+	h.Record(100 * time.Hour)
+
+	s := h.Snapshot()
+	suite.Equal(int64(2), s.Count())
+	suite.Zero(s.Min(), "Negative values should be recorded as zero")
+	suite.Equal(100*time.Hour, s.Max())
+	suite.Equal(100*time.Hour, s.Percentile(100))
+}
+
+// TestMerge 测试合并快照
+func (suite *HistogramTestSuite) TestMerge() {
+	a, b := collector.NewHistogram(2), collector.NewHistogram(2)
+	for i := 1; i <= 50; i++ {
+		a.Record(time.Duration(i))
+		b.Record(time.Duration(i + 50))
+	}
+
+	merged := a.Snapshot()
+	suite.Require().NoError(merged.Merge(b.Snapshot()))
+	suite.Equal(int64(100), merged.Count())
+	suite.Equal(time.Duration(51), merged.Percentile(50))
+	suite.Equal(time.Duration(1), merged.Min())
+	suite.Equal(time.Duration(100), merged.Max())
+	suite.Equal(int64(50), a.Snapshot().Count(), "Merging must not modify the source histogram")
+
+	suite.Error(merged.Merge(collector.NewHistogram(3).Snapshot()))
+}
+
+// TestConcurrentRecord 测试并发记录
+func (suite *HistogramTestSuite) TestConcurrentRecord() {
+	h := collector.NewHistogram(2)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 1; i <= 1000; i++ {
+				h.Record(time.Duration(w*1000+i) * time.Microsecond)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	s := h.Snapshot()
+	suite.Equal(int64(8000), s.Count())
+	suite.Equal(time.Microsecond, s.Min())
+	suite.Equal(8*time.Millisecond, s.Max())
+}
+
+// TestHistogramTestSuite 运行测试套件
+func TestHistogramTestSuite(t *testing.T) {
+	suite.Run(t, new(HistogramTestSuite))
+}
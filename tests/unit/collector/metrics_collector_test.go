@@ -0,0 +1,456 @@
+package collector_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// MetricsCollectorTestSuite 指标收集器测试套件
+type MetricsCollectorTestSuite struct {
+	suite.Suite
+	collector *collector.MetricsCollector
+	base      time.Time
+}
+
+// SetupTest 每个测试前执行
+func (suite *MetricsCollectorTestSuite) SetupTest() {
+	suite.collector = collector.NewMetricsCollector()
+	suite.collector.SetErrorClassifier(middleware.ClassifyError)
+	suite.base = time.Now().Add(-time.Minute)
+}
+
+// record 记录一次在 base+offset 完成的操作
+func (suite *MetricsCollectorTestSuite) record(offset time.Duration, success bool, duration time.Duration) {
+	var err error
+	if !success {
+		err = errors.New("boom")
+	}
+	result := core.NewResult(success, duration, err)
+	result.Timestamp = suite.base.Add(offset)
+	suite.collector.RecordOperation(result)
+}
+
+// recordSeries 从 start 开始每隔 step 记录一次操作
+func (suite *MetricsCollectorTestSuite) recordSeries(start, step time.Duration, outcomes ...bool) time.Duration {
+	at := start
+	for _, success := range outcomes {
+		suite.record(at, success, 0)
+		at += step
+	}
+	return at
+}
+
+func repeat(success bool, n int) []bool {
+	outcomes := make([]bool, n)
+	for i := range outcomes {
+		outcomes[i] = success
+	}
+	return outcomes
+}
+
+// TestNoOutage 测试无故障
+func (suite *MetricsCollectorTestSuite) TestNoOutage() {
+	suite.recordSeries(0, 100*time.Millisecond, repeat(true, 50)...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Zero(metrics.OutageCount)
+	suite.Zero(metrics.MTTR)
+	suite.Zero(metrics.MTBF)
+	suite.Empty(metrics.Outages)
+	suite.False(metrics.HasUnrecoveredOutage())
+}
+
+// TestConsecutiveFailures 测试连续失败规则
+func (suite *MetricsCollectorTestSuite) TestConsecutiveFailures() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 5})
+
+	// 0-9s成功，10-15s失败，16s起恢复
+	at := suite.recordSeries(0, time.Second, repeat(true, 10)...)
+	at = suite.recordSeries(at, time.Second, repeat(false, 6)...)
+	suite.recordSeries(at, time.Second, repeat(true, 10)...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Require().Len(metrics.Outages, 1)
+
+	outage := metrics.Outages[0]
+	suite.Equal(suite.base.Add(10*time.Second), outage.Start)
+	suite.Equal(suite.base.Add(16*time.Second), outage.End)
+	suite.Equal(6*time.Second, outage.Duration)
+	suite.Equal(int64(6), outage.Failures)
+	suite.True(outage.Recovered)
+
+	suite.Equal(int64(1), metrics.OutageCount)
+	suite.Equal(6*time.Second, metrics.MTTR)
+	suite.Equal(6*time.Second, metrics.LongestOutage)
+	suite.Equal(6*time.Second, metrics.TotalDowntime)
+}
+
+// TestShortFailureBurstIgnored 测试未达到阈值的失败不计为故障
+func (suite *MetricsCollectorTestSuite) TestShortFailureBurstIgnored() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 5})
+
+	outcomes := append(repeat(true, 10), repeat(false, 4)...)
+	outcomes = append(outcomes, repeat(true, 10)...)
+	suite.recordSeries(0, time.Second, outcomes...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Zero(metrics.OutageCount)
+	suite.Equal(int64(4), metrics.FailedOperations)
+}
+
+// TestMultipleOutages 测试多次故障的MTTR/MTBF
+func (suite *MetricsCollectorTestSuite) TestMultipleOutages() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 3})
+
+	at := suite.recordSeries(0, time.Second, repeat(true, 5)...)
+	at = suite.recordSeries(at, time.Second, repeat(false, 3)...) // 3s
+	at = suite.recordSeries(at, time.Second, repeat(true, 5)...)
+	at = suite.recordSeries(at, time.Second, repeat(false, 5)...) // 5s
+	suite.recordSeries(at, time.Second, repeat(true, 5)...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(int64(2), metrics.OutageCount)
+	suite.Equal(4*time.Second, metrics.MTTR)
+	suite.Equal(5*time.Second, metrics.LongestOutage)
+	suite.Equal(8*time.Second, metrics.TotalDowntime)
+}
+
+// TestMTBF 测试MTBF为正常运行时间除以故障次数
+func (suite *MetricsCollectorTestSuite) TestMTBF() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 1})
+
+	suite.collector.RecordOperation(core.NewResult(false, 0, errors.New("boom")))
+	time.Sleep(20 * time.Millisecond)
+	suite.collector.RecordOperation(core.NewResult(true, 0, nil))
+	time.Sleep(20 * time.Millisecond)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Require().Equal(int64(1), metrics.OutageCount)
+	suite.GreaterOrEqual(metrics.TotalDowntime, 20*time.Millisecond)
+	suite.Equal(metrics.Duration-metrics.TotalDowntime, metrics.MTBF)
+	suite.Equal(metrics.TotalDowntime, metrics.MTTR)
+}
+
+// TestErrorRateWindow 测试滑动窗口错误率规则
+func (suite *MetricsCollectorTestSuite) TestErrorRateWindow() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{
+		ErrorRate:    0.5,
+		Window:       2 * time.Second,
+		MinWindowOps: 4,
+	})
+
+	// 每100ms一次操作：2s正常，2s内每3次操作失败2次（不会触发连续失败规则），之后恢复
+	at := suite.recordSeries(0, 100*time.Millisecond, repeat(true, 20)...)
+	var flapping []bool
+	for i := 0; i < 20; i++ {
+		flapping = append(flapping, i%3 == 2)
+	}
+	at = suite.recordSeries(at, 100*time.Millisecond, flapping...)
+	recoveredAt := at
+	suite.recordSeries(at, 100*time.Millisecond, repeat(true, 40)...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Require().Equal(int64(1), metrics.OutageCount, "%+v", metrics.Outages)
+
+	outage := metrics.Outages[0]
+	suite.True(outage.Recovered)
+	suite.True(outage.Start.After(suite.base.Add(2*time.Second)), "outage start %v", outage.Start.Sub(suite.base))
+	// 恢复时间为最后一段连续成功的开始，而不是窗口错误率回落的时间
+	suite.Equal(suite.base.Add(recoveredAt), outage.End)
+}
+
+// TestUnrecoveredOutage 测试测试结束时仍未恢复
+func (suite *MetricsCollectorTestSuite) TestUnrecoveredOutage() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 2})
+
+	at := suite.recordSeries(0, time.Second, repeat(true, 5)...)
+	suite.recordSeries(at, time.Second, repeat(false, 5)...)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(int64(1), metrics.OutageCount)
+	suite.True(metrics.HasUnrecoveredOutage())
+	suite.Zero(metrics.MTTR, "Unrecovered outages must not count towards MTTR")
+	suite.Greater(metrics.TotalDowntime, 50*time.Second)
+}
+
+// TestOutageStartUsesOperationStart 测试故障开始时间取失败操作的开始时间
+func (suite *MetricsCollectorTestSuite) TestOutageStartUsesOperationStart() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 1})
+
+	suite.record(0, true, 0)
+	suite.record(10*time.Second, false, 5*time.Second) // 5s超时
+	suite.record(11*time.Second, true, 0)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Require().Len(metrics.Outages, 1)
+	suite.Equal(suite.base.Add(5*time.Second), metrics.Outages[0].Start)
+	suite.Equal(6*time.Second, metrics.MTTR)
+}
+
+// TestDefaultDetection 测试默认规则
+func (suite *MetricsCollectorTestSuite) TestDefaultDetection() {
+	rule := collector.DefaultOutageDetection()
+	suite.Equal(5, rule.ConsecutiveFailures)
+
+	at := suite.recordSeries(0, 100*time.Millisecond, repeat(true, 10)...)
+	at = suite.recordSeries(at, 100*time.Millisecond, repeat(false, rule.ConsecutiveFailures)...)
+	suite.recordSeries(at, 100*time.Millisecond, repeat(true, 100)...)
+
+	suite.Equal(int64(1), suite.collector.GetMetrics().OutageCount)
+}
+
+// TestReconnectAttempts 测试重连成功率
+func (suite *MetricsCollectorTestSuite) TestReconnectAttempts() {
+	suite.Equal(1.0, suite.collector.GetMetrics().ReconnectSuccessRate, "No reconnects should count as fully successful")
+
+	suite.collector.RecordReconnectAttempt(false, 10*time.Millisecond)
+	suite.collector.RecordReconnectAttempt(false, 10*time.Millisecond)
+	suite.collector.RecordReconnectAttempt(false, 10*time.Millisecond)
+	suite.collector.RecordReconnectAttempt(true, 10*time.Millisecond)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(int64(4), metrics.TotalReconnectAttempts)
+	suite.Equal(int64(1), metrics.SuccessfulReconnects)
+	suite.Equal(0.25, metrics.ReconnectSuccessRate)
+}
+
+// TestErrorClassification 测试失败操作按注入的分类器归类
+func (suite *MetricsCollectorTestSuite) TestErrorClassification() {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	suite.collector.RecordOperation(core.NewResult(false, 0, refused))
+	suite.collector.RecordOperation(core.NewResult(false, 0, refused))
+	suite.collector.RecordOperation(core.NewResult(false, 0, context.DeadlineExceeded))
+	suite.collector.RecordOperation(core.NewResult(false, 0, errors.New("boom")))
+	suite.collector.RecordOperation(core.NewResult(false, 0, nil))
+	suite.collector.RecordOperation(core.NewResult(true, 0, nil))
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(map[core.ErrorType]int64{
+		core.ErrorTypeNetwork: 2,
+		core.ErrorTypeTimeout: 1,
+		core.ErrorTypeOther:   2,
+	}, metrics.ErrorsByType)
+}
+
+// TestNoErrorClassifier 测试未设置分类器时失败操作均计为其他错误
+func (suite *MetricsCollectorTestSuite) TestNoErrorClassifier() {
+	mc := collector.NewMetricsCollector()
+	mc.RecordOperation(core.NewResult(false, 0, context.DeadlineExceeded))
+	mc.RecordOperation(core.NewResult(false, 0, errors.New("boom")))
+
+	suite.Equal(map[core.ErrorType]int64{core.ErrorTypeOther: 2}, mc.GetMetrics().ErrorsByType)
+}
+
+// TestCustomErrorClassifier 测试自定义错误分类器
+func (suite *MetricsCollectorTestSuite) TestCustomErrorClassifier() {
+	suite.collector.SetErrorClassifier(func(err error) core.ErrorType {
+		return core.ErrorTypeAuthentication
+	})
+	suite.collector.RecordOperation(core.NewResult(false, 0, errors.New("denied")))
+
+	suite.Equal(int64(1), suite.collector.GetMetrics().ErrorsByType[core.ErrorTypeAuthentication])
+}
+
+// TestReset 测试重置清除故障记录
+func (suite *MetricsCollectorTestSuite) TestReset() {
+	suite.collector.SetOutageDetection(collector.OutageDetection{ConsecutiveFailures: 1})
+	suite.recordSeries(0, time.Second, false, true)
+	suite.Equal(int64(1), suite.collector.GetMetrics().OutageCount)
+
+	suite.collector.Reset()
+	metrics := suite.collector.GetMetrics()
+	suite.Zero(metrics.OutageCount)
+	suite.Zero(metrics.TotalOperations)
+}
+
+// TestLatencyMetrics 测试延迟指标来自直方图，且并发记录计数准确
+func (suite *MetricsCollectorTestSuite) TestLatencyMetrics() {
+	suite.collector.SetLatencyPrecision(3)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 1; i <= 250; i++ {
+				suite.collector.RecordOperation(core.NewResult(i%50 != 0, time.Duration(w*250+i)*time.Millisecond, nil))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(int64(1000), metrics.TotalOperations)
+	suite.Equal(int64(980), metrics.SuccessfulOperations)
+	suite.Equal(int64(20), metrics.FailedOperations)
+	suite.InEpsilon(float64(501*time.Millisecond), float64(metrics.P50Latency), 0.001)
+	suite.InEpsilon(float64(991*time.Millisecond), float64(metrics.P99Latency), 0.001)
+	suite.InEpsilon(float64(time.Second), float64(metrics.P999Latency), 0.001)
+	suite.Equal(time.Second, metrics.P9999Latency)
+	suite.Equal(time.Millisecond, metrics.MinLatency)
+	suite.Equal(time.Second, metrics.MaxLatency)
+	suite.InEpsilon(float64(500500*time.Microsecond), float64(metrics.AvgLatency), 1e-6)
+	suite.InEpsilon(float64(288675*time.Microsecond), float64(metrics.StdDevLatency), 0.01)
+	suite.Equal(int64(1000), suite.collector.LatencySnapshot().Count())
+
+	suite.collector.Reset()
+	suite.Zero(suite.collector.GetMetrics().P99Latency)
+}
+
+// TestOperationBreakdown 测试按操作分类的指标
+func (suite *MetricsCollectorTestSuite) TestOperationBreakdown() {
+	record := func(name string, opType core.OperationType, success bool, latency time.Duration) {
+		var err error
+		if !success {
+			err = errors.New("READONLY You can't write against a read only replica")
+		}
+		result := core.NewResult(success, latency, err)
+		result.Operation = name
+		result.OpType = opType
+		suite.collector.RecordOperation(result)
+	}
+	for i := 1; i <= 100; i++ {
+		record("get", core.OpTypeRead, true, time.Millisecond)
+		record("set", core.OpTypeWrite, i%10 != 0, time.Duration(i)*time.Millisecond)
+	}
+	suite.collector.RecordOperation(core.NewResult(true, time.Millisecond, nil))
+	typeOnly := core.NewResult(true, time.Millisecond, nil)
+	typeOnly.OpType = core.OpTypeCustom
+	suite.collector.RecordOperation(typeOnly)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(int64(202), metrics.TotalOperations)
+	suite.Require().Len(metrics.Operations, 3, "Results without operation should only count in totals")
+
+	get := metrics.Operations["get"]
+	suite.Equal(core.OpTypeRead, get.Type)
+	suite.Equal(int64(100), get.Operations)
+	suite.Equal(1.0, get.Availability)
+	suite.Equal(time.Millisecond, get.P99Latency)
+	suite.Nil(get.ErrorsByType)
+
+	set := metrics.Operations["set"]
+	suite.Equal("set", set.Name)
+	suite.Equal(int64(90), set.Successes)
+	suite.Equal(int64(10), set.Failures)
+	suite.InDelta(0.1, set.ErrorRate, 1e-9)
+	suite.Equal(map[core.ErrorType]int64{core.ErrorTypeOther: 10}, set.ErrorsByType)
+	suite.InEpsilon(float64(96*time.Millisecond), float64(set.P95Latency), 0.01)
+	suite.Equal(100*time.Millisecond, set.MaxLatency)
+	suite.Greater(set.Throughput, 0.0)
+
+	suite.Equal(int64(1), metrics.Operations["custom"].Operations)
+
+	clone := metrics.Clone()
+	clone.Operations["set"].ErrorsByType[core.ErrorTypeOther] = 0
+	suite.Equal(int64(10), metrics.Operations["set"].ErrorsByType[core.ErrorTypeOther])
+
+	suite.collector.Reset()
+	suite.Empty(suite.collector.GetMetrics().Operations)
+}
+
+// TestSeries 测试按区间划分的时间序列
+func (suite *MetricsCollectorTestSuite) TestSeries() {
+	suite.collector.SetSeriesInterval(time.Second)
+	start := suite.collector.GetMetrics().StartTime
+	record := func(at time.Duration, success bool, latency time.Duration) {
+		var err error
+		if !success {
+			err = context.DeadlineExceeded
+		}
+		result := core.NewResult(success, latency, err)
+		result.Timestamp = start.Add(at)
+		suite.collector.RecordOperation(result)
+	}
+
+	record(500*time.Millisecond, true, 10*time.Millisecond)
+	record(600*time.Millisecond, false, 20*time.Millisecond)
+	record(1200*time.Millisecond, true, 30*time.Millisecond)
+	record(2500*time.Millisecond, true, 40*time.Millisecond)
+	record(900*time.Millisecond, true, 50*time.Millisecond) // 晚到的结果计入最早的打开区间
+	record(4500*time.Millisecond, true, 60*time.Millisecond)
+
+	metrics := suite.collector.GetMetrics()
+	suite.Equal(time.Second, metrics.SeriesInterval)
+	series := metrics.Series
+	suite.Require().Len(series, 5)
+	for i, im := range series {
+		suite.Equal(start.Add(time.Duration(i)*time.Second), im.Start)
+		suite.Equal(time.Second, im.Duration)
+	}
+
+	suite.Equal(int64(2), series[0].Operations)
+	suite.Equal(int64(1), series[0].Failures)
+	suite.Equal(map[core.ErrorType]int64{core.ErrorTypeTimeout: 1}, series[0].ErrorsByType)
+	suite.Equal(0.5, series[0].Availability())
+	suite.Equal(20*time.Millisecond, series[0].MaxLatency)
+
+	suite.Equal(int64(2), series[1].Operations)
+	suite.Equal(int64(2), series[1].Successes)
+	suite.Nil(series[1].ErrorsByType)
+	suite.InEpsilon(float64(50*time.Millisecond), float64(series[1].P99Latency), 0.01)
+
+	suite.Equal(int64(1), series[2].Operations)
+	suite.Zero(series[3].Operations, "Intervals without operations should be listed")
+	suite.Zero(series[3].Availability())
+	suite.Equal(int64(1), series[4].Operations)
+	suite.Equal(1.0, series[4].Throughput())
+
+	clone := metrics.Clone()
+	clone.Series[0].ErrorsByType[core.ErrorTypeTimeout] = 10
+	suite.Equal(int64(1), metrics.Series[0].ErrorsByType[core.ErrorTypeTimeout])
+}
+
+// TestSeriesCoversIdleTail 测试时间序列覆盖到测试结束
+func (suite *MetricsCollectorTestSuite) TestSeriesCoversIdleTail() {
+	suite.collector.SetSeriesInterval(10 * time.Millisecond)
+	suite.collector.RecordOperation(core.NewResult(true, time.Millisecond, nil))
+	time.Sleep(50 * time.Millisecond)
+
+	metrics := suite.collector.GetMetrics()
+	suite.GreaterOrEqual(len(metrics.Series), 5)
+	var ops int64
+	for _, im := range metrics.Series {
+		ops += im.Operations
+	}
+	suite.Equal(int64(1), ops)
+}
+
+// TestMetricsCollectorTestSuite 运行测试套件
+func TestMetricsCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsCollectorTestSuite))
+}
+
+// BenchmarkRecordOperationParallel 并发worker记录操作（含10%失败），可配合 -race 观察锁争用
+func BenchmarkRecordOperationParallel(b *testing.B) {
+	mc := collector.NewMetricsCollector()
+	mc.SetErrorClassifier(middleware.ClassifyError)
+	failure := errors.New("boom")
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			i++
+			var err error
+			if i%10 == 0 {
+				err = failure
+			}
+			result := core.NewResult(err == nil, time.Duration(i%1000)*time.Microsecond, err)
+			result.Operation = "set"
+			result.OpType = core.OpTypeWrite
+			mc.RecordOperation(result)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package compare_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/compare"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// CompareTestSuite 基线对比测试套件
+type CompareTestSuite struct {
+	suite.Suite
+}
+
+// run 生成60个区间的测试指标，每个区间1000次操作；latency 为P50/P95/P99的倍数，
+// 各区间在 ±jitter 范围内交替波动
+func run(latency, jitter float64, failures int64) *core.StabilityMetrics {
+	start := time.Date(2025, 10, 30, 14, 0, 0, 0, time.UTC)
+	scale := func(d time.Duration, f float64) time.Duration { return time.Duration(float64(d) * f) }
+	m := &core.StabilityMetrics{
+		TotalOperations:      60000,
+		SuccessfulOperations: 60000 - failures,
+		FailedOperations:     failures,
+		Availability:         float64(60000-failures) / 60000,
+		ErrorRate:            float64(failures) / 60000,
+		P50Latency:           scale(800*time.Microsecond, latency),
+		P95Latency:           scale(2*time.Millisecond, latency),
+		P99Latency:           scale(5*time.Millisecond, latency),
+		P999Latency:          12 * time.Millisecond,
+		Throughput:           1000,
+		StartTime:            start,
+		Duration:             time.Minute,
+		SeriesInterval:       time.Second,
+	}
+	for i := 0; i < 60; i++ {
+		f := latency * (1 + jitter*float64(i%7-3)/3)
+		m.Series = append(m.Series, core.IntervalMetrics{
+			Start:      start.Add(time.Duration(i) * time.Second),
+			Duration:   time.Second,
+			Operations: 1000,
+			P50Latency: scale(800*time.Microsecond, f),
+			P95Latency: scale(2*time.Millisecond, f),
+			P99Latency: scale(5*time.Millisecond, f),
+		})
+	}
+	return m
+}
+
+// report 经JSON报告往返得到对比数据
+func (suite *CompareTestSuite) report(name string, m *core.StabilityMetrics) *compare.Report {
+	var buf bytes.Buffer
+	evaluation := &core.EvaluationResult{Score: 90, Grade: core.GradeExcellent, Status: core.StatusPass,
+		EvaluatedAt: m.StartTime.Add(m.Duration)}
+	suite.Require().NoError(reporter.NewJSONReporter().GenerateReport(m, evaluation, &buf))
+	r, err := compare.Parse(&buf, name)
+	suite.Require().NoError(err)
+	return r
+}
+
+// deltas 按指标名称索引
+func deltas(c *compare.Comparison) map[string]compare.Delta {
+	byMetric := make(map[string]compare.Delta)
+	for _, d := range c.Deltas {
+		byMetric[d.Metric] = d
+	}
+	return byMetric
+}
+
+// TestParse 测试从JSON报告读取指标
+func (suite *CompareTestSuite) TestParse() {
+	r := suite.report("base.json", run(1, 0.1, 6))
+	suite.Equal("base.json", r.Name)
+	suite.Equal(int64(60000), r.Operations)
+	suite.Equal(int64(6), r.Failures)
+	suite.Equal(800*time.Microsecond, r.Latency["p50"], "Latency should keep microsecond precision")
+	suite.Equal(12*time.Millisecond, r.Latency["p999"])
+	suite.Len(r.Intervals["p99"], 60)
+	suite.Equal(time.Minute, r.Duration)
+	suite.Equal("PASS", r.Status)
+
+	// 较早的报告只有毫秒精度
+	old := `{"evaluation": {"score": 80}, "metrics": {"availability": {"rate": 0.99},
+		"performance": {"p99_latency_ms": 7}}, "series": {"intervals": [{"operations": 1, "p99_latency_ms": 3}]}}`
+	r, err := compare.Parse(strings.NewReader(old), "old.json")
+	suite.Require().NoError(err)
+	suite.Equal(7*time.Millisecond, r.Latency["p99"])
+	suite.Equal([]time.Duration{3 * time.Millisecond}, r.Intervals["p99"])
+
+	for _, bad := range []string{"not json", `{"evaluation": {}}`} {
+		_, err := compare.Parse(strings.NewReader(bad), "bad.json")
+		suite.True(errors.Is(err, core.ErrInvalidMetrics), bad)
+	}
+}
+
+// TestNoRegression 测试容差内的变化
+func (suite *CompareTestSuite) TestNoRegression() {
+	c := compare.Compare(suite.report("a", run(1, 0.1, 6)), suite.report("b", run(1.05, 0.1, 9)),
+		compare.DefaultOptions())
+	suite.Empty(c.Regressions())
+	d := deltas(c)
+	suite.Equal(compare.VerdictUnchanged, d["p99_latency"].Verdict)
+	suite.False(d["p99_latency"].Tested(), "Changes within tolerance are not tested")
+	suite.InDelta(0.05, d["p99_latency"].Relative, 1e-9)
+	suite.Equal(compare.VerdictInfo, d["throughput"].Verdict)
+	suite.False(compare.HasRegression([]*compare.Comparison{c}))
+}
+
+// TestRegression 测试显著的延迟和可用性回归
+func (suite *CompareTestSuite) TestRegression() {
+	c := compare.Compare(suite.report("a", run(1, 0.1, 6)), suite.report("b", run(1.4, 0.1, 200)),
+		compare.DefaultOptions())
+	d := deltas(c)
+	for _, metric := range []string{"availability", "error_rate", "p50_latency", "p95_latency", "p99_latency"} {
+		suite.Equal(compare.VerdictRegression, d[metric].Verdict, metric)
+		suite.True(d[metric].Tested(), metric)
+		suite.Less(d[metric].PValue, 0.001, metric)
+	}
+	suite.Equal(compare.VerdictUnchanged, d["p999_latency"].Verdict)
+	suite.InDelta(-(200.0-6)/60000, d["availability"].Change, 1e-12)
+	suite.Len(c.Regressions(), 5)
+	suite.True(compare.HasRegression([]*compare.Comparison{c}))
+
+	// 反过来对比为改善
+	c = compare.Compare(suite.report("b", run(1.4, 0.1, 200)), suite.report("a", run(1, 0.1, 6)),
+		compare.DefaultOptions())
+	suite.Empty(c.Regressions())
+	suite.Equal(compare.VerdictImprovement, deltas(c)["p99_latency"].Verdict)
+	suite.Equal(compare.VerdictImprovement, deltas(c)["error_rate"].Verdict)
+}
+
+// TestNotSignificant 测试超过容差但不显著的变化
+func (suite *CompareTestSuite) TestNotSignificant() {
+	// 各区间波动±50%，整体P99增加15%，只有7个区间
+	base, cand := suite.report("a", run(1, 0.5, 6)), suite.report("b", run(1.15, 0.5, 6))
+	for _, r := range []*compare.Report{base, cand} {
+		for p := range r.Intervals {
+			r.Intervals[p] = r.Intervals[p][:7]
+		}
+	}
+	d := deltas(compare.Compare(base, cand, compare.DefaultOptions()))["p99_latency"]
+	suite.True(d.Tested())
+	suite.Greater(d.PValue, 0.05)
+	suite.Equal(compare.VerdictUnchanged, d.Verdict)
+
+	// 错误率增加0.2个百分点，超过容差；操作数很少时不显著
+	base, cand = suite.report("a", run(1, 0, 0)), suite.report("b", run(1, 0, 0))
+	base.Operations, base.Successes, base.Failures, base.ErrorRate = 500, 500, 0, 0
+	cand.Operations, cand.Successes, cand.Failures, cand.ErrorRate = 500, 499, 1, 0.002
+	base.Availability, cand.Availability = 1, 0.998
+	d = deltas(compare.Compare(base, cand, compare.DefaultOptions()))["error_rate"]
+	suite.True(d.Tested())
+	suite.Equal(compare.VerdictUnchanged, d.Verdict)
+}
+
+// TestTolerance 测试容差和最小延迟变化
+func (suite *CompareTestSuite) TestTolerance() {
+	base, cand := suite.report("a", run(1, 0.1, 6)), suite.report("b", run(1.4, 0.1, 200))
+
+	opts := compare.DefaultOptions()
+	opts.LatencyTolerance = 0.5
+	opts.ErrorRateTolerance = 0.01
+	opts.AvailabilityTolerance = 0.01
+	suite.Empty(compare.Compare(base, cand, opts).Regressions())
+
+	opts = compare.DefaultOptions()
+	opts.MinLatencyChange = time.Millisecond
+	d := deltas(compare.Compare(base, cand, opts))
+	suite.Equal(compare.VerdictUnchanged, d["p50_latency"].Verdict, "+320µs is below the minimum change")
+	suite.Equal(compare.VerdictRegression, d["p99_latency"].Verdict)
+
+	// 没有时间序列时只按容差判断
+	base.Intervals, cand.Intervals = nil, nil
+	d = deltas(compare.Compare(base, cand, compare.DefaultOptions()))
+	suite.False(d["p99_latency"].Tested())
+	suite.Equal(compare.VerdictRegression, d["p99_latency"].Verdict)
+}
+
+// TestRender 测试控制台和Markdown输出
+func (suite *CompareTestSuite) TestRender() {
+	comparisons := []*compare.Comparison{
+		compare.Compare(suite.report("runs/base.json", run(1, 0.1, 6)), suite.report("runs/ok.json", run(1, 0.1, 6)),
+			compare.DefaultOptions()),
+		compare.Compare(suite.report("runs/base.json", run(1, 0.1, 6)), suite.report("runs/bad.json", run(1.4, 0.1, 200)),
+			compare.DefaultOptions()),
+	}
+
+	var console strings.Builder
+	suite.Require().NoError(compare.WriteConsole(&console, comparisons))
+	out := console.String()
+	suite.Contains(out, "基线: base.json (90.0 EXCELLENT, PASS, 2025-10-30 14:01)")
+	suite.Contains(out, "对比: bad.json")
+	suite.Contains(out, "结论: 未发现回归")
+	suite.Contains(out, "结论: 发现 5 项回归: 可用性, 错误率, P50延迟, P95延迟, P99延迟")
+	suite.Regexp(`P99延迟\s+5ms\s+7ms\s+\+2ms \(\+40\.0%\)\s+<0\.001\s+✗ 回归`, out)
+	suite.Regexp(`可用性\s+99\.990%\s+99\.667%\s+-0\.323pp`, out)
+
+	var md strings.Builder
+	suite.Require().NoError(compare.WriteMarkdown(&md, comparisons))
+	suite.Contains(md.String(), "## base.json vs bad.json")
+	suite.Contains(md.String(), "| P99延迟 | 5ms | 7ms | +2ms (+40.0%) | <0.001 | ✗ 回归 |")
+}
+
+// TestLoad 测试读取报告文件
+func (suite *CompareTestSuite) TestLoad() {
+	path := filepath.Join(suite.T().TempDir(), "report.json")
+	f, err := os.Create(path)
+	suite.Require().NoError(err)
+	suite.Require().NoError(reporter.NewJSONReporter().GenerateReport(run(1, 0, 0),
+		&core.EvaluationResult{Status: core.StatusPass}, f))
+	suite.Require().NoError(f.Close())
+
+	r, err := compare.Load(path)
+	suite.Require().NoError(err)
+	suite.Equal(path, r.Name)
+
+	_, err = compare.Load(filepath.Join(suite.T().TempDir(), "missing.json"))
+	suite.Error(err)
+}
+
+// TestCompareTestSuite 运行测试套件
+func TestCompareTestSuite(t *testing.T) {
+	suite.Run(t, new(CompareTestSuite))
+}
@@ -0,0 +1,579 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/evaluator"
+	"middleware-chaos-testing/internal/i18n"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// ConfigTestSuite 配置加载测试套件
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+// TestImplementsInterface 测试实现core.Config接口
+func (suite *ConfigTestSuite) TestImplementsInterface() {
+	var _ core.Config = config.New()
+}
+
+// TestParseYAML 测试解析YAML配置
+func (suite *ConfigTestSuite) TestParseYAML() {
+	cfg, err := config.Parse([]byte(`
+name: "Redis Stability Test"
+middleware: "redis"
+connection:
+  host: "redis.local"
+  port: 6380
+  db: 2
+  timeout: 3s
+test:
+  duration: 30s
+  operations: 500
+  concurrency: 8
+  workload:
+    - operation: "set"
+      weight: 40
+      key_pattern: "test:key:{id}"
+      value_size: 1024
+    - operation: "get"
+      weight: 60
+      key_pattern: "test:key:{id}"
+thresholds:
+  availability:
+    excellent: 99.99%
+    pass: 95%
+  p95_latency:
+    good: 50ms
+  error_rate:
+    pass: 0.01
+output:
+  format: "json"
+  include_recommendations: false
+`))
+	suite.Require().NoError(err)
+	suite.Require().NoError(cfg.Validate())
+
+	suite.Equal("redis", cfg.GetMiddlewareType())
+
+	conn := cfg.GetConnectionConfig()
+	suite.Equal("redis.local", conn.Host)
+	suite.Equal(6380, conn.Port)
+	suite.Equal(2, conn.Database)
+	suite.Equal(3*time.Second, conn.Timeout)
+
+	tc := cfg.GetTestConfig()
+	suite.Equal(30*time.Second, tc.Duration)
+	suite.Equal(500, tc.Operations)
+	suite.Equal(8, tc.Concurrency)
+	suite.Require().Len(tc.Workload, 2)
+	suite.Equal(core.WorkloadConfig{Operation: "set", Weight: 40, KeyPattern: "test:key:{id}", ValueSize: 1024}, tc.Workload[0])
+
+	th := cfg.GetThresholds()
+	suite.InDelta(0.9999, th.AvailabilityExcellent, 1e-9)
+	suite.InDelta(0.95, th.AvailabilityPass, 1e-9)
+	suite.Equal(50*time.Millisecond, th.P95LatencyGood)
+	suite.InDelta(0.01, th.ErrorRatePass, 1e-9)
+
+	out := cfg.GetOutputConfig()
+	suite.Equal("json", out.Format)
+	suite.False(out.IncludeRecommendations)
+}
+
+// TestParseJSON 测试解析JSON配置
+func (suite *ConfigTestSuite) TestParseJSON() {
+	cfg, err := config.Parse([]byte(`{
+  "middleware": "kafka",
+  "connection": {"brokers": ["b1:9092", "b2:9092"], "topic": "orders"},
+  "test": {"operations": 100},
+  "thresholds": {"availability": {"good": "99.5%"}}
+}`))
+	suite.Require().NoError(err)
+	cfg.ApplyDefaults()
+	suite.Require().NoError(cfg.Validate())
+
+	conn := cfg.GetConnectionConfig()
+	suite.Equal([]string{"b1:9092", "b2:9092"}, conn.Brokers)
+	suite.Equal("orders", conn.Topic)
+	suite.Equal(config.DefaultKafkaGroup, conn.GroupID)
+	suite.InDelta(0.995, cfg.GetThresholds().AvailabilityGood, 1e-9)
+}
+
+// TestDefaults 测试默认值
+func (suite *ConfigTestSuite) TestDefaults() {
+	cfg, err := config.Parse([]byte("middleware: Kafka\ntest:\n  duration: 10s\n"))
+	suite.Require().NoError(err)
+	cfg.ApplyDefaults()
+
+	suite.Equal("kafka", cfg.GetMiddlewareType())
+	conn := cfg.GetConnectionConfig()
+	suite.Equal([]string{"localhost:9092"}, conn.Brokers)
+	suite.Equal(config.DefaultKafkaTopic, conn.Topic)
+	suite.Equal(config.DefaultTimeout, conn.Timeout)
+	suite.Equal(1, cfg.GetTestConfig().Concurrency)
+
+	out := cfg.GetOutputConfig()
+	suite.Equal("console", out.Format)
+	suite.True(out.IncludeRecommendations)
+}
+
+// TestEnvExpansion 测试环境变量展开和覆盖
+func (suite *ConfigTestSuite) TestEnvExpansion() {
+	suite.T().Setenv("TEST_REDIS_HOST", "cache.internal")
+	suite.T().Setenv("TEST_REDIS_PASSWORD", "s3cr$t")
+
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+connection:
+  host: "${TEST_REDIS_HOST}"
+  password: "${TEST_REDIS_PASSWORD}"
+test:
+  operations: 1
+`))
+	suite.Require().NoError(err)
+	suite.Equal("cache.internal", cfg.Connection.Host)
+	suite.Equal("s3cr$t", cfg.Connection.Password)
+
+	suite.T().Setenv(config.EnvPassword, "from-env")
+	cfg.ApplyEnv()
+	suite.Equal("from-env", cfg.GetConnectionConfig().Password)
+}
+
+// TestHistoryAndLabels 测试运行历史目录和标签
+func (suite *ConfigTestSuite) TestHistoryAndLabels() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+history:
+  dir: /var/lib/mct
+labels:
+  env: staging
+  build: "1234"
+`))
+	suite.Require().NoError(err)
+	suite.Equal("/var/lib/mct", cfg.History.Dir)
+	suite.Equal(map[string]string{"env": "staging", "build": "1234"}, cfg.Labels)
+	suite.NoError(cfg.Validate())
+
+	suite.T().Setenv(config.EnvHistoryDir, "/tmp/mct")
+	cfg.ApplyEnv()
+	suite.Equal("/tmp/mct", cfg.History.Dir)
+
+	cfg.Labels["bad name"] = "x"
+	err = cfg.Validate()
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.Contains(err.Error(), `labels: invalid label name "bad name"`)
+}
+
+// TestOutputTemplate 测试自定义报告模板配置
+func (suite *ConfigTestSuite) TestOutputTemplate() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+output:
+  format: markdown
+  template: report.md.tmpl
+`))
+	suite.Require().NoError(err)
+	suite.NoError(cfg.Validate())
+	suite.Equal("report.md.tmpl", cfg.GetOutputConfig().Template)
+
+	cfg.Output.Format = "json"
+	err = cfg.Validate()
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.Contains(err.Error(), "output.template")
+}
+
+// TestOutputLang 测试报告语言：配置文件、环境变量和无效值
+func (suite *ConfigTestSuite) TestOutputLang() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+output:
+  lang: en_US
+`))
+	suite.Require().NoError(err)
+	suite.NoError(cfg.Validate())
+	suite.Equal(i18n.En, cfg.ReportLang())
+	suite.Equal("en", cfg.GetOutputConfig().Lang)
+
+	suite.T().Setenv(config.EnvLang, "zh-CN")
+	cfg.ApplyEnv()
+	suite.Equal(i18n.ZhCN, cfg.ReportLang())
+
+	cfg.Output.Lang = "fr"
+	err = cfg.Validate()
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.Contains(err.Error(), "output.lang")
+	suite.Equal(i18n.Default, cfg.ReportLang())
+}
+
+// TestLoad 测试从文件加载
+func (suite *ConfigTestSuite) TestLoad() {
+	path := filepath.Join(suite.T().TempDir(), "test.yaml")
+	suite.Require().NoError(os.WriteFile(path, []byte("middleware: redis\ntest:\n  operations: 5\n"), 0o644))
+
+	cfg, err := config.Load(path)
+	suite.Require().NoError(err)
+	suite.Equal(5, cfg.GetTestConfig().Operations)
+
+	_, err = config.Load(filepath.Join(suite.T().TempDir(), "missing.yaml"))
+	suite.Error(err)
+}
+
+// TestLoadExampleConfigs 测试仓库自带的示例配置
+func (suite *ConfigTestSuite) TestLoadExampleConfigs() {
+	for _, name := range []string{"test-redis.yaml", "test-kafka.yaml"} {
+		cfg, err := config.Load(filepath.Join("..", "..", "..", "configs", name))
+		suite.Require().NoError(err, name)
+		cfg.ApplyDefaults()
+		suite.NoError(cfg.Validate(), name)
+	}
+}
+
+// TestUnknownField 测试拒绝未知字段
+func (suite *ConfigTestSuite) TestUnknownField() {
+	_, err := config.Parse([]byte("middleware: redis\nconection:\n  host: x\n"))
+	suite.Error(err)
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.Contains(err.Error(), "conection")
+}
+
+// TestInvalidPercent 测试无效百分比
+func (suite *ConfigTestSuite) TestInvalidPercent() {
+	_, err := config.Parse([]byte("thresholds:\n  availability:\n    good: abc%\n"))
+	suite.Error(err)
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+}
+
+// TestPercentFlag 测试百分比作为命令行参数
+func (suite *ConfigTestSuite) TestPercentFlag() {
+	var p config.Percent
+	suite.NoError(p.Set("2.5%"))
+	suite.InDelta(0.025, float64(p), 1e-12)
+	suite.Equal("2.5%", p.String())
+	suite.NoError(p.Set("0.1"))
+	suite.InDelta(0.1, float64(p), 1e-12)
+	suite.Equal("percent", p.Type())
+
+	suite.Error(p.Set("abc%"))
+}
+
+// TestValidate_FieldErrors 测试字段级校验错误
+func (suite *ConfigTestSuite) TestValidate_FieldErrors() {
+	cfg, err := config.Parse([]byte(`
+middleware: mysql
+connection:
+  port: 70000
+test:
+  concurrency: -1
+output:
+  format: pdf
+`))
+	suite.Require().NoError(err)
+
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.False(errors.Is(err, core.ErrInvalidThresholds))
+
+	var ve config.ValidationErrors
+	suite.Require().True(errors.As(err, &ve))
+
+	fields := make([]string, 0, len(ve))
+	for _, fe := range ve {
+		fields = append(fields, fe.Field)
+	}
+	suite.Equal([]string{"middleware", "connection.port", "test", "test.concurrency", "output.format"}, fields)
+}
+
+// TestValidate_Thresholds 测试阈值校验
+func (suite *ConfigTestSuite) TestValidate_Thresholds() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+thresholds:
+  availability:
+    excellent: 99%
+    good: 99.9%
+    pass: 120%
+  p95_latency:
+    excellent: 100ms
+    good: 50ms
+`))
+	suite.Require().NoError(err)
+
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.True(errors.Is(err, core.ErrInvalidThresholds))
+	suite.False(errors.Is(err, core.ErrInvalidConfig))
+
+	msg := err.Error()
+	suite.Contains(msg, "thresholds.availability.pass")
+	suite.Contains(msg, "good must not be higher than excellent")
+	suite.Contains(msg, "thresholds.p95_latency")
+}
+
+// TestValidate_Workload 测试工作负载校验
+func (suite *ConfigTestSuite) TestValidate_Workload() {
+	cfg, err := config.Parse([]byte(`
+middleware: kafka
+test:
+  operations: 1
+  workload:
+    - operation: "set"
+      weight: 1
+`))
+	suite.Require().NoError(err)
+
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.True(strings.Contains(err.Error(), "test.workload"), err.Error())
+}
+
+// TestThresholdsMerge 测试配置阈值与默认阈值合并
+func (suite *ConfigTestSuite) TestThresholdsMerge() {
+	cfg, err := config.Parse([]byte("thresholds:\n  p95_latency:\n    pass: 1s\n"))
+	suite.Require().NoError(err)
+
+	merged := evaluator.MergeThresholds(evaluator.DefaultThresholds(), cfg.GetThresholds())
+	defaults := evaluator.DefaultThresholds()
+
+	suite.Equal(time.Second, merged.P95LatencyPass)
+	suite.Equal(defaults.P95LatencyGood, merged.P95LatencyGood)
+	suite.Equal(defaults.AvailabilityPass, merged.AvailabilityPass)
+
+	cfg, err = config.Parse([]byte("thresholds:\n  e2e_p99_latency:\n    pass: 3s\n"))
+	suite.Require().NoError(err)
+	merged = evaluator.MergeThresholds(evaluator.KafkaThresholds(), cfg.GetThresholds())
+	suite.Equal(3*time.Second, merged.E2EP99LatencyPass)
+	suite.Equal(evaluator.KafkaThresholds().E2EP95LatencyPass, merged.E2EP95LatencyPass)
+}
+
+// TestOutputTimestamp 测试报告路径时间戳替换
+func (suite *ConfigTestSuite) TestOutputTimestamp() {
+	cfg, err := config.Parse([]byte("output:\n  path: ./reports/test-{timestamp}.json\n"))
+	suite.Require().NoError(err)
+
+	path := cfg.GetOutputConfig().Path
+	suite.NotContains(path, "{timestamp}")
+	suite.True(strings.HasPrefix(path, "./reports/test-"))
+}
+
+// TestOutageDetection 测试故障检测规则配置
+func (suite *ConfigTestSuite) TestOutageDetection() {
+	cfg, err := config.Parse([]byte(`
+test:
+  outage_detection:
+    consecutive_failures: 3
+    error_rate: 20%
+`))
+	suite.Require().NoError(err)
+
+	rule := cfg.GetOutageDetection()
+	defaults := collector.DefaultOutageDetection()
+	suite.Equal(3, rule.ConsecutiveFailures)
+	suite.InDelta(0.2, rule.ErrorRate, 1e-9)
+	suite.Equal(defaults.Window, rule.Window)
+	suite.Equal(defaults.MinWindowOps, rule.MinWindowOps)
+
+	cfg, err = config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+  outage_detection:
+    consecutive_failures: -1
+    error_rate: 150%
+`))
+	suite.Require().NoError(err)
+
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.outage_detection.consecutive_failures")
+	suite.Contains(err.Error(), "test.outage_detection.error_rate")
+}
+
+// TestReconnectPolicy 测试自动重连策略配置
+func (suite *ConfigTestSuite) TestReconnectPolicy() {
+	cfg, err := config.Parse([]byte(`
+connection:
+  reconnect:
+    max_attempts: 3
+    initial_backoff: 50ms
+    jitter: 10%
+`))
+	suite.Require().NoError(err)
+
+	policy := cfg.GetReconnectPolicy()
+	defaults := middleware.DefaultReconnectPolicy()
+	suite.Equal(3, policy.MaxAttempts)
+	suite.Equal(50*time.Millisecond, policy.InitialBackoff)
+	suite.InDelta(0.1, policy.Jitter, 1e-9)
+	suite.Equal(defaults.MaxBackoff, policy.MaxBackoff)
+	suite.Equal(defaults.Multiplier, policy.Multiplier)
+
+	cfg, err = config.Parse([]byte("connection:\n  reconnect:\n    enabled: false\n"))
+	suite.Require().NoError(err)
+	suite.False(cfg.GetReconnectPolicy().Enabled())
+
+	cfg, err = config.Parse([]byte(`
+middleware: redis
+connection:
+  reconnect:
+    initial_backoff: 10s
+    max_backoff: 1s
+    multiplier: 0.5
+test:
+  operations: 1
+`))
+	suite.Require().NoError(err)
+
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "connection.reconnect.initial_backoff")
+	suite.Contains(err.Error(), "connection.reconnect.multiplier")
+}
+
+// TestVerify 测试一致性校验开关
+func (suite *ConfigTestSuite) TestVerify() {
+	cfg, err := config.Parse([]byte("middleware: redis\ntest:\n  operations: 1\n  verify: true\n"))
+	suite.Require().NoError(err)
+	suite.True(cfg.Test.Verify)
+	suite.NoError(cfg.Validate())
+
+	cfg, err = config.Parse([]byte("middleware: kafka\ntest:\n  operations: 1\n  verify: true\n  verify_grace: -1s\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.verify_grace")
+}
+
+// TestRate 测试开环模式速率
+func (suite *ConfigTestSuite) TestRate() {
+	cfg, err := config.Parse([]byte("middleware: redis\ntest:\n  duration: 10s\n  rate: 500\n"))
+	suite.Require().NoError(err)
+	suite.NoError(cfg.Validate())
+	suite.Equal(500.0, cfg.GetTestConfig().Rate)
+
+	cfg, err = config.Parse([]byte("middleware: redis\ntest:\n  duration: 10s\n  rate: -1\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.rate")
+}
+
+// TestProfile 测试目标速率曲线
+func (suite *ConfigTestSuite) TestProfile() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  duration: 5m
+  rate: 2000
+  profile:
+    shape: step
+    start_rate: 500
+    step_rate: 500
+    step_time: 1m
+`))
+	suite.Require().NoError(err)
+	suite.Require().NoError(cfg.Validate())
+	suite.Equal(&core.LoadProfile{
+		Shape:     core.ProfileStep,
+		StartRate: 500,
+		StepRate:  500,
+		StepTime:  time.Minute,
+	}, cfg.GetTestConfig().Profile)
+
+	cfg, err = config.Parse([]byte("middleware: redis\ntest:\n  duration: 1m\n  profile:\n    shape: ramp\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.profile")
+	suite.Contains(err.Error(), "test.rate")
+
+	cfg, err = config.Parse([]byte("middleware: redis\ntest:\n  duration: 1m\n  rate: 10\n  profile:\n    shape: square\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), `unknown load profile shape "square"`)
+}
+
+// TestCollectorOptions 测试延迟直方图精度和时间序列区间
+func (suite *ConfigTestSuite) TestCollectorOptions() {
+	cfg, err := config.Parse([]byte("middleware: redis\ntest:\n  operations: 1\n  latency_precision: 3\n"))
+	suite.Require().NoError(err)
+	suite.Equal(3, cfg.Test.LatencyPrecision)
+	suite.NoError(cfg.Validate())
+
+	cfg, err = config.Parse([]byte("middleware: redis\ntest:\n  operations: 1\n  latency_precision: 4\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.latency_precision")
+
+	cfg, err = config.Parse([]byte("middleware: redis\ntest:\n  operations: 1\n  series_interval: -1s\n"))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "test.series_interval")
+}
+
+// TestOperationThresholds 测试按操作的阈值
+func (suite *ConfigTestSuite) TestOperationThresholds() {
+	cfg, err := config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+thresholds:
+  operations:
+    SET:
+      availability: 99.9%
+      p99_latency: 50ms
+    read:
+      error_rate: 0.1%
+`))
+	suite.Require().NoError(err)
+	suite.Require().NoError(cfg.Validate())
+
+	thresholds := cfg.GetThresholds()
+	suite.InDelta(0.999, thresholds.Operations["set"].Availability, 1e-12)
+	suite.Equal(50*time.Millisecond, thresholds.Operations["set"].P99Latency)
+	suite.InDelta(0.001, thresholds.Operations["read"].ErrorRate, 1e-12)
+
+	cfg, err = config.Parse([]byte(`
+middleware: redis
+test:
+  operations: 1
+thresholds:
+  operations:
+    produce:
+      p99_latency: 50ms
+    del:
+      availability: 120%
+`))
+	suite.Require().NoError(err)
+	err = cfg.Validate()
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), `unknown operation "produce"`)
+	suite.Contains(err.Error(), `reported as "delete"`)
+	suite.Contains(err.Error(), "thresholds.operations.del.availability")
+}
+
+// TestConfigTestSuite 运行测试套件
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
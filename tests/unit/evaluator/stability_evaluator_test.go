@@ -1,6 +1,7 @@
 package evaluator_test
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"middleware-chaos-testing/internal/core"
 	"middleware-chaos-testing/internal/evaluator"
+	"middleware-chaos-testing/internal/i18n"
 )
 
 // StabilityEvaluatorTestSuite 稳定性评估器测试套件
@@ -340,6 +342,272 @@ func (suite *StabilityEvaluatorTestSuite) TestEvaluate_GradeMapping() {
 	}
 }
 
+// TestEvaluate_UnrecoveredOutage 测试测试结束时仍未恢复的故障
+func (suite *StabilityEvaluatorTestSuite) TestEvaluate_UnrecoveredOutage() {
+	start := time.Now().Add(-time.Minute)
+	metrics := &core.StabilityMetrics{
+		Availability:         0.9999,
+		P95Latency:           10 * time.Millisecond,
+		P99Latency:           20 * time.Millisecond,
+		ErrorRate:            0.0001,
+		ReconnectSuccessRate: 0.99,
+		OutageCount:          1,
+		TotalDowntime:        time.Minute,
+		LongestOutage:        time.Minute,
+		Outages: []core.Outage{
+			{Start: start, End: start.Add(time.Minute), Duration: time.Minute, Failures: 100},
+		},
+	}
+
+	result := suite.evaluator.Evaluate(metrics)
+
+	found := false
+	for _, issue := range result.Issues {
+		if issue.Type == "unrecovered_outage" {
+			found = true
+			suite.Equal("CRITICAL", issue.Severity)
+		}
+	}
+	suite.True(found, "Should report unrecovered outage")
+	suite.Equal(core.StatusFail, result.Status, "Unrecovered outage should fail the test")
+	suite.Contains(result.Rationale, "故障检测")
+}
+
+// TestEvaluate_ErrorTypeIssues 测试按错误类型生成问题
+func (suite *StabilityEvaluatorTestSuite) TestEvaluate_ErrorTypeIssues() {
+	metrics := &core.StabilityMetrics{
+		TotalOperations:      10000,
+		FailedOperations:     120,
+		Availability:         0.988,
+		P95Latency:           10 * time.Millisecond,
+		P99Latency:           20 * time.Millisecond,
+		ErrorRate:            0.012,
+		MTTR:                 5 * time.Second,
+		ReconnectSuccessRate: 1,
+		ErrorsByType: map[core.ErrorType]int64{
+			core.ErrorTypeAuthentication: 3,
+			core.ErrorTypeNetwork:        100,
+			core.ErrorTypeTimeout:        17,
+		},
+	}
+
+	result := suite.evaluator.Evaluate(metrics)
+
+	severities := make(map[string]string)
+	for _, issue := range result.Issues {
+		severities[issue.Type] = issue.Severity
+	}
+	suite.Equal("CRITICAL", severities["authentication_failures"])
+	suite.Equal("HIGH", severities["network_errors"])
+	suite.NotContains(severities, "timeout_errors", "Timeouts below the fair threshold should not be reported")
+	suite.Equal(core.StatusFail, result.Status)
+
+	titles := make([]string, 0, len(result.Recommendations))
+	for _, rec := range result.Recommendations {
+		titles = append(titles, rec.Title)
+	}
+	suite.Contains(titles, "修复认证配置")
+}
+
+// TestEvaluateKafka_DeliveryIssues 测试Kafka消息重复和乱序问题
+func (suite *StabilityEvaluatorTestSuite) TestEvaluateKafka_DeliveryIssues() {
+	metrics := &core.StabilityMetrics{
+		TotalOperations:      10000,
+		Availability:         1,
+		P95Latency:           10 * time.Millisecond,
+		P99Latency:           20 * time.Millisecond,
+		ReconnectSuccessRate: 1,
+	}
+	(&core.DeliveryReport{Produced: 100, Acked: 100, Received: 100, Duplicates: 2, OutOfOrder: 1}).Apply(metrics)
+
+	result := suite.evaluator.EvaluateKafka(metrics)
+
+	severities := make(map[string]string)
+	for _, issue := range result.Issues {
+		severities[issue.Type] = issue.Severity
+	}
+	suite.Equal("MEDIUM", severities["duplicate_messages"])
+	suite.Equal("MEDIUM", severities["out_of_order_messages"])
+
+	titles := make([]string, 0, len(result.Recommendations))
+	for _, rec := range result.Recommendations {
+		titles = append(titles, rec.Title)
+	}
+	suite.Contains(titles, "启用幂等生产")
+	suite.Contains(titles, "保证分区内消息顺序")
+}
+
+// TestEvaluate_OperationThresholds 测试按操作名称或类型的阈值
+func (suite *StabilityEvaluatorTestSuite) TestEvaluate_OperationThresholds() {
+	eval := evaluator.NewStabilityEvaluator(&core.Thresholds{
+		Operations: map[string]core.OperationThresholds{
+			"set":  {Availability: 0.999, P99Latency: 50 * time.Millisecond},
+			"read": {ErrorRate: 0.001, P95Latency: 10 * time.Millisecond},
+		},
+	})
+	metrics := &core.StabilityMetrics{
+		TotalOperations:      10000,
+		Availability:         0.9995,
+		P95Latency:           5 * time.Millisecond,
+		P99Latency:           10 * time.Millisecond,
+		ReconnectSuccessRate: 1,
+		Operations: map[string]*core.OperationMetrics{
+			"set": {Name: "set", Type: core.OpTypeWrite, Operations: 2000, Availability: 0.9975, ErrorRate: 0.0025,
+				P99Latency: 80 * time.Millisecond},
+			"get": {Name: "get", Type: core.OpTypeRead, Operations: 8000, Availability: 1,
+				P95Latency: 2 * time.Millisecond},
+			"delete": {Name: "delete", Type: core.OpTypeDelete, Operations: 10, Availability: 0.5, ErrorRate: 0.5},
+		},
+	}
+
+	result := eval.Evaluate(metrics)
+
+	metricsWithIssues := make(map[string]string)
+	for _, issue := range result.Issues {
+		metricsWithIssues[issue.Metric] = issue.Type
+	}
+	suite.Equal("operation_low_availability", metricsWithIssues["operations.set.availability"])
+	suite.Equal("operation_high_latency", metricsWithIssues["operations.set.p99_latency"])
+	suite.NotContains(metricsWithIssues, "operations.get.error_rate", "get is within the read thresholds")
+	suite.NotContains(metricsWithIssues, "operations.delete.availability", "delete has no thresholds")
+	suite.Equal(core.StatusWarning, result.Status)
+	suite.Contains(result.Rationale, "set 可用性 99.75%")
+
+	metrics.Operations["get"].ErrorRate = 0.01
+	metrics.Operations["get"].P95Latency = 20 * time.Millisecond
+	result = eval.Evaluate(metrics)
+	metricsWithIssues = make(map[string]string)
+	for _, issue := range result.Issues {
+		metricsWithIssues[issue.Metric] = issue.Type
+	}
+	suite.Equal("operation_high_error_rate", metricsWithIssues["operations.get.error_rate"])
+	suite.Equal("operation_high_latency", metricsWithIssues["operations.get.p95_latency"])
+
+	base := evaluator.DefaultThresholds()
+	base.Operations = map[string]core.OperationThresholds{"set": {Availability: 0.999}, "read": {ErrorRate: 0.001}}
+	merged := evaluator.MergeThresholds(base,
+		&core.Thresholds{Operations: map[string]core.OperationThresholds{"set": {Availability: 0.99}}})
+	suite.Equal(0.99, merged.Operations["set"].Availability)
+	suite.Contains(merged.Operations, "read")
+}
+
+// TestEvaluate_OpenLoopSchedule 测试开环负载落后计划时提示
+func (suite *StabilityEvaluatorTestSuite) TestEvaluate_OpenLoopSchedule() {
+	metrics := &core.StabilityMetrics{
+		TotalOperations:      10000,
+		Availability:         1,
+		P95Latency:           5 * time.Millisecond,
+		P99Latency:           10 * time.Millisecond,
+		ReconnectSuccessRate: 1,
+		Throughput:           800,
+		TargetRate:           1000,
+		LateOperations:       50,
+		MaxScheduleLag:       20 * time.Millisecond,
+	}
+
+	result := suite.evaluator.Evaluate(metrics)
+	for _, issue := range result.Issues {
+		suite.NotEqual("load_generator_behind", issue.Type, "0.5% late operations is within tolerance")
+	}
+	suite.Contains(result.Rationale, "开环负载: 目标 1000 ops/s，实际 800 ops/s")
+
+	metrics.LateOperations = 500
+	result = suite.evaluator.Evaluate(metrics)
+	var found bool
+	for _, issue := range result.Issues {
+		if issue.Type == "load_generator_behind" {
+			found = true
+			suite.Equal("MEDIUM", issue.Severity)
+		}
+	}
+	suite.True(found, "Expected load_generator_behind issue")
+	suite.NotEqual(core.StatusFail, result.Status)
+}
+
+// TestEvaluateKafka_EndToEndLatency 测试Kafka端到端延迟计入性能得分
+func (suite *StabilityEvaluatorTestSuite) TestEvaluateKafka_EndToEndLatency() {
+	eval := evaluator.NewStabilityEvaluator(evaluator.KafkaThresholds())
+	newMetrics := func() *core.StabilityMetrics {
+		return &core.StabilityMetrics{
+			TotalOperations:      10000,
+			Availability:         1,
+			P95Latency:           5 * time.Millisecond,
+			P99Latency:           10 * time.Millisecond,
+			ReconnectSuccessRate: 1,
+		}
+	}
+	baseline := eval.EvaluateKafka(newMetrics())
+	suite.Equal(25.0, baseline.Scores.Performance)
+
+	metrics := newMetrics()
+	(&core.DeliveryReport{
+		Produced: 100, Acked: 100, Received: 100,
+		EndToEndLatency: core.LatencySummary{P50: time.Second, P95: 2 * time.Second, P99: 3 * time.Second},
+	}).Apply(metrics)
+	suite.Equal(2*time.Second, metrics.ConsumerLag)
+
+	result := eval.EvaluateKafka(metrics)
+	suite.Equal((25.0+8.0+5.0)/2, result.Scores.Performance)
+	suite.Less(result.Score, baseline.Score)
+	suite.Contains(result.Rationale, "端到端延迟")
+
+	found := false
+	for _, issue := range result.Issues {
+		if issue.Type == "high_e2e_latency" && issue.Metric == "e2e_p95_latency" {
+			found = true
+			suite.Equal("HIGH", issue.Severity)
+		}
+	}
+	suite.True(found, "Should report high end-to-end latency")
+	suite.Equal(core.StatusWarning, result.Status)
+}
+
+// TestEvaluate_English 测试英文的问题、建议和判断依据，问题类型和建议代码不随语言变化
+func (suite *StabilityEvaluatorTestSuite) TestEvaluate_English() {
+	newMetrics := func() *core.StabilityMetrics {
+		metrics := &core.StabilityMetrics{
+			TotalOperations:      10000,
+			Availability:         0.98,
+			ErrorRate:            0.02,
+			ErrorsByType:         map[core.ErrorType]int64{core.ErrorTypeTimeout: 200},
+			P95Latency:           10 * time.Millisecond,
+			P99Latency:           20 * time.Millisecond,
+			ReconnectSuccessRate: 1,
+		}
+		(&core.DeliveryReport{Produced: 100, Acked: 100, Received: 100, Duplicates: 2}).Apply(metrics)
+		return metrics
+	}
+	zh := evaluator.EvaluateMiddleware("kafka", nil, i18n.ZhCN, newMetrics())
+	en := evaluator.EvaluateMiddleware("kafka", nil, i18n.En, newMetrics())
+
+	suite.Equal(zh.Score, en.Score)
+	suite.Require().Equal(len(zh.Issues), len(en.Issues))
+	for i := range en.Issues {
+		suite.Equal(zh.Issues[i].Type, en.Issues[i].Type)
+		suite.NotEqual(zh.Issues[i].Message, en.Issues[i].Message)
+	}
+	suite.Require().Equal(len(zh.Recommendations), len(en.Recommendations))
+	codes := make([]string, 0, len(en.Recommendations))
+	for i, rec := range en.Recommendations {
+		suite.Equal(zh.Recommendations[i].Code, rec.Code)
+		codes = append(codes, rec.Code)
+	}
+	suite.Contains(codes, "enable_idempotence")
+
+	han := regexp.MustCompile(`\p{Han}`)
+	text := []string{en.Rationale}
+	for _, issue := range en.Issues {
+		text = append(text, issue.Message)
+	}
+	for _, rec := range en.Recommendations {
+		text = append(append(text, rec.Title, rec.Message), rec.Actions...)
+	}
+	for _, s := range text {
+		suite.False(han.MatchString(s), "English evaluation should not contain Chinese: %s", s)
+	}
+	suite.Contains(en.Rationale, "Availability")
+}
+
 // TestStabilityEvaluatorTestSuite 运行测试套件
 func TestStabilityEvaluatorTestSuite(t *testing.T) {
 	suite.Run(t, new(StabilityEvaluatorTestSuite))
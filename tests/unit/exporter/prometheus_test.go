@@ -0,0 +1,150 @@
+package exporter_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/exporter"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// PrometheusExporterTestSuite Prometheus指标导出测试套件
+type PrometheusExporterTestSuite struct {
+	suite.Suite
+	exporter  *exporter.Exporter
+	collector *collector.MetricsCollector
+	phase     string
+}
+
+// SetupTest 每个测试前执行
+func (suite *PrometheusExporterTestSuite) SetupTest() {
+	suite.exporter = exporter.NewExporter("redis")
+	suite.exporter.SetPhaseSource(func() string { return suite.phase })
+	suite.collector = collector.NewMetricsCollector()
+	suite.collector.SetErrorClassifier(middleware.ClassifyError)
+	suite.collector.SetObserver(suite.exporter)
+	suite.phase = ""
+}
+
+// record 记录一次操作
+func (suite *PrometheusExporterTestSuite) record(op string, opType core.OperationType, d time.Duration, err error) {
+	result := core.NewResult(err == nil, d, err)
+	result.Operation = op
+	result.OpType = opType
+	suite.collector.RecordOperation(result)
+}
+
+// scrape 通过HTTP抓取指标，返回 指标行 -> 值
+func (suite *PrometheusExporterTestSuite) scrape() map[string]string {
+	resp, err := http.Get("http://" + suite.exporter.Addr() + exporter.MetricsPath)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Contains(resp.Header.Get("Content-Type"), "version=0.0.4")
+
+	body, err := io.ReadAll(resp.Body)
+	suite.Require().NoError(err)
+	samples := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		suite.Require().Positive(i, "malformed line %q", line)
+		samples[line[:i]] = line[i+1:]
+	}
+	return samples
+}
+
+// TestScrape 测试通过HTTP抓取计数和延迟直方图
+func (suite *PrometheusExporterTestSuite) TestScrape() {
+	suite.Require().NoError(suite.exporter.Start("127.0.0.1:0"))
+	defer suite.exporter.Close()
+
+	suite.record("set", core.OpTypeWrite, 2*time.Millisecond, nil)
+	suite.record("set", core.OpTypeWrite, 300*time.Millisecond, nil)
+	suite.record("get", core.OpTypeRead, time.Millisecond, context.DeadlineExceeded)
+
+	samples := suite.scrape()
+	set := `middleware="redis",operation="set",type="write",phase=""`
+	get := `middleware="redis",operation="get",type="read",phase=""`
+	suite.Equal("2", samples[`mct_operations_total{`+set+`,result="success"}`])
+	suite.Equal("0", samples[`mct_operations_total{`+set+`,result="failure"}`])
+	suite.Equal("1", samples[`mct_operations_total{`+get+`,result="failure"}`])
+	suite.Equal("1", samples[`mct_errors_total{`+get+`,error_type="timeout"}`])
+
+	suite.Equal("0", samples[`mct_operation_duration_seconds_bucket{`+set+`,le="0.001"}`])
+	suite.Equal("1", samples[`mct_operation_duration_seconds_bucket{`+set+`,le="0.0025"}`])
+	suite.Equal("1", samples[`mct_operation_duration_seconds_bucket{`+set+`,le="0.25"}`])
+	suite.Equal("2", samples[`mct_operation_duration_seconds_bucket{`+set+`,le="0.5"}`])
+	suite.Equal("2", samples[`mct_operation_duration_seconds_bucket{`+set+`,le="+Inf"}`])
+	suite.Equal("2", samples[`mct_operation_duration_seconds_count{`+set+`}`])
+	suite.Equal("0.302", samples[`mct_operation_duration_seconds_sum{`+set+`}`])
+	suite.Equal("1", samples[`mct_operation_duration_seconds_bucket{`+get+`,le="0.001"}`])
+
+	// 计数实时更新
+	suite.record("set", core.OpTypeWrite, time.Millisecond, nil)
+	suite.Equal("3", suite.scrape()[`mct_operations_total{`+set+`,result="success"}`])
+}
+
+// TestPhaseLabel 测试按场景阶段区分序列
+func (suite *PrometheusExporterTestSuite) TestPhaseLabel() {
+	suite.phase = "baseline"
+	suite.record("set", core.OpTypeWrite, time.Millisecond, nil)
+	suite.phase = `latency "200ms"`
+	suite.record("set", core.OpTypeWrite, time.Millisecond, errors.New("boom"))
+
+	var sb strings.Builder
+	suite.exporter.Write(&sb)
+	out := sb.String()
+	suite.Contains(out, `mct_operations_total{middleware="redis",operation="set",type="write",phase="baseline",result="success"} 1`)
+	suite.Contains(out, `mct_operations_total{middleware="redis",operation="set",type="write",phase="latency \"200ms\"",result="failure"} 1`)
+	suite.Contains(out, "# TYPE mct_operation_duration_seconds histogram")
+}
+
+// TestConcurrentObserve 测试并发记录
+func (suite *PrometheusExporterTestSuite) TestConcurrentObserve() {
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				suite.record("get", core.OpTypeRead, time.Millisecond, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	suite.exporter.Write(&sb)
+	suite.Contains(sb.String(),
+		`mct_operation_duration_seconds_count{middleware="redis",operation="get",type="read",phase=""} 4000`)
+}
+
+// TestClose 测试关闭后不再提供服务
+func (suite *PrometheusExporterTestSuite) TestClose() {
+	suite.Require().NoError(suite.exporter.Start("127.0.0.1:0"))
+	addr := suite.exporter.Addr()
+	suite.NotEmpty(addr)
+	suite.NoError(suite.exporter.Close())
+	suite.Empty(suite.exporter.Addr())
+
+	_, err := http.Get("http://" + addr + exporter.MetricsPath)
+	suite.Error(err)
+	suite.NoError(suite.exporter.Close(), "Closing twice should be a no-op")
+}
+
+// TestPrometheusExporterTestSuite 运行测试套件
+func TestPrometheusExporterTestSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusExporterTestSuite))
+}
@@ -0,0 +1,202 @@
+package history_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/history"
+)
+
+// HistoryTestSuite 运行历史测试套件
+type HistoryTestSuite struct {
+	suite.Suite
+	store *history.Store
+}
+
+func (suite *HistoryTestSuite) SetupTest() {
+	suite.store = history.NewStore(filepath.Join(suite.T().TempDir(), "history"))
+}
+
+// record 创建第 day 天的运行记录，延迟随 factor 变化
+func record(name, middleware string, labels map[string]string, day int, score, factor float64) *history.Record {
+	cfg := &config.Config{Name: name, Middleware: middleware, Labels: labels}
+	cfg.Connection.Host = "cache.internal"
+	cfg.Connection.Password = "s3cret"
+	metrics := &core.StabilityMetrics{
+		TotalOperations: 1000,
+		Availability:    0.999,
+		Throughput:      500,
+		P50Latency:      time.Duration(factor * float64(time.Millisecond)),
+		P95Latency:      time.Duration(factor * float64(2*time.Millisecond)),
+		P99Latency:      time.Duration(factor * float64(5*time.Millisecond)),
+		P999Latency:     10 * time.Millisecond,
+		StartTime:       time.Date(2025, 10, day, 2, 0, 0, 0, time.Local),
+		Duration:        time.Minute,
+		ErrorsByType:    map[core.ErrorType]int64{core.ErrorTypeTimeout: 1},
+	}
+	evaluation := &core.EvaluationResult{Score: score, Grade: core.GradeGood, Status: core.StatusPass}
+	return history.NewRecord(cfg, metrics, evaluation)
+}
+
+// TestAppendAndList 测试追加和读取记录
+func (suite *HistoryTestSuite) TestAppendAndList() {
+	records, err := suite.store.List(history.Filter{})
+	suite.NoError(err, "Missing history should be empty")
+	suite.Empty(records)
+
+	// 追加顺序与开始时间不一致时按开始时间排序
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", map[string]string{"env": "ci"}, 2, 85, 1.2)))
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", map[string]string{"env": "ci"}, 1, 90, 1)))
+
+	records, err = suite.store.List(history.Filter{})
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 2)
+	r := records[0]
+	suite.True(strings.HasPrefix(r.ID, "20251001-020000-"), r.ID)
+	suite.Equal("nightly", r.Name)
+	suite.Equal("redis", r.Middleware)
+	suite.Equal(map[string]string{"env": "ci"}, r.Labels)
+	suite.Equal(90.0, r.Evaluation.Score)
+	suite.Equal(5*time.Millisecond, r.Metrics.P99Latency)
+	suite.Equal(int64(1), r.Metrics.ErrorsByType[core.ErrorTypeTimeout])
+	suite.Equal("cache.internal", r.Config.Connection.Host)
+	suite.Empty(r.Config.Connection.Password, "Passwords must not be recorded")
+	suite.Equal(85.0, records[1].Evaluation.Score)
+
+	data, err := os.ReadFile(suite.store.Path())
+	suite.Require().NoError(err)
+	suite.Equal(2, strings.Count(string(data), "\n"), "One line per run")
+	suite.NotContains(string(data), "s3cret")
+}
+
+// TestFilter 测试按中间件、名称、标签、时间筛选和数量限制
+func (suite *HistoryTestSuite) TestFilter() {
+	staging := map[string]string{"env": "staging", "build": "42"}
+	prod := map[string]string{"env": "prod"}
+	for day, r := range []*history.Record{
+		record("nightly", "redis", staging, 1, 90, 1),
+		record("nightly", "redis", prod, 2, 89, 1),
+		record("nightly", "kafka", staging, 3, 80, 1),
+		record("smoke", "redis", nil, 4, 70, 1),
+		record("nightly", "redis", staging, 5, 88, 1),
+	} {
+		suite.Require().NoError(suite.store.Append(r), day)
+	}
+
+	scores := func(filter history.Filter) []float64 {
+		records, err := suite.store.List(filter)
+		suite.Require().NoError(err)
+		values := make([]float64, len(records))
+		for i, r := range records {
+			values[i] = r.Evaluation.Score
+		}
+		return values
+	}
+
+	suite.Equal([]float64{90, 89, 70, 88}, scores(history.Filter{Middleware: "Redis"}))
+	suite.Equal([]float64{70}, scores(history.Filter{Name: "smoke"}))
+	suite.Equal([]float64{90, 80, 88}, scores(history.Filter{Labels: map[string]string{"env": "staging"}}))
+	suite.Equal([]float64{90, 88}, scores(history.Filter{Middleware: "redis", Labels: map[string]string{"build": ""}}))
+	suite.Equal([]float64{80, 70, 88}, scores(history.Filter{Since: time.Date(2025, 10, 3, 0, 0, 0, 0, time.Local)}))
+	suite.Equal([]float64{70, 88}, scores(history.Filter{Limit: 2}), "Limit keeps the most recent runs")
+}
+
+// TestCorruptHistory 测试未写完和损坏的记录
+func (suite *HistoryTestSuite) TestCorruptHistory() {
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", nil, 1, 90, 1)))
+
+	f, err := os.OpenFile(suite.store.Path(), os.O_APPEND|os.O_WRONLY, 0o644)
+	suite.Require().NoError(err)
+	_, err = f.WriteString(`{"id": "20251002-020000-0001", "metr`)
+	suite.Require().NoError(err)
+	suite.Require().NoError(f.Close())
+
+	records, err := suite.store.List(history.Filter{})
+	suite.NoError(err, "An unfinished last line is ignored")
+	suite.Len(records, 1)
+
+	suite.Require().NoError(os.WriteFile(suite.store.Path(), []byte("not json\n"), 0o644))
+	_, err = suite.store.List(history.Filter{})
+	suite.True(errors.Is(err, core.ErrInvalidMetrics))
+	suite.Contains(err.Error(), "line 1")
+}
+
+// TestAppendAfterPartialRecord 测试在未写完的记录之后追加，未写完的部分被截掉
+func (suite *HistoryTestSuite) TestAppendAfterPartialRecord() {
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", nil, 1, 90, 1)))
+
+	f, err := os.OpenFile(suite.store.Path(), os.O_APPEND|os.O_WRONLY, 0o644)
+	suite.Require().NoError(err)
+	_, err = f.WriteString(`{"id": "20251002-020000-0001", "metr`)
+	suite.Require().NoError(err)
+	suite.Require().NoError(f.Close())
+
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", nil, 3, 80, 1)))
+
+	records, err := suite.store.List(history.Filter{})
+	suite.Require().NoError(err)
+	suite.Len(records, 2)
+
+	data, err := os.ReadFile(suite.store.Path())
+	suite.Require().NoError(err)
+	suite.NotContains(string(data), `"metr{`)
+	suite.Equal(2, strings.Count(string(data), "\n"))
+
+	// 文件只有未写完的记录
+	suite.Require().NoError(os.WriteFile(suite.store.Path(), []byte(`{"id": "2025`), 0o644))
+	suite.Require().NoError(suite.store.Append(record("nightly", "redis", nil, 4, 85, 1)))
+	records, err = suite.store.List(history.Filter{})
+	suite.Require().NoError(err)
+	suite.Len(records, 1)
+}
+
+// TestTrends 测试趋势计算和输出
+func (suite *HistoryTestSuite) TestTrends() {
+	records := []*history.Record{
+		record("nightly", "redis", map[string]string{"env": "ci"}, 1, 90, 1),
+		record("nightly", "redis", map[string]string{"env": "ci"}, 2, 85, 1.5),
+		record("nightly", "redis", map[string]string{"env": "ci"}, 3, 80, 2),
+	}
+
+	trends := history.Trends(records)
+	byMetric := make(map[string][]float64)
+	for _, t := range trends {
+		byMetric[t.Metric] = t.Values
+	}
+	suite.Equal([]float64{90, 85, 80}, byMetric["score"])
+	suite.Equal([]float64{float64(5 * time.Millisecond), float64(7500 * time.Microsecond), float64(10 * time.Millisecond)},
+		byMetric["p99_latency"])
+	suite.Len(byMetric["p999_latency"], 3)
+
+	var sb strings.Builder
+	suite.Require().NoError(history.WriteConsole(&sb, records))
+	out := sb.String()
+	suite.Contains(out, "运行历史")
+	suite.Contains(out, records[2].ID)
+	suite.Contains(out, "env=ci")
+	suite.Contains(out, "趋势 (2025-10-01 02:00 ~ 2025-10-03 02:00, 3 次运行)")
+	suite.Regexp(`总分\s+█▅▁\s+90\.0\s+80\.0\s+80\.0\s+90\.0\s+-11\.1%`, out)
+	suite.Regexp(`P99延迟\s+▁▅█\s+5ms\s+10ms\s+5ms\s+10ms\s+\+100\.0%`, out)
+	suite.Regexp(`P99\.9延迟\s+▅▅▅\s+10ms`, out)
+	suite.Regexp(`可用性\s+▅▅▅\s+99\.900%\s+99\.900%\s+99\.900%\s+99\.900%\s+\+0\.000pp`, out)
+
+	sb.Reset()
+	suite.Require().NoError(history.WriteConsole(&sb, records[:1]))
+	suite.NotContains(sb.String(), "趋势", "A single run has no trend")
+
+	sb.Reset()
+	suite.Require().NoError(history.WriteConsole(&sb, nil))
+	suite.Contains(sb.String(), "没有符合条件的运行记录")
+}
+
+// TestHistoryTestSuite 运行测试套件
+func TestHistoryTestSuite(t *testing.T) {
+	suite.Run(t, new(HistoryTestSuite))
+}
@@ -0,0 +1,78 @@
+package i18n_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/i18n"
+)
+
+// I18nTestSuite 消息目录测试套件
+type I18nTestSuite struct {
+	suite.Suite
+}
+
+// TestParse 测试语言名称解析
+func (suite *I18nTestSuite) TestParse() {
+	for name, want := range map[string]i18n.Lang{
+		"":        i18n.ZhCN,
+		"zh":      i18n.ZhCN,
+		"zh-CN":   i18n.ZhCN,
+		"zh_cn":   i18n.ZhCN,
+		"ZH-Hans": i18n.ZhCN,
+		"en":      i18n.En,
+		"en-US":   i18n.En,
+		" EN_gb ": i18n.En,
+	} {
+		lang, err := i18n.Parse(name)
+		suite.Require().NoError(err, name)
+		suite.Equal(want, lang, name)
+	}
+
+	for _, name := range []string{"fr", "zh-TW", "english"} {
+		_, err := i18n.Parse(name)
+		suite.Error(err, name)
+	}
+}
+
+// TestT 测试消息格式化和回退
+func (suite *I18nTestSuite) TestT() {
+	suite.Equal("Middleware Stability Test Report", i18n.En.T("report.title"))
+	suite.Equal("中间件稳定性测试报告", i18n.ZhCN.T("report.title"))
+	suite.Equal("Issues (3)", i18n.En.T("report.issues", 3))
+	suite.Equal("发现的问题 (3个)", i18n.ZhCN.T("report.issues", 3))
+
+	// 未设置语言时使用默认语言，缺少的消息返回key
+	suite.Equal("中间件稳定性测试报告", i18n.Lang("").T("report.title"))
+	suite.Equal("no.such.key", i18n.En.T("no.such.key"))
+	suite.False(i18n.En.Has("no.such.key"))
+}
+
+// TestLinesAndName 测试多行消息和代码显示名称
+func (suite *I18nTestSuite) TestLinesAndName() {
+	actions := i18n.En.Lines("rec.enable_idempotence.actions")
+	suite.Greater(len(actions), 1)
+	suite.Contains(actions[0], "enable.idempotence=true")
+	suite.Nil(i18n.En.Lines("no.such.key"))
+
+	suite.Equal("Timeout", i18n.En.Name("error_type", "timeout"))
+	suite.Equal("超时", i18n.ZhCN.Name("error_type", "timeout"))
+	suite.Equal("custom", i18n.En.Name("error_type", "custom"), "Unknown codes are shown as is")
+}
+
+// TestCatalogsComplete 测试各语言的消息目录完整，且格式参数一致
+func (suite *I18nTestSuite) TestCatalogsComplete() {
+	verbs := regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+	for _, lang := range i18n.Langs {
+		suite.Equal(i18n.Keys(i18n.Default), i18n.Keys(lang), "Catalog %s should have the same keys", lang)
+		for _, key := range i18n.Keys(i18n.Default) {
+			suite.Equal(verbs.FindAllString(i18n.Default.T(key), -1), verbs.FindAllString(lang.T(key), -1),
+				"Message %s in %s should take the same arguments", key, lang)
+		}
+	}
+}
+
+func TestI18nTestSuite(t *testing.T) {
+	suite.Run(t, new(I18nTestSuite))
+}
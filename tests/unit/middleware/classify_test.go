@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// redisError 模拟go-redis返回的服务端错误
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+func (redisError) RedisError()     {}
+
+var _ redis.Error = redisError("")
+
+// ClassifyErrorTestSuite 错误分类测试套件
+type ClassifyErrorTestSuite struct {
+	suite.Suite
+}
+
+// TestClassify 测试各类错误的归类
+func (suite *ClassifyErrorTestSuite) TestClassify() {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	ioTimeout := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+
+	cases := []struct {
+		name     string
+		err      error
+		expected core.ErrorType
+	}{
+		{"nil", nil, ""},
+		{"connection refused", refused, core.ErrorTypeNetwork},
+		{"wrapped connection refused", fmt.Errorf("failed to produce message: %w", refused), core.ErrorTypeNetwork},
+		{"eof", io.EOF, core.ErrorTypeNetwork},
+		{"redis client closed", redis.ErrClosed, core.ErrorTypeNetwork},
+		{"io timeout", ioTimeout, core.ErrorTypeTimeout},
+		{"context deadline", context.DeadlineExceeded, core.ErrorTypeTimeout},
+		{"redis pool timeout", errors.New("redis: connection pool timeout"), core.ErrorTypeTimeout},
+		{"redis noauth", redisError("NOAUTH Authentication required."), core.ErrorTypeAuthentication},
+		{"redis wrongpass", redisError("WRONGPASS invalid username-password pair or user is disabled."), core.ErrorTypeAuthentication},
+		{"redis other", redisError("WRONGTYPE Operation against a key holding the wrong kind of value"), core.ErrorTypeOther},
+		{"kafka sasl", kafka.SASLAuthenticationFailed, core.ErrorTypeAuthentication},
+		{"kafka topic acl", fmt.Errorf("failed to produce message: %w", kafka.TopicAuthorizationFailed), core.ErrorTypeAuthentication},
+		{"kafka leader", kafka.LeaderNotAvailable, core.ErrorTypeNetwork},
+		{"kafka request timeout", kafka.RequestTimedOut, core.ErrorTypeTimeout},
+		{"kafka offset out of range", kafka.OffsetOutOfRange, core.ErrorTypeDataLoss},
+		{"kafka write errors", kafka.WriteErrors{nil, kafka.NotLeaderForPartition}, core.ErrorTypeNetwork},
+		{"kafka other", kafka.InvalidMessage, core.ErrorTypeOther},
+		{"sasl handshake", errors.New("SASL handshake failed"), core.ErrorTypeAuthentication},
+		{"unknown", errors.New("boom"), core.ErrorTypeOther},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.expected, middleware.ClassifyError(tc.err))
+		})
+	}
+}
+
+// TestClassifyErrorTestSuite 运行测试套件
+func TestClassifyErrorTestSuite(t *testing.T) {
+	suite.Run(t, new(ClassifyErrorTestSuite))
+}
@@ -0,0 +1,272 @@
+package middleware_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// DeliveryTrackerTestSuite 消息投递跟踪测试套件
+type DeliveryTrackerTestSuite struct {
+	suite.Suite
+	tracker *middleware.DeliveryTracker
+	base    time.Time
+}
+
+// SetupTest 每个测试前执行
+func (suite *DeliveryTrackerTestSuite) SetupTest() {
+	suite.tracker = middleware.NewDeliveryTracker("run-1")
+	suite.base = time.Now()
+}
+
+// produce 在 base+start 开始发送一条消息，在 base+ack 确认
+func (suite *DeliveryTrackerTestSuite) produce(start, ack time.Duration) kafka.Message {
+	msg := kafka.Message{Value: []byte("v")}
+	seq := suite.tracker.Stamp(&msg, suite.base.Add(start))
+	suite.tracker.Ack(seq, true, suite.base.Add(ack))
+	return msg
+}
+
+// deliver 模拟消费端在指定分区收到消息
+func (suite *DeliveryTrackerTestSuite) deliver(partition int, msgs ...kafka.Message) {
+	for _, msg := range msgs {
+		msg.Partition = partition
+		suite.tracker.Observe(msg, time.Now())
+	}
+}
+
+// TestStampHeaders 测试消息头携带运行ID和序列号
+func (suite *DeliveryTrackerTestSuite) TestStampHeaders() {
+	msg := kafka.Message{}
+	suite.Equal(uint64(1), suite.tracker.Stamp(&msg, suite.base))
+	suite.Equal(uint64(2), suite.tracker.Stamp(&kafka.Message{}, suite.base))
+
+	headers := make(map[string]string)
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	suite.Equal("run-1", headers[middleware.HeaderRunID])
+	suite.Equal("1", headers[middleware.HeaderSeq])
+	suite.Equal(strconv.FormatInt(suite.base.UnixNano(), 10), headers[middleware.HeaderSentAt])
+
+	suite.NotEmpty(middleware.NewDeliveryTracker("").RunID(), "An empty run ID should be generated")
+}
+
+// TestAllDelivered 测试全部按序送达
+func (suite *DeliveryTrackerTestSuite) TestAllDelivered() {
+	m1 := suite.produce(0, time.Millisecond)
+	m2 := suite.produce(2*time.Millisecond, 3*time.Millisecond)
+	suite.deliver(0, m1, m2)
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(2), report.Produced)
+	suite.Equal(int64(2), report.Acked)
+	suite.Equal(int64(2), report.Received)
+	suite.Zero(report.Lost + report.Duplicates + report.OutOfOrder)
+	suite.Zero(suite.tracker.Pending())
+}
+
+// TestLatencies 测试发送确认延迟和端到端延迟
+func (suite *DeliveryTrackerTestSuite) TestLatencies() {
+	for i := 1; i <= 100; i++ {
+		msg := suite.produce(0, time.Duration(i)*time.Millisecond)
+		suite.tracker.Observe(msg, suite.base.Add(time.Duration(i)*10*time.Millisecond))
+	}
+
+	// 延迟按直方图分桶记录，百分位误差在1%以内，最大值精确
+	report := suite.tracker.Report()
+	suite.InEpsilon(float64(51*time.Millisecond), float64(report.ProduceLatency.P50), 0.01)
+	suite.InEpsilon(float64(96*time.Millisecond), float64(report.ProduceLatency.P95), 0.01)
+	suite.InEpsilon(float64(100*time.Millisecond), float64(report.ProduceLatency.P99), 0.01)
+	suite.Equal(100*time.Millisecond, report.ProduceLatency.Max)
+	suite.InEpsilon(float64(510*time.Millisecond), float64(report.EndToEndLatency.P50), 0.01)
+	suite.Equal(time.Second, report.EndToEndLatency.Max)
+
+	metrics := &core.StabilityMetrics{}
+	report.Apply(metrics)
+	suite.InEpsilon(float64(960*time.Millisecond), float64(metrics.ConsumerLag), 0.01)
+}
+
+// TestCompletedMessagesPruned 测试收到且结果已知的消息不再占用发送记录，重复仍可识别
+func (suite *DeliveryTrackerTestSuite) TestCompletedMessagesPruned() {
+	m1 := suite.produce(0, time.Millisecond)
+	m2 := kafka.Message{}
+	seq := suite.tracker.Stamp(&m2, suite.base)
+	m3 := kafka.Message{}
+	failed := suite.tracker.Stamp(&m3, suite.base)
+	suite.tracker.Ack(failed, false, suite.base)
+	m4 := suite.produce(0, time.Millisecond)
+	suite.Equal(4, suite.tracker.Tracked())
+
+	suite.deliver(0, m1, m2, m3)
+	suite.Equal(2, suite.tracker.Tracked(), "Messages awaiting ack or delivery must be kept")
+	suite.tracker.Ack(seq, true, suite.base.Add(time.Millisecond))
+	suite.Equal(1, suite.tracker.Tracked())
+
+	suite.deliver(0, m1, m2, m3, m4)
+	suite.Zero(suite.tracker.Tracked())
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(4), report.Received)
+	suite.Equal(int64(3), report.Duplicates)
+	suite.Equal(int64(3), report.Acked)
+	suite.Zero(report.Lost)
+}
+
+// TestLostAndDuplicate 测试丢失和重复
+func (suite *DeliveryTrackerTestSuite) TestLostAndDuplicate() {
+	m1 := suite.produce(0, time.Millisecond)
+	suite.produce(2*time.Millisecond, 3*time.Millisecond)
+	suite.deliver(0, m1, m1, m1)
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(1), report.Received)
+	suite.Equal(int64(2), report.Duplicates)
+	suite.Equal(int64(1), report.Lost)
+
+	metrics := &core.StabilityMetrics{}
+	report.Apply(metrics)
+	suite.Equal(0.5, metrics.DataLossRate)
+	suite.Equal(int64(2), metrics.DuplicateMessages)
+	suite.InDelta(2.0/3.0, metrics.DuplicateRate, 1e-9)
+	suite.Equal(report, metrics.Delivery)
+}
+
+// TestFailedSendsExpire 测试发送失败且未收到的消息在宽限期后不再跟踪
+func (suite *DeliveryTrackerTestSuite) TestFailedSendsExpire() {
+	suite.tracker.SetGrace(time.Second)
+	for i := 0; i < 100; i++ {
+		seq := suite.tracker.Stamp(&kafka.Message{}, suite.base)
+		suite.tracker.Ack(seq, false, suite.base)
+	}
+	suite.Equal(100, suite.tracker.Tracked(), "Failed sends are kept during the grace period")
+
+	// 宽限期后的发送触发过期，之后送达的消息正常核对
+	msg := suite.produce(2*time.Second, 2*time.Second)
+	suite.deliver(0, msg)
+	suite.Zero(suite.tracker.Tracked())
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(101), report.Produced)
+	suite.Equal(int64(1), report.Received)
+	suite.Zero(report.Lost + report.Duplicates)
+}
+
+// TestLostMessagesExpire 测试已确认的消息超过宽限期未收到时计为丢失并不再跟踪，迟到的消息不计为重复
+func (suite *DeliveryTrackerTestSuite) TestLostMessagesExpire() {
+	suite.tracker.SetGrace(time.Second)
+	lost := suite.produce(0, time.Millisecond)
+	delivered := suite.produce(0, time.Millisecond)
+	suite.deliver(0, delivered)
+	suite.Equal(int64(1), suite.tracker.Pending())
+
+	latest := suite.produce(2*time.Second, 2*time.Second)
+	suite.Equal(1, suite.tracker.Tracked(), "Only the latest message is still awaited")
+	suite.Equal(int64(1), suite.tracker.Pending())
+	suite.Equal(int64(2), suite.tracker.Report().Lost, "Expired and pending messages both count as lost")
+
+	suite.deliver(0, latest, lost, delivered)
+	suite.Zero(suite.tracker.Tracked())
+	report := suite.tracker.Report()
+	suite.Equal(int64(2), report.Received)
+	suite.Equal(int64(1), report.Duplicates, "Only the redelivered message is a duplicate")
+	suite.Equal(int64(1), report.Lost, "Messages arriving after the grace period stay lost")
+}
+
+// TestOutOfOrder 测试同一分区内乱序
+func (suite *DeliveryTrackerTestSuite) TestOutOfOrder() {
+	m1 := suite.produce(0, time.Millisecond)
+	m2 := suite.produce(2*time.Millisecond, 3*time.Millisecond)
+	suite.deliver(0, m2, m1)
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(1), report.OutOfOrder)
+
+	metrics := &core.StabilityMetrics{}
+	report.Apply(metrics)
+	suite.Equal(0.5, metrics.OutOfOrderRate)
+}
+
+// TestConcurrentSendsNotOutOfOrder 测试并发发送或不同分区的消息不计为乱序
+func (suite *DeliveryTrackerTestSuite) TestConcurrentSendsNotOutOfOrder() {
+	// m1和m2发送时间重叠，先后顺序不确定
+	m1 := suite.produce(0, 5*time.Millisecond)
+	m2 := suite.produce(time.Millisecond, 2*time.Millisecond)
+	suite.deliver(0, m2, m1)
+
+	// 不同分区之间没有顺序保证
+	m3 := suite.produce(10*time.Millisecond, 11*time.Millisecond)
+	m4 := suite.produce(12*time.Millisecond, 13*time.Millisecond)
+	suite.deliver(1, m4)
+	suite.deliver(2, m3)
+
+	suite.Zero(suite.tracker.Report().OutOfOrder)
+}
+
+// TestUnackedMessages 测试发送结果未知的消息
+func (suite *DeliveryTrackerTestSuite) TestUnackedMessages() {
+	written := kafka.Message{}
+	seq := suite.tracker.Stamp(&written, suite.base)
+	suite.tracker.Ack(seq, false, suite.base)
+	suite.tracker.Stamp(&kafka.Message{}, suite.base)
+	suite.deliver(0, written)
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(2), report.Produced)
+	suite.Equal(int64(1), report.Received)
+	suite.Zero(report.Acked)
+	suite.Zero(report.Lost, "Messages without ack must not count as lost")
+}
+
+// TestReceivedBeforeAck 测试确认返回前已被消费
+func (suite *DeliveryTrackerTestSuite) TestReceivedBeforeAck() {
+	msg := kafka.Message{}
+	seq := suite.tracker.Stamp(&msg, suite.base)
+	suite.deliver(0, msg)
+	suite.tracker.Ack(seq, true, suite.base.Add(time.Millisecond))
+
+	report := suite.tracker.Report()
+	suite.Equal(int64(1), report.Acked)
+	suite.Zero(report.Lost)
+}
+
+// TestForeignMessagesIgnored 测试忽略其他运行的消息
+func (suite *DeliveryTrackerTestSuite) TestForeignMessagesIgnored() {
+	other := middleware.NewDeliveryTracker("run-2")
+	msg := kafka.Message{}
+	other.Stamp(&msg, suite.base)
+	suite.deliver(0, msg, kafka.Message{Value: []byte("no headers")})
+
+	suite.Zero(suite.tracker.Report().Received)
+}
+
+// TestWaitForLateMessages 测试宽限期内等待迟到消息
+func (suite *DeliveryTrackerTestSuite) TestWaitForLateMessages() {
+	msg := suite.produce(0, time.Millisecond)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		suite.tracker.Observe(msg, time.Now())
+	}()
+
+	start := time.Now()
+	suite.tracker.Wait(context.Background(), 5*time.Second)
+	suite.Less(time.Since(start), time.Second, "Wait should return once all messages arrived")
+	suite.Zero(suite.tracker.Report().Lost)
+
+	suite.produce(0, time.Millisecond)
+	start = time.Now()
+	suite.tracker.Wait(context.Background(), 50*time.Millisecond)
+	suite.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+	suite.Equal(int64(1), suite.tracker.Report().Lost)
+}
+
+// TestDeliveryTrackerTestSuite 运行测试套件
+func TestDeliveryTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTrackerTestSuite))
+}
@@ -0,0 +1,507 @@
+package middleware_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// fakeRedisServer 最小RESP服务器，只实现客户端连接和读写所需的命令
+// 数据在重启（stop/start）后保留
+type fakeRedisServer struct {
+	addr string
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+	data     map[string]string
+
+	// 键为 holdKey 的命令在 gate 关闭前不回复（收到时向 held 发送信号），键为 dropKey 的命令直接断开连接
+	gate chan struct{}
+	held chan struct{}
+}
+
+const (
+	holdKey = "hold"
+	dropKey = "drop"
+)
+
+func newFakeRedisServer(addr string) (*fakeRedisServer, error) {
+	s := &fakeRedisServer{
+		conns: make(map[net.Conn]struct{}),
+		data:  make(map[string]string),
+	}
+	if err := s.start(addr); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// start 在指定地址监听（用于模拟重启）
+func (s *fakeRedisServer) start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.listener = ln
+	s.addr = ln.Addr().String()
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns[conn] = struct{}{}
+			s.mu.Unlock()
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serve(conn)
+			}()
+		}
+	}()
+	return nil
+}
+
+// stop 关闭监听和所有连接（模拟服务宕机）
+func (s *fakeRedisServer) stop() {
+	s.mu.Lock()
+	_ = s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = make(map[net.Conn]struct{})
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// put 直接修改服务端数据（模拟数据回滚或损坏）
+func (s *fakeRedisServer) put(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+}
+
+// flush 清空服务端数据（模拟未持久化的重启）
+func (s *fakeRedisServer) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = make(map[string]string)
+}
+
+func (s *fakeRedisServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) > 1 {
+			switch args[1] {
+			case dropKey:
+				return
+			case holdKey:
+				s.held <- struct{}{}
+				<-s.gate
+			}
+		}
+
+		var reply string
+		s.mu.Lock()
+		switch strings.ToUpper(args[0]) {
+		case "HELLO":
+			reply = "-ERR unknown command 'HELLO'\r\n"
+		case "PING":
+			reply = "+PONG\r\n"
+		case "GET":
+			if value, ok := s.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case "SET":
+			s.data[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case "DEL":
+			reply = ":0\r\n"
+			if _, ok := s.data[args[1]]; ok {
+				delete(s.data, args[1])
+				reply = ":1\r\n"
+			}
+		default:
+			reply = "+OK\r\n"
+		}
+		s.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand 读取一条RESP数组命令
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+// reconnectRecorder 记录重连尝试
+type reconnectRecorder struct {
+	mu       sync.Mutex
+	attempts []bool
+}
+
+func (r *reconnectRecorder) RecordReconnectAttempt(success bool, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, success)
+}
+
+func (r *reconnectRecorder) snapshot() []bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]bool(nil), r.attempts...)
+}
+
+// ReconnectTestSuite 自动重连测试套件
+type ReconnectTestSuite struct {
+	suite.Suite
+}
+
+// TestBackoff 测试指数退避
+func (suite *ReconnectTestSuite) TestBackoff() {
+	policy := middleware.ReconnectPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+
+	suite.Equal(100*time.Millisecond, policy.Backoff(1))
+	suite.Equal(200*time.Millisecond, policy.Backoff(2))
+	suite.Equal(400*time.Millisecond, policy.Backoff(3))
+	suite.Equal(time.Second, policy.Backoff(5), "Backoff should be capped at MaxBackoff")
+	suite.Equal(time.Second, policy.Backoff(50))
+
+	policy.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(2)
+		suite.GreaterOrEqual(backoff, 160*time.Millisecond)
+		suite.LessOrEqual(backoff, 240*time.Millisecond)
+	}
+}
+
+// TestDefaultPolicy 测试默认策略
+func (suite *ReconnectTestSuite) TestDefaultPolicy() {
+	policy := middleware.DefaultReconnectPolicy()
+	suite.True(policy.Enabled())
+	suite.False(middleware.ReconnectPolicy{}.Enabled())
+}
+
+// TestIsConnectionError 测试连接错误判断
+func (suite *ReconnectTestSuite) TestIsConnectionError() {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	timeout := &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}
+
+	suite.True(middleware.IsConnectionError(refused))
+	suite.True(middleware.IsConnectionError(fmt.Errorf("failed to produce message: %w", refused)))
+	suite.True(middleware.IsConnectionError(io.EOF))
+	suite.True(middleware.IsConnectionError(syscall.ECONNRESET))
+	suite.False(middleware.IsConnectionError(nil))
+	suite.False(middleware.IsConnectionError(timeout), "Timeouts should not trigger reconnect")
+	suite.False(middleware.IsConnectionError(context.DeadlineExceeded))
+	suite.False(middleware.IsConnectionError(errors.New("WRONGTYPE")))
+}
+
+// TestRedisReconnectAfterRestart 测试服务重启后Redis客户端自动重连
+func (suite *ReconnectTestSuite) TestRedisReconnectAfterRestart() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer server.stop()
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	client := middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:    host,
+		Port:    port,
+		Timeout: time.Second,
+		Reconnect: &middleware.ReconnectPolicy{
+			MaxAttempts:    50,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+			Multiplier:     2,
+		},
+	})
+	recorder := &reconnectRecorder{}
+	client.SetReconnectObserver(recorder)
+
+	ctx := context.Background()
+	suite.Require().NoError(client.Connect(ctx))
+	defer client.Disconnect(ctx)
+
+	op := &middleware.RedisSetOperation{OpKey: "k", OpValue: []byte("v")}
+	result, err := client.Execute(ctx, op)
+	suite.Require().NoError(err)
+	suite.True(result.Success)
+
+	// 服务宕机：操作失败并触发后台重连
+	server.stop()
+	result, err = client.Execute(ctx, op)
+	suite.Error(err)
+	suite.False(result.Success)
+
+	suite.Eventually(func() bool {
+		attempts := recorder.snapshot()
+		return len(attempts) >= 2
+	}, 2*time.Second, 5*time.Millisecond, "Reconnect should be attempted while the server is down")
+	suite.NotContains(recorder.snapshot(), true)
+
+	// 服务恢复：重连成功，操作恢复正常
+	suite.Require().NoError(server.start(server.addr))
+	suite.Eventually(func() bool {
+		attempts := recorder.snapshot()
+		return len(attempts) > 0 && attempts[len(attempts)-1]
+	}, 2*time.Second, 5*time.Millisecond, "Reconnect should succeed after restart")
+
+	result, err = client.Execute(ctx, op)
+	suite.NoError(err)
+	suite.True(result.Success)
+
+	metrics := client.GetMetrics()
+	suite.Equal(int64(len(recorder.snapshot())), metrics.ReconnectAttempts)
+	suite.Equal(int64(1), metrics.SuccessfulReconnects)
+}
+
+// TestRedisReconnectKeepsInFlightOperations 测试重连替换连接时，仍在使用旧连接的操作正常完成
+func (suite *ReconnectTestSuite) TestRedisReconnectKeepsInFlightOperations() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+	server.gate, server.held = make(chan struct{}), make(chan struct{}, 1)
+	defer server.stop()
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	client := middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:    host,
+		Port:    port,
+		Timeout: time.Second,
+		Reconnect: &middleware.ReconnectPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+			Multiplier:     2,
+		},
+	})
+	recorder := &reconnectRecorder{}
+	client.SetReconnectObserver(recorder)
+
+	ctx := context.Background()
+	suite.Require().NoError(client.Connect(ctx))
+	defer client.Disconnect(ctx)
+
+	// 一个操作在旧连接上等待回复
+	done := make(chan error, 1)
+	go func() {
+		result, err := client.Execute(ctx, &middleware.RedisGetOperation{OpKey: holdKey})
+		if err == nil && !result.Success {
+			err = result.Error
+		}
+		done <- err
+	}()
+	<-server.held
+
+	// 另一个操作遇到断开的连接，触发重连并替换连接
+	_, err = client.Execute(ctx, &middleware.RedisSetOperation{OpKey: dropKey, OpValue: []byte("v")})
+	suite.Require().True(middleware.IsConnectionError(err), "unexpected error: %v", err)
+	suite.Eventually(func() bool {
+		attempts := recorder.snapshot()
+		return len(attempts) > 0 && attempts[len(attempts)-1]
+	}, 2*time.Second, 5*time.Millisecond, "Reconnect should succeed")
+
+	close(server.gate)
+	suite.NoError(<-done, "In-flight operations must not fail when the old connection is replaced")
+
+	result, err := client.Execute(ctx, &middleware.RedisSetOperation{OpKey: "k", OpValue: []byte("v")})
+	suite.NoError(err)
+	suite.True(result.Success)
+}
+
+// TestRedisReconnectGivesUp 测试用尽尝试次数后停止重连
+func (suite *ReconnectTestSuite) TestRedisReconnectGivesUp() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	client := middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:    host,
+		Port:    port,
+		Timeout: time.Second,
+		Reconnect: &middleware.ReconnectPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+		},
+	})
+	recorder := &reconnectRecorder{}
+	client.SetReconnectObserver(recorder)
+
+	ctx := context.Background()
+	suite.Require().NoError(client.Connect(ctx))
+	defer client.Disconnect(ctx)
+	server.stop()
+
+	op := &middleware.RedisGetOperation{OpKey: "k"}
+	_, _ = client.Execute(ctx, op)
+	suite.Eventually(func() bool {
+		return len(recorder.snapshot()) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	// 用尽后后续失败不再触发新一轮重连
+	_, _ = client.Execute(ctx, op)
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal([]bool{false, false, false}, recorder.snapshot())
+}
+
+// TestRedisReconnectAfterExhaustion 测试用尽尝试次数后服务恢复，再次中断时仍会自动重连
+func (suite *ReconnectTestSuite) TestRedisReconnectAfterExhaustion() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer server.stop()
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	client := middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:    host,
+		Port:    port,
+		Timeout: time.Second,
+		Reconnect: &middleware.ReconnectPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+		},
+	})
+	recorder := &reconnectRecorder{}
+	client.SetReconnectObserver(recorder)
+
+	ctx := context.Background()
+	suite.Require().NoError(client.Connect(ctx))
+	defer client.Disconnect(ctx)
+
+	// 第一次中断持续到重连用尽
+	server.stop()
+	op := &middleware.RedisSetOperation{OpKey: "k", OpValue: []byte("v")}
+	_, _ = client.Execute(ctx, op)
+	suite.Eventually(func() bool {
+		return len(recorder.snapshot()) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	// 服务恢复：连接池自行重建连接，操作成功后重新允许自动重连
+	suite.Require().NoError(server.start(server.addr))
+	suite.Eventually(func() bool {
+		result, err := client.Execute(ctx, op)
+		return err == nil && result.Success
+	}, 2*time.Second, 5*time.Millisecond)
+	suite.Equal([]bool{false, false, false}, recorder.snapshot())
+
+	// 再次中断：启动新一轮重连，服务恢复后重连成功
+	server.stop()
+	_, err = client.Execute(ctx, op)
+	suite.Require().Error(err)
+	suite.Eventually(func() bool {
+		return len(recorder.snapshot()) > 3
+	}, time.Second, 5*time.Millisecond, "Reconnect should resume after the service recovered")
+
+	suite.Require().NoError(server.start(server.addr))
+	suite.Eventually(func() bool {
+		attempts := recorder.snapshot()
+		return attempts[len(attempts)-1]
+	}, 2*time.Second, 5*time.Millisecond, "Reconnect should succeed after the second outage")
+	suite.Equal(int64(1), client.GetMetrics().SuccessfulReconnects)
+}
+
+// TestRedisReconnectDisabled 测试禁用自动重连
+func (suite *ReconnectTestSuite) TestRedisReconnectDisabled() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	client := middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:      host,
+		Port:      port,
+		Timeout:   time.Second,
+		Reconnect: &middleware.ReconnectPolicy{},
+	})
+	recorder := &reconnectRecorder{}
+	client.SetReconnectObserver(recorder)
+
+	ctx := context.Background()
+	suite.Require().NoError(client.Connect(ctx))
+	defer client.Disconnect(ctx)
+	server.stop()
+
+	_, err = client.Execute(ctx, &middleware.RedisGetOperation{OpKey: "k"})
+	suite.Error(err)
+	time.Sleep(50 * time.Millisecond)
+	suite.Empty(recorder.snapshot())
+}
+
+// timeoutError 模拟超时的网络错误
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// TestReconnectTestSuite 运行测试套件
+func TestReconnectTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconnectTestSuite))
+}
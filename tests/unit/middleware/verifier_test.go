@@ -0,0 +1,178 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+)
+
+// VerifierTestSuite 读后写一致性校验测试套件
+type VerifierTestSuite struct {
+	suite.Suite
+	server *fakeRedisServer
+	client *middleware.RedisClient
+	ctx    context.Context
+}
+
+// SetupTest 每个测试前启动服务端并连接启用校验的客户端
+func (suite *VerifierTestSuite) SetupTest() {
+	server, err := newFakeRedisServer("127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.server = server
+
+	host, portStr, _ := net.SplitHostPort(server.addr)
+	port, _ := strconv.Atoi(portStr)
+	suite.client = middleware.NewRedisClient(&middleware.RedisConfig{
+		Host:      host,
+		Port:      port,
+		Timeout:   time.Second,
+		Reconnect: &middleware.ReconnectPolicy{},
+		Verify:    true,
+	})
+	suite.ctx = context.Background()
+	suite.Require().NoError(suite.client.Connect(suite.ctx))
+}
+
+// TearDownTest 每个测试后断开连接并停止服务端
+func (suite *VerifierTestSuite) TearDownTest() {
+	_ = suite.client.Disconnect(suite.ctx)
+	suite.server.stop()
+}
+
+func (suite *VerifierTestSuite) set(key, value string) {
+	result, err := suite.client.Execute(suite.ctx, &middleware.RedisSetOperation{OpKey: key, OpValue: []byte(value)})
+	suite.Require().NoError(err)
+	suite.Require().True(result.Success)
+}
+
+func (suite *VerifierTestSuite) get(key string) (*core.Result, error) {
+	return suite.client.Execute(suite.ctx, &middleware.RedisGetOperation{OpKey: key})
+}
+
+// TestConsistentReads 测试读到最新写入的值
+func (suite *VerifierTestSuite) TestConsistentReads() {
+	suite.set("k", "v1")
+	suite.set("k", "v2")
+
+	result, err := suite.get("k")
+	suite.NoError(err)
+	suite.True(result.Success)
+	suite.Equal([]byte("v2"), result.Data)
+
+	_, err = suite.client.Execute(suite.ctx, &middleware.RedisDeleteOperation{OpKey: "k"})
+	suite.Require().NoError(err)
+	_, err = suite.get("k")
+	suite.NoError(err, "Reading a deleted key should be consistent")
+
+	report := suite.client.ConsistencyReport()
+	suite.Require().NotNil(report)
+	suite.Equal(int64(2), report.AckedWrites)
+	suite.Equal(int64(2), report.VerifiedReads)
+	suite.Equal(int64(2), report.ConsistentReads)
+	suite.Zero(report.Inconsistencies())
+}
+
+// TestUnverifiedKeys 测试未写入过的键不参与校验
+func (suite *VerifierTestSuite) TestUnverifiedKeys() {
+	suite.server.put("preexisting", "x")
+
+	_, err := suite.get("preexisting")
+	suite.NoError(err)
+	_, err = suite.get("missing")
+	suite.NoError(err)
+
+	suite.Zero(suite.client.ConsistencyReport().VerifiedReads)
+}
+
+// TestLostWrite 测试已确认写入丢失（如未持久化的重启）
+func (suite *VerifierTestSuite) TestLostWrite() {
+	suite.set("k", "v1")
+	suite.server.flush()
+
+	result, err := suite.get("k")
+	suite.Error(err)
+	suite.True(errors.Is(err, core.ErrDataInconsistent))
+	suite.False(result.Success)
+	suite.Equal(core.ErrorTypeDataLoss, middleware.ClassifyError(err))
+
+	// 同一写入的重复读取只计一次丢失
+	_, _ = suite.get("k")
+	report := suite.client.ConsistencyReport()
+	suite.Equal(int64(1), report.LostWrites)
+	suite.Equal(int64(2), report.VerifiedReads)
+	suite.Zero(report.ConsistentReads)
+
+	metrics := &core.StabilityMetrics{}
+	report.Apply(metrics)
+	suite.Equal(1.0, metrics.DataLossRate)
+	suite.Zero(metrics.DataConsistency)
+	suite.Equal(report, metrics.Consistency)
+}
+
+// TestStaleRead 测试读到已被覆盖的旧值（如故障切换到落后的副本）
+func (suite *VerifierTestSuite) TestStaleRead() {
+	suite.set("k", "v1")
+	suite.set("k", "v2")
+	suite.server.put("k", "v1")
+
+	_, err := suite.get("k")
+	suite.True(errors.Is(err, core.ErrDataInconsistent))
+
+	report := suite.client.ConsistencyReport()
+	suite.Equal(int64(1), report.StaleReads)
+	suite.Zero(report.LostWrites)
+	suite.Zero(report.CorruptedReads)
+}
+
+// TestCorruptedRead 测试读到从未写入过的值
+func (suite *VerifierTestSuite) TestCorruptedRead() {
+	suite.set("k", "v1")
+	suite.server.put("k", "garbage")
+
+	_, err := suite.get("k")
+	suite.True(errors.Is(err, core.ErrDataInconsistent))
+	suite.Equal(int64(1), suite.client.ConsistencyReport().CorruptedReads)
+}
+
+// TestFailedWriteMayApply 测试结果未知的写入视为可能已生效
+func (suite *VerifierTestSuite) TestFailedWriteMayApply() {
+	suite.set("k", "v1")
+
+	// 服务端已写入但客户端未收到确认：两个值都是合法的读取结果
+	suite.server.stop()
+	_, err := suite.client.Execute(suite.ctx, &middleware.RedisSetOperation{OpKey: "k", OpValue: []byte("v2")})
+	suite.Require().Error(err)
+	suite.server.put("k", "v2")
+	suite.Require().NoError(suite.server.start(suite.server.addr))
+	suite.Require().NoError(suite.client.Connect(suite.ctx))
+
+	_, err = suite.get("k")
+	suite.NoError(err)
+
+	report := suite.client.ConsistencyReport()
+	suite.Equal(int64(1), report.AckedWrites)
+	suite.Equal(int64(1), report.ConsistentReads)
+}
+
+// TestVerifyDisabled 测试未启用校验时不返回报告
+func (suite *VerifierTestSuite) TestVerifyDisabled() {
+	client := middleware.NewRedisClient(&middleware.RedisConfig{Host: "127.0.0.1", Port: 6379})
+	suite.Nil(client.ConsistencyReport())
+
+	metrics := &core.StabilityMetrics{}
+	(&core.ConsistencyReport{}).Apply(metrics)
+	suite.Equal(1.0, metrics.DataConsistency, "No verified reads should count as fully consistent")
+	suite.Zero(metrics.DataLossRate)
+}
+
+// TestVerifierTestSuite 运行测试套件
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
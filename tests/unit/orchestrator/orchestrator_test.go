@@ -0,0 +1,431 @@
+package orchestrator_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/collector"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/orchestrator"
+	"middleware-chaos-testing/internal/workload"
+)
+
+// fakeClient 本地替身客户端，可配置操作延迟和连接错误
+type fakeClient struct {
+	latency    time.Duration
+	connectErr error
+
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+	executed    atomic.Int64
+	connected   atomic.Bool
+}
+
+func (f *fakeClient) Connect(ctx context.Context) error {
+	if f.connectErr != nil {
+		return f.connectErr
+	}
+	f.connected.Store(true)
+	return nil
+}
+
+func (f *fakeClient) Disconnect(ctx context.Context) error {
+	f.connected.Store(false)
+	return nil
+}
+
+func (f *fakeClient) Execute(ctx context.Context, op core.Operation) (*core.Result, error) {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		max := f.maxInFlight.Load()
+		if n <= max || f.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	start := time.Now()
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	f.executed.Add(1)
+	return core.NewResult(true, time.Since(start), nil), nil
+}
+
+func (f *fakeClient) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeClient) GetMetrics() *core.ClientMetrics {
+	return &core.ClientMetrics{}
+}
+
+// reconnectingClient 每次连接时上报一次失败和一次成功的重连尝试
+type reconnectingClient struct {
+	fakeClient
+	observer core.ReconnectObserver
+}
+
+func (c *reconnectingClient) SetReconnectObserver(observer core.ReconnectObserver) {
+	c.observer = observer
+}
+
+func (c *reconnectingClient) Connect(ctx context.Context) error {
+	if c.observer != nil {
+		c.observer.RecordReconnectAttempt(false, time.Millisecond)
+		c.observer.RecordReconnectAttempt(true, time.Millisecond)
+	}
+	return c.fakeClient.Connect(ctx)
+}
+
+// testConfig 测试用配置
+type testConfig struct {
+	test *core.TestConfig
+}
+
+func (c *testConfig) GetMiddlewareType() string                   { return "fake" }
+func (c *testConfig) GetConnectionConfig() *core.ConnectionConfig { return &core.ConnectionConfig{} }
+func (c *testConfig) GetTestConfig() *core.TestConfig             { return c.test }
+func (c *testConfig) GetThresholds() *core.Thresholds             { return nil }
+func (c *testConfig) GetOutputConfig() *core.OutputConfig         { return nil }
+func (c *testConfig) Validate() error                             { return nil }
+
+// fakeOperation 测试用操作
+type fakeOperation struct{}
+
+func (f *fakeOperation) Type() core.OperationType         { return core.OpTypeWrite }
+func (f *fakeOperation) Key() string                      { return "key" }
+func (f *fakeOperation) Value() []byte                    { return nil }
+func (f *fakeOperation) Metadata() map[string]interface{} { return nil }
+
+var fakeGenerator = orchestrator.OperationGeneratorFunc(func(seq int64) core.Operation {
+	return &fakeOperation{}
+})
+
+// OrchestratorTestSuite 测试编排器测试套件
+type OrchestratorTestSuite struct {
+	suite.Suite
+	client *fakeClient
+	orch   *orchestrator.Orchestrator
+}
+
+// SetupTest 每个测试前执行
+func (suite *OrchestratorTestSuite) SetupTest() {
+	suite.client = &fakeClient{}
+	suite.orch = orchestrator.NewOrchestrator(suite.client, collector.NewMetricsCollector(), fakeGenerator)
+}
+
+// TestImplementsInterface 测试实现了core.Orchestrator接口
+func (suite *OrchestratorTestSuite) TestImplementsInterface() {
+	var _ core.Orchestrator = suite.orch
+}
+
+// TestRun_CompletesOperations 测试按操作数完成
+func (suite *OrchestratorTestSuite) TestRun_CompletesOperations() {
+	cfg := &testConfig{test: &core.TestConfig{Operations: 200, Concurrency: 4}}
+
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.Equal(int64(200), metrics.TotalOperations)
+	suite.Equal(int64(200), metrics.SuccessfulOperations)
+	suite.Equal(int64(1), metrics.TotalConnectionAttempts)
+	suite.False(suite.client.connected.Load(), "Client should be disconnected after run")
+	suite.Require().Contains(metrics.Operations, "write", "Unnamed operations should be grouped by type")
+	suite.Equal(int64(200), metrics.Operations["write"].Operations)
+
+	status := suite.orch.GetStatus()
+	suite.Equal(orchestrator.StateCompleted, status.State)
+	suite.Equal(1.0, status.Progress)
+	suite.Equal(int64(200), status.Operations)
+}
+
+// TestRun_UsesWorkerPool 测试并发worker池
+func (suite *OrchestratorTestSuite) TestRun_UsesWorkerPool() {
+	suite.client.latency = 5 * time.Millisecond
+	cfg := &testConfig{test: &core.TestConfig{Operations: 80, Concurrency: 8}}
+
+	_, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.Greater(suite.client.maxInFlight.Load(), int64(1), "Operations should run concurrently")
+	suite.LessOrEqual(suite.client.maxInFlight.Load(), int64(8), "Concurrency should be bounded")
+}
+
+// TestRun_StopsAtDuration 测试按时长结束
+func (suite *OrchestratorTestSuite) TestRun_StopsAtDuration() {
+	cfg := &testConfig{test: &core.TestConfig{
+		Duration:    200 * time.Millisecond,
+		Operations:  1000000,
+		Concurrency: 2,
+	}}
+
+	start := time.Now()
+	_, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.Less(time.Since(start), 2*time.Second, "Run should end near the configured duration")
+	suite.Equal(orchestrator.StateCompleted, suite.orch.GetStatus().State)
+}
+
+// TestRun_OpenLoopRate 测试开环模式按固定速率发送
+func (suite *OrchestratorTestSuite) TestRun_OpenLoopRate() {
+	cfg := &testConfig{test: &core.TestConfig{
+		Duration:    300 * time.Millisecond,
+		Rate:        200,
+		Concurrency: 4,
+	}}
+
+	start := time.Now()
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.InDelta(60, metrics.TotalOperations, 2, "Operations should follow rate * duration")
+	suite.GreaterOrEqual(time.Since(start), 290*time.Millisecond, "Operations should be spread over the duration")
+	suite.Equal(200.0, metrics.TargetRate)
+	suite.Less(metrics.LateOperations, metrics.TotalOperations/2, "Idle workers should keep up with the schedule")
+}
+
+// TestRun_OpenLoopCoordinatedOmission 测试服务端变慢时延迟包含排队时间
+func (suite *OrchestratorTestSuite) TestRun_OpenLoopCoordinatedOmission() {
+	// 单个worker每20ms完成一次，计划每2ms发送一次，操作越来越落后计划
+	suite.client.latency = 20 * time.Millisecond
+	cfg := &testConfig{test: &core.TestConfig{
+		Operations:  10,
+		Rate:        500,
+		Concurrency: 1,
+	}}
+
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.Equal(int64(10), metrics.TotalOperations)
+	suite.Greater(metrics.LateOperations, int64(5))
+	suite.GreaterOrEqual(metrics.MaxScheduleLag, 150*time.Millisecond)
+	suite.GreaterOrEqual(metrics.MaxLatency, 170*time.Millisecond,
+		"Latency should be measured from the intended start time")
+	suite.GreaterOrEqual(metrics.P50Latency, 80*time.Millisecond)
+}
+
+// TestRun_RateProfile 测试按速率曲线发送，并在时间序列中记录目标速率
+func (suite *OrchestratorTestSuite) TestRun_RateProfile() {
+	coll := collector.NewMetricsCollector()
+	coll.SetSeriesInterval(100 * time.Millisecond)
+	suite.orch = orchestrator.NewOrchestrator(suite.client, coll, fakeGenerator)
+
+	// 前200ms为100 ops/s，之后为400 ops/s
+	profile, err := workload.NewRateProfile(400, &core.LoadProfile{
+		Shape: core.ProfileStep, StartRate: 100, StepRate: 300, StepTime: 200 * time.Millisecond,
+	}, 400*time.Millisecond)
+	suite.Require().NoError(err)
+	suite.orch.SetRateSchedule(profile)
+
+	cfg := &testConfig{test: &core.TestConfig{Duration: 400 * time.Millisecond, Rate: 400, Concurrency: 4}}
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.InDelta(100, metrics.TotalOperations, 3)
+	suite.InDelta(250, metrics.TargetRate, 10)
+	suite.Require().GreaterOrEqual(len(metrics.Series), 4)
+	for i, want := range []float64{100, 100, 400, 400} {
+		suite.InDelta(want, metrics.Series[i].TargetRate, 20, "interval %d", i)
+	}
+	early := metrics.Series[0].Operations + metrics.Series[1].Operations
+	late := metrics.Series[2].Operations + metrics.Series[3].Operations
+	suite.Greater(late, 2*early, "Throughput should follow the profile")
+}
+
+// TestRun_OpenLoopPause 测试开环模式暂停期间不计划发送，目标速率为0
+func (suite *OrchestratorTestSuite) TestRun_OpenLoopPause() {
+	coll := collector.NewMetricsCollector()
+	coll.SetSeriesInterval(100 * time.Millisecond)
+	suite.orch = orchestrator.NewOrchestrator(suite.client, coll, fakeGenerator)
+	cfg := &testConfig{test: &core.TestConfig{Duration: 400 * time.Millisecond, Rate: 200, Concurrency: 2}}
+
+	var metrics *core.StabilityMetrics
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		metrics, err = suite.orch.Run(context.Background(), cfg)
+		done <- err
+	}()
+
+	time.Sleep(150 * time.Millisecond)
+	suite.Require().NoError(suite.orch.Pause())
+	time.Sleep(300 * time.Millisecond)
+	paused := suite.orch.GetStatus().Operations
+	suite.Require().NoError(suite.orch.Resume())
+	suite.Require().NoError(<-done)
+
+	suite.InDelta(80, metrics.TotalOperations, 4, "Paused time should not count towards the schedule")
+	suite.Less(paused, metrics.TotalOperations)
+	suite.InDelta(200, metrics.TargetRate, 10)
+	suite.Require().GreaterOrEqual(len(metrics.Series), 7)
+	suite.InDelta(200, metrics.Series[0].TargetRate, 20)
+	suite.InDelta(0, metrics.Series[3].TargetRate, 20, "No operations are scheduled while paused")
+	suite.Zero(metrics.Series[3].Operations)
+	suite.InDelta(200, metrics.Series[6].TargetRate, 20)
+}
+
+// TestRun_ClosedLoopHasNoSchedule 测试闭环模式不统计调度落后
+func (suite *OrchestratorTestSuite) TestRun_ClosedLoopHasNoSchedule() {
+	suite.client.latency = 5 * time.Millisecond
+	cfg := &testConfig{test: &core.TestConfig{Operations: 10, Concurrency: 1}}
+
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	suite.Zero(metrics.TargetRate)
+	suite.Zero(metrics.LateOperations)
+	suite.Less(metrics.MaxLatency, 100*time.Millisecond)
+}
+
+// TestRun_ConnectFailure 测试连接失败
+func (suite *OrchestratorTestSuite) TestRun_ConnectFailure() {
+	suite.client.connectErr = core.ErrConnectionFailed
+	cfg := &testConfig{test: &core.TestConfig{Operations: 10}}
+
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Error(err)
+	suite.True(errors.Is(err, core.ErrConnectionFailed))
+	suite.Nil(metrics)
+	suite.Equal(orchestrator.StateStopped, suite.orch.GetStatus().State)
+}
+
+// TestRun_InvalidConfig 测试无效配置
+func (suite *OrchestratorTestSuite) TestRun_InvalidConfig() {
+	_, err := suite.orch.Run(context.Background(), &testConfig{test: &core.TestConfig{}})
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+}
+
+// TestPauseResumeStop 测试从其他goroutine暂停、恢复和停止
+func (suite *OrchestratorTestSuite) TestPauseResumeStop() {
+	cfg := &testConfig{test: &core.TestConfig{
+		Duration:    10 * time.Second,
+		Operations:  10000,
+		Concurrency: 2,
+	}}
+
+	var wg sync.WaitGroup
+	var runErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, runErr = suite.orch.Run(context.Background(), cfg)
+	}()
+
+	suite.Eventually(func() bool {
+		return suite.orch.GetStatus().Operations > 0
+	}, time.Second, 5*time.Millisecond)
+
+	suite.NoError(suite.orch.Pause())
+	suite.Equal(orchestrator.StatePaused, suite.orch.GetStatus().State)
+	suite.ErrorIs(suite.orch.Pause(), orchestrator.ErrNotRunning)
+
+	// 暂停期间操作数和运行时长不再增长
+	time.Sleep(20 * time.Millisecond)
+	paused := suite.orch.GetStatus().Operations
+	elapsed := suite.orch.Elapsed()
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(paused, suite.orch.GetStatus().Operations)
+	suite.Equal(elapsed, suite.orch.Elapsed())
+
+	suite.NoError(suite.orch.Resume())
+	suite.ErrorIs(suite.orch.Resume(), orchestrator.ErrNotPaused)
+	suite.Eventually(func() bool {
+		return suite.orch.GetStatus().Operations > paused
+	}, time.Second, 5*time.Millisecond)
+
+	suite.NoError(suite.orch.Stop())
+	wg.Wait()
+
+	suite.NoError(runErr)
+	status := suite.orch.GetStatus()
+	suite.Equal(orchestrator.StateStopped, status.State)
+	suite.Less(status.Progress, 1.0)
+	suite.Greater(status.ElapsedTime, time.Duration(0))
+	suite.ErrorIs(suite.orch.Stop(), orchestrator.ErrNotRunning)
+}
+
+// TestRun_Scenario 测试场景与负载并行执行，事件附加到指标
+func (suite *OrchestratorTestSuite) TestRun_Scenario() {
+	var phases []string
+	var mu sync.Mutex
+	suite.orch.SetScenario(scenarioFunc(func(ctx context.Context, record func(core.Event)) error {
+		for _, name := range []string{"baseline", "fault"} {
+			record(core.Event{Type: core.EventPhaseStart, Phase: name})
+			mu.Lock()
+			phases = append(phases, suite.orch.GetStatus().Phase)
+			mu.Unlock()
+			select {
+			case <-time.After(50 * time.Millisecond):
+			case <-ctx.Done():
+			}
+			record(core.Event{Type: core.EventPhaseEnd, Phase: name})
+		}
+		<-ctx.Done()
+		return nil
+	}))
+
+	cfg := &testConfig{test: &core.TestConfig{Duration: 300 * time.Millisecond, Concurrency: 1}}
+	metrics, err := suite.orch.Run(context.Background(), cfg)
+	suite.Require().NoError(err)
+
+	mu.Lock()
+	suite.Equal([]string{"baseline", "fault"}, phases)
+	mu.Unlock()
+	suite.Empty(suite.orch.GetStatus().Phase)
+
+	suite.Require().Len(metrics.Events, 4)
+	suite.Equal(core.EventPhaseStart, metrics.Events[0].Type)
+	suite.Equal("fault", metrics.Events[2].Phase)
+	for i, e := range metrics.Events {
+		suite.False(e.Time.IsZero())
+		if i > 0 {
+			suite.GreaterOrEqual(e.Offset, metrics.Events[i-1].Offset)
+		}
+	}
+	suite.GreaterOrEqual(metrics.Events[2].Offset, 50*time.Millisecond)
+}
+
+// TestRun_ScenarioError 测试场景失败时返回错误和已收集的指标
+func (suite *OrchestratorTestSuite) TestRun_ScenarioError() {
+	suite.orch.SetScenario(scenarioFunc(func(ctx context.Context, record func(core.Event)) error {
+		return errors.New("injector unavailable")
+	}))
+
+	metrics, err := suite.orch.Run(context.Background(), &testConfig{test: &core.TestConfig{Operations: 10}})
+	suite.Error(err)
+	suite.NotNil(metrics)
+	suite.Equal(int64(10), metrics.TotalOperations)
+}
+
+// scenarioFunc 函数形式的场景
+type scenarioFunc func(ctx context.Context, record func(core.Event)) error
+
+func (f scenarioFunc) Run(ctx context.Context, record func(core.Event)) error {
+	return f(ctx, record)
+}
+
+// TestRun_RecordsReconnects 测试客户端的重连尝试记入指标
+func (suite *OrchestratorTestSuite) TestRun_RecordsReconnects() {
+	client := &reconnectingClient{}
+	orch := orchestrator.NewOrchestrator(client, collector.NewMetricsCollector(), fakeGenerator)
+
+	metrics, err := orch.Run(context.Background(), &testConfig{test: &core.TestConfig{Operations: 10}})
+	suite.Require().NoError(err)
+
+	suite.Equal(int64(2), metrics.TotalReconnectAttempts)
+	suite.Equal(int64(1), metrics.SuccessfulReconnects)
+	suite.Equal(0.5, metrics.ReconnectSuccessRate)
+}
+
+// TestOrchestratorTestSuite 运行测试套件
+func TestOrchestratorTestSuite(t *testing.T) {
+	suite.Run(t, new(OrchestratorTestSuite))
+}
@@ -0,0 +1,128 @@
+package reporter_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// fakeSource 固定的运行状态和指标
+type fakeSource struct {
+	status  core.OrchestratorStatus
+	metrics core.StabilityMetrics
+}
+
+func (f *fakeSource) GetStatus() *core.OrchestratorStatus {
+	status := f.status
+	return &status
+}
+
+func (f *fakeSource) GetMetrics() *core.StabilityMetrics {
+	return f.metrics.Clone()
+}
+
+// DashboardTestSuite 实时进度面板测试套件
+type DashboardTestSuite struct {
+	suite.Suite
+	source *fakeSource
+	out    *strings.Builder
+}
+
+// SetupTest 每个测试前执行
+func (suite *DashboardTestSuite) SetupTest() {
+	suite.out = &strings.Builder{}
+	suite.source = &fakeSource{
+		status: core.OrchestratorStatus{
+			State:       "running",
+			Progress:    0.5,
+			ElapsedTime: 30 * time.Second,
+			Phase:       "latency",
+		},
+		metrics: core.StabilityMetrics{
+			TotalOperations: 1000,
+			Availability:    0.99,
+			Throughput:      33,
+			ErrorsByType:    map[core.ErrorType]int64{core.ErrorTypeTimeout: 8, core.ErrorTypeNetwork: 2},
+			Series: []core.IntervalMetrics{
+				{Duration: time.Second, P50Latency: time.Millisecond, P95Latency: 5 * time.Millisecond,
+					P99Latency: 12 * time.Millisecond, MaxLatency: 20 * time.Millisecond},
+				{Duration: time.Second},
+			},
+		},
+	}
+}
+
+// TestInteractiveFrame 测试交互模式的面板内容和原地刷新
+func (suite *DashboardTestSuite) TestInteractiveFrame() {
+	d := reporter.NewDashboard(suite.out, suite.source, suite.source, true)
+	d.Update(time.Now().Add(time.Second))
+
+	frame := suite.out.String()
+	suite.NotContains(frame, "\033[", "The first frame should not move the cursor")
+	suite.Contains(frame, "运行中")
+	suite.Contains(frame, " 50%")
+	suite.Contains(frame, "操作: 1000")
+	suite.Contains(frame, "成功率: 99.00%")
+	suite.Contains(frame, "P50 1ms / P95 5ms / P99 12ms", "Latency should come from the last complete interval")
+	suite.Contains(frame, "超时 8  网络错误 2")
+	suite.Contains(frame, "阶段: latency")
+	suite.Contains(frame, "吞吐趋势")
+	lines := strings.Count(frame, "\n")
+
+	suite.out.Reset()
+	suite.source.metrics.TotalOperations = 2000
+	d.Update(time.Now().Add(2 * time.Second))
+	suite.True(strings.HasPrefix(suite.out.String(), "\033["), "Later frames should redraw in place")
+	suite.Contains(suite.out.String(), fmt.Sprintf("\033[%dF", lines), "Cursor should move back over the previous frame")
+}
+
+// TestPlainLogLines 测试非交互模式输出单行摘要
+func (suite *DashboardTestSuite) TestPlainLogLines() {
+	d := reporter.NewDashboard(suite.out, suite.source, suite.source, false)
+	d.Update(time.Now().Add(time.Second))
+	suite.source.status.Phase = ""
+	suite.source.metrics.ErrorsByType = nil
+	d.Update(time.Now().Add(2 * time.Second))
+
+	out := suite.out.String()
+	suite.NotContains(out, "\033[")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	suite.Require().Len(lines, 2)
+	suite.Contains(lines[0], "[30s] 运行中 50% | 操作 1000")
+	suite.Contains(lines[0], "P50/P95/P99 1ms/5ms/12ms")
+	suite.Contains(lines[0], "错误 超时 8 网络错误 2 | 阶段 latency")
+	suite.Contains(lines[1], "错误 无")
+	suite.NotContains(lines[1], "阶段")
+}
+
+// TestRunStopsWithFinalUpdate 测试停止时输出最后一次状态
+func (suite *DashboardTestSuite) TestRunStopsWithFinalUpdate() {
+	suite.source.status.State = "completed"
+	suite.source.status.Progress = 1
+	d := reporter.NewDashboard(suite.out, suite.source, suite.source, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Run(ctx)
+	suite.Contains(suite.out.String(), "已完成 100%")
+}
+
+// TestIsTerminal 测试非终端文件
+func (suite *DashboardTestSuite) TestIsTerminal() {
+	f, err := os.CreateTemp(suite.T().TempDir(), "out")
+	suite.Require().NoError(err)
+	defer f.Close()
+	suite.False(reporter.IsTerminal(f))
+}
+
+// TestDashboardTestSuite 运行测试套件
+func TestDashboardTestSuite(t *testing.T) {
+	suite.Run(t, new(DashboardTestSuite))
+}
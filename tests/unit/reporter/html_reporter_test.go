@@ -0,0 +1,167 @@
+package reporter_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// HTMLReporterTestSuite HTML报告测试套件
+type HTMLReporterTestSuite struct {
+	suite.Suite
+	metrics    *core.StabilityMetrics
+	evaluation *core.EvaluationResult
+}
+
+// SetupTest 每个测试前执行：60s测试，+15s到+35s注入延迟，+45s重置连接，+20s起故障8s
+func (suite *HTMLReporterTestSuite) SetupTest() {
+	start := time.Date(2025, 10, 30, 14, 0, 0, 0, time.UTC)
+	suite.metrics = &core.StabilityMetrics{
+		TotalOperations:      5000,
+		SuccessfulOperations: 4952,
+		FailedOperations:     48,
+		Availability:         0.9904,
+		ErrorsByType:         map[core.ErrorType]int64{core.ErrorTypeTimeout: 40, core.ErrorTypeNetwork: 8},
+		StartTime:            start,
+		Duration:             60 * time.Second,
+		SeriesInterval:       time.Second,
+		OutageCount:          1,
+		Outages: []core.Outage{
+			{Start: start.Add(20 * time.Second), Duration: 8 * time.Second, Failures: 48, Recovered: true},
+		},
+		Events: []core.Event{
+			{Offset: 15 * time.Second, Type: core.EventFaultStart, Phase: "latency", Fault: "latency=200ms"},
+			{Offset: 35 * time.Second, Type: core.EventFaultStop, Phase: "latency", Fault: "latency=200ms"},
+			{Offset: 45 * time.Second, Type: core.EventFaultInject, Phase: "reset", Fault: "reset_connections"},
+		},
+	}
+	for i := 0; i < 60; i++ {
+		im := core.IntervalMetrics{
+			Start:      start.Add(time.Duration(i) * time.Second),
+			Duration:   time.Second,
+			Operations: 100,
+			Successes:  100,
+			P50Latency: 2 * time.Millisecond,
+			P95Latency: 5 * time.Millisecond,
+			P99Latency: 9 * time.Millisecond,
+		}
+		if i >= 20 && i < 28 {
+			im.Successes, im.Failures = 94, 6
+			im.ErrorsByType = map[core.ErrorType]int64{core.ErrorTypeTimeout: 5, core.ErrorTypeNetwork: 1}
+		}
+		suite.metrics.Series = append(suite.metrics.Series, im)
+	}
+
+	suite.evaluation = &core.EvaluationResult{
+		Score:       72.5,
+		Grade:       core.GradeFair,
+		Status:      core.StatusWarning,
+		EvaluatedAt: start.Add(time.Minute),
+		Rationale:   "可用性低于预期 <script>alert(1)</script>",
+		Issues: []core.Issue{
+			{Type: "low_availability", Severity: "HIGH", Metric: "availability", Current: 99.04, Expected: 99.9,
+				Message: "可用性 99.04% 低于 99.9%"},
+		},
+		Recommendations: []core.Recommendation{
+			{Priority: "MEDIUM", Category: "CONFIGURATION", Title: "调整超时配置",
+				Actions: []string{"增大客户端超时"}, References: []string{"https://redis.io/docs/"}},
+		},
+	}
+	suite.evaluation.Scores.Availability = 20
+	suite.evaluation.Scores.Performance = 25
+}
+
+// generate 生成报告
+func (suite *HTMLReporterTestSuite) generate() string {
+	var sb strings.Builder
+	suite.Require().NoError(reporter.NewHTMLReporter().GenerateReport(suite.metrics, suite.evaluation, &sb))
+	return sb.String()
+}
+
+// TestSelfContained 测试报告不引用任何外部资源
+func (suite *HTMLReporterTestSuite) TestSelfContained() {
+	out := suite.generate()
+	suite.True(strings.HasPrefix(out, "<!DOCTYPE html>"))
+	for _, ref := range []string{"<script", "<link", "src=", "href=", "@import", "url("} {
+		suite.NotContains(out, ref)
+	}
+	suite.Contains(out, "&lt;script&gt;alert(1)&lt;/script&gt;", "Text from the evaluation should be escaped")
+}
+
+// TestCharts 测试延迟、吞吐量和错误图表
+func (suite *HTMLReporterTestSuite) TestCharts() {
+	out := suite.generate()
+	suite.Equal(3, strings.Count(out, "<svg "))
+	suite.Contains(out, "延迟百分位")
+	suite.Contains(out, "吞吐量 (ops/s)")
+	suite.Contains(out, "错误数（按类型）")
+
+	// 三条延迟百分位折线 + 一条吞吐量折线
+	suite.Equal(4, strings.Count(out, "<path "))
+	suite.NotContains(out, "目标速率", "Closed-loop runs have no target rate line")
+
+	// 8个有错误的区间，每个按类型堆叠两段
+	bars := regexp.MustCompile(`<rect [^>]*><title>\+\d+s (超时|网络错误): \d+</title></rect>`).FindAllString(out, -1)
+	suite.Len(bars, 16)
+	suite.Contains(out, "<title>+20s 超时: 5</title>")
+}
+
+// TestFaultWindows 测试故障区间在每张图表中标出
+func (suite *HTMLReporterTestSuite) TestFaultWindows() {
+	out := suite.generate()
+	// 绘图区横坐标 64-744，60s对应680，+15s到+35s为 x=234.0 宽226.7
+	suite.Equal(3, strings.Count(out, `<rect x="234.0" y="12.0" width="226.7" height="180.0" fill="#f59e0b"`))
+	suite.Equal(3, strings.Count(out, `<title>reset_connections +45s</title></line>`))
+	suite.Equal(3, strings.Count(out, `<rect x="290.7" y="12.0" width="90.7" height="180.0" fill="#ef4444"`))
+	suite.Contains(out, "注入故障")
+	suite.Contains(out, "检测到的故障窗口")
+
+	// 测试结束时仍未结束的故障延续到测试结束
+	suite.metrics.Events = suite.metrics.Events[:1]
+	suite.Equal(3, strings.Count(suite.generate(), `<rect x="234.0" y="12.0" width="510.0" height="180.0" fill="#f59e0b"`))
+}
+
+// TestSections 测试评分、问题和建议
+func (suite *HTMLReporterTestSuite) TestSections() {
+	out := suite.generate()
+	suite.Contains(out, "72.5")
+	suite.Contains(out, `<span class="badge warning">WARNING</span>`)
+	suite.Contains(out, `style="width: 66.7%"`, "Availability 20/30 should fill two thirds of the bar")
+	suite.Contains(out, "</span>超时</td><td class=\"num\">40</td>")
+	suite.Contains(out, `<span class="badge high">HIGH</span> <strong>low_availability</strong>`)
+	suite.Contains(out, "可用性 99.04% 低于 99.9%")
+	suite.Contains(out, "调整超时配置")
+	suite.Contains(out, "<li>增大客户端超时</li>")
+	suite.Contains(out, "https://redis.io/docs/")
+	suite.Contains(out, "reset_connections")
+}
+
+// TestOpenLoopAndEmptySeries 测试开环目标速率和没有时间序列的报告
+func (suite *HTMLReporterTestSuite) TestOpenLoopAndEmptySeries() {
+	suite.metrics.TargetRate = 100
+	for i := range suite.metrics.Series {
+		suite.metrics.Series[i].TargetRate = 100
+	}
+	out := suite.generate()
+	suite.Contains(out, "目标速率")
+	suite.Contains(out, `stroke-dasharray="6 4"`)
+	suite.Contains(out, "开环负载")
+
+	suite.metrics.Series = nil
+	suite.metrics.Events = nil
+	suite.metrics.Outages = nil
+	out = suite.generate()
+	suite.NotContains(out, "<svg ")
+	suite.NotContains(out, "指标趋势")
+	suite.Contains(out, "</html>")
+}
+
+// TestHTMLReporterTestSuite 运行测试套件
+func TestHTMLReporterTestSuite(t *testing.T) {
+	suite.Run(t, new(HTMLReporterTestSuite))
+}
@@ -0,0 +1,165 @@
+package reporter_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// junitDoc 解析JUnit XML的结构
+type junitDoc struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Skipped  int `xml:"skipped,attr"`
+	Suites   []struct {
+		Name       string `xml:"name,attr"`
+		Tests      int    `xml:"tests,attr"`
+		Failures   int    `xml:"failures,attr"`
+		Skipped    int    `xml:"skipped,attr"`
+		Properties []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"value,attr"`
+		} `xml:"properties>property"`
+		TestCases []struct {
+			Name      string `xml:"name,attr"`
+			ClassName string `xml:"classname,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+				Type    string `xml:"type,attr"`
+			} `xml:"failure"`
+			Skipped *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"skipped"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+// JUnitReporterTestSuite JUnit报告测试套件
+type JUnitReporterTestSuite struct {
+	suite.Suite
+	metrics    *core.StabilityMetrics
+	evaluation *core.EvaluationResult
+}
+
+// SetupTest 每个测试前执行
+func (suite *JUnitReporterTestSuite) SetupTest() {
+	suite.metrics = &core.StabilityMetrics{
+		TotalOperations: 10000,
+		Availability:    0.9995,
+		ErrorRate:       0.0005,
+		P95Latency:      12 * time.Millisecond,
+		P99Latency:      40 * time.Millisecond,
+		StartTime:       time.Date(2025, 10, 30, 14, 0, 0, 0, time.UTC),
+		Duration:        30 * time.Second,
+	}
+	suite.evaluation = &core.EvaluationResult{
+		Score:     91.5,
+		Grade:     core.GradeExcellent,
+		Status:    core.StatusPass,
+		Rationale: "系统稳定",
+	}
+	suite.evaluation.Scores.Availability = 27
+}
+
+// generate 生成并解析报告，返回结果 用例名 -> "pass"/"failure:<type>"/"skipped"
+func (suite *JUnitReporterTestSuite) generate() (junitDoc, map[string]string) {
+	var sb strings.Builder
+	suite.Require().NoError(reporter.NewJUnitReporter().GenerateReport(suite.metrics, suite.evaluation, &sb))
+	suite.True(strings.HasPrefix(sb.String(), `<?xml version="1.0" encoding="UTF-8"?>`))
+
+	var doc junitDoc
+	suite.Require().NoError(xml.Unmarshal([]byte(sb.String()), &doc))
+	suite.Require().Len(doc.Suites, 1)
+
+	outcomes := make(map[string]string)
+	for _, tc := range doc.Suites[0].TestCases {
+		switch {
+		case tc.Failure != nil:
+			outcomes[tc.Name] = "failure:" + tc.Failure.Type
+		case tc.Skipped != nil:
+			outcomes[tc.Name] = "skipped"
+		default:
+			outcomes[tc.Name] = "pass"
+		}
+	}
+	return doc, outcomes
+}
+
+// TestPass 测试全部通过
+func (suite *JUnitReporterTestSuite) TestPass() {
+	doc, outcomes := suite.generate()
+	suite.Equal(map[string]string{
+		"overall":        "pass",
+		"availability":   "pass",
+		"p95_latency":    "pass",
+		"p99_latency":    "pass",
+		"error_rate":     "pass",
+		"mttr":           "skipped",
+		"data_loss_rate": "skipped",
+	}, outcomes, "Dimensions without measurements should be skipped")
+	suite.Equal(7, doc.Tests)
+	suite.Zero(doc.Failures)
+	suite.Equal(2, doc.Skipped)
+
+	properties := make(map[string]string)
+	for _, p := range doc.Suites[0].Properties {
+		properties[p.Name] = p.Value
+	}
+	suite.Equal("91.5", properties["score"])
+	suite.Equal("EXCELLENT", properties["grade"])
+	suite.Equal("PASS", properties["status"])
+	suite.Equal("27.0", properties["score.availability"])
+}
+
+// TestFail 测试FAIL状态下所有超出阈值的维度均失败，问题不重复报告
+func (suite *JUnitReporterTestSuite) TestFail() {
+	suite.metrics.OutageCount = 1
+	suite.metrics.MTTR = 2 * time.Second
+	suite.evaluation.Status = core.StatusFail
+	suite.evaluation.Issues = []core.Issue{
+		{Type: "low_availability", Severity: "CRITICAL", Metric: "availability", Message: "可用性过低"},
+		{Type: "high_p95_latency", Severity: "HIGH", Metric: "p95_latency", Message: "P95过高"},
+		{Type: "high_p99_latency", Severity: "MEDIUM", Metric: "p99_latency", Message: "P99过高"},
+	}
+
+	doc, outcomes := suite.generate()
+	suite.Equal("failure:FAIL", outcomes["overall"])
+	suite.Equal("failure:CRITICAL", outcomes["availability"])
+	suite.Equal("failure:HIGH", outcomes["p95_latency"])
+	suite.Equal("failure:MEDIUM", outcomes["p99_latency"], "Any threshold breach fails when the status is FAIL")
+	suite.Equal("pass", outcomes["mttr"], "MTTR is measured once an outage was detected")
+	suite.NotContains(outcomes, "low_availability [availability]", "Threshold issues are reported once")
+	suite.Equal(7, doc.Tests)
+	suite.Equal(4, doc.Failures)
+	suite.Equal(1, doc.Skipped)
+	suite.Equal(doc.Failures, doc.Suites[0].Failures)
+}
+
+// TestWarning 测试WARNING状态下HIGH问题不导致失败
+func (suite *JUnitReporterTestSuite) TestWarning() {
+	suite.metrics.Consistency = &core.ConsistencyReport{}
+	suite.evaluation.Status = core.StatusWarning
+	suite.evaluation.Issues = []core.Issue{
+		{Type: "high_error_rate", Severity: "HIGH", Metric: "error_rate", Message: "错误率<2%>"},
+		{Type: "operation_high_latency", Severity: "MEDIUM", Metric: "operations.set.p95_latency", Message: "set过慢"},
+	}
+
+	doc, outcomes := suite.generate()
+	suite.Equal("skipped", outcomes["overall"])
+	suite.Equal("skipped", outcomes["error_rate"])
+	suite.Equal("pass", outcomes["data_loss_rate"], "Data loss is measured when verification is enabled")
+	suite.NotContains(outcomes, "high_error_rate [error_rate]")
+	suite.Equal("skipped", outcomes["operation_high_latency [operations.set.p95_latency]"])
+	suite.Zero(doc.Failures)
+	suite.Equal(4, doc.Skipped)
+}
+
+// TestJUnitReporterTestSuite 运行测试套件
+func TestJUnitReporterTestSuite(t *testing.T) {
+	suite.Run(t, new(JUnitReporterTestSuite))
+}
@@ -0,0 +1,136 @@
+package reporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/i18n"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// LangTestSuite 报告语言测试套件
+type LangTestSuite struct {
+	suite.Suite
+	metrics    *core.StabilityMetrics
+	evaluation *core.EvaluationResult
+}
+
+// SetupTest 每个测试前执行
+func (suite *LangTestSuite) SetupTest() {
+	start := time.Date(2025, 10, 30, 14, 0, 0, 0, time.UTC)
+	suite.metrics = &core.StabilityMetrics{
+		TotalOperations:      1000,
+		SuccessfulOperations: 990,
+		FailedOperations:     10,
+		Availability:         0.99,
+		ErrorsByType:         map[core.ErrorType]int64{core.ErrorTypeTimeout: 10},
+		StartTime:            start,
+		Duration:             10 * time.Second,
+		OutageCount:          1,
+		Outages:              []core.Outage{{Start: start.Add(2 * time.Second), Duration: time.Second, Failures: 10}},
+		Events: []core.Event{
+			{Offset: 2 * time.Second, Type: core.EventFaultStart, Phase: "outage", Fault: "drop=100%"},
+		},
+		Operations: map[string]*core.OperationMetrics{
+			"get": {Name: "get", Type: "read", Operations: 1000, ErrorsByType: map[core.ErrorType]int64{core.ErrorTypeTimeout: 10}},
+		},
+	}
+	for i := 0; i < 10; i++ {
+		suite.metrics.Series = append(suite.metrics.Series, core.IntervalMetrics{
+			Start: start.Add(time.Duration(i) * time.Second), Duration: time.Second, Operations: 100, Successes: 99, Failures: 1,
+		})
+	}
+	suite.evaluation = &core.EvaluationResult{
+		Score:       80,
+		Grade:       core.GradeGood,
+		Status:      core.StatusPass,
+		EvaluatedAt: start.Add(10 * time.Second),
+		Issues: []core.Issue{
+			{Type: "low_availability", Severity: "HIGH", Metric: "availability", Current: 99, Expected: 99.9},
+		},
+		Recommendations: []core.Recommendation{
+			{Code: "improve_availability", Priority: "HIGH", Category: "CONFIGURATION", Title: "Improve availability"},
+		},
+	}
+}
+
+// generate 按格式和语言生成报告
+func (suite *LangTestSuite) generate(format string, lang i18n.Lang) string {
+	r, err := reporter.NewReporter(format, lang)
+	suite.Require().NoError(err)
+	var buf bytes.Buffer
+	suite.Require().NoError(r.GenerateReport(suite.metrics, suite.evaluation, &buf))
+	return buf.String()
+}
+
+// TestEnglishReports 测试英文报告的标题、段落和显示名称中没有中文
+func (suite *LangTestSuite) TestEnglishReports() {
+	han := regexp.MustCompile(`\p{Han}`)
+	for _, format := range []string{"console", "markdown", "html"} {
+		out := suite.generate(format, i18n.En)
+		suite.Contains(out, "Middleware Stability Test Report", format)
+		suite.Contains(out, "Timeout", format)
+		suite.Contains(out, "Fault start", format)
+		suite.False(han.MatchString(out), "%s report should not contain Chinese: %s", format, han.FindString(out))
+
+		suite.Contains(suite.generate(format, i18n.ZhCN), "中间件稳定性测试报告", format)
+	}
+	suite.Contains(suite.generate("html", i18n.En), `<html lang="en">`)
+	suite.Contains(suite.generate("html", ""), `<html lang="zh-CN">`)
+}
+
+// TestJSONCodes 测试JSON报告记录语言，问题类型和建议代码不随语言变化
+func (suite *LangTestSuite) TestJSONCodes() {
+	var report struct {
+		Lang   string `json:"lang"`
+		Issues []struct {
+			Type string
+		} `json:"issues"`
+		Recommendations []struct {
+			Code  string
+			Title string
+		} `json:"recommendations"`
+	}
+	suite.Require().NoError(json.Unmarshal([]byte(suite.generate("json", i18n.En)), &report))
+	suite.Equal("en", report.Lang)
+	suite.Equal("low_availability", report.Issues[0].Type)
+	suite.Equal("improve_availability", report.Recommendations[0].Code)
+	suite.Equal("Improve availability", report.Recommendations[0].Title)
+
+	suite.Require().NoError(json.Unmarshal([]byte(suite.generate("json", "")), &report))
+	suite.Equal("zh-CN", report.Lang)
+}
+
+// TestTemplate 测试自定义模板的 t 函数和显示名称使用报告语言
+func (suite *LangTestSuite) TestTemplate() {
+	r := reporter.NewMarkdownReporter()
+	r.SetLang(i18n.En)
+	r.SetTemplate(`{{t "report.title"}}|{{.Lang}}|{{range .Scores}}{{.Name}} {{end}}|{{range .Errors}}{{.Label}}{{end}}|{{yesNo true}}`)
+	var buf bytes.Buffer
+	suite.Require().NoError(r.GenerateReport(suite.metrics, suite.evaluation, &buf))
+	suite.Equal("Middleware Stability Test Report|en|Availability Performance Reliability Resilience |Timeout|yes", buf.String())
+}
+
+// TestDashboard 测试实时进度使用报告语言
+func (suite *LangTestSuite) TestDashboard() {
+	source := &fakeSource{
+		status:  core.OrchestratorStatus{State: "paused", Progress: 0.5, ElapsedTime: 5 * time.Second},
+		metrics: *suite.metrics,
+	}
+	var out strings.Builder
+	d := reporter.NewDashboard(&out, source, source, false)
+	d.SetLang(i18n.En)
+	d.Update(time.Now())
+	suite.Contains(out.String(), "Paused 50%")
+	suite.Contains(out.String(), "Errors Timeout 10")
+}
+
+func TestLangTestSuite(t *testing.T) {
+	suite.Run(t, new(LangTestSuite))
+}
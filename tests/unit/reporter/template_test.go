@@ -0,0 +1,152 @@
+package reporter_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/reporter"
+)
+
+// TemplateTestSuite 自定义报告模板测试套件
+type TemplateTestSuite struct {
+	suite.Suite
+	metrics    *core.StabilityMetrics
+	evaluation *core.EvaluationResult
+}
+
+// SetupTest 每个测试前执行
+func (suite *TemplateTestSuite) SetupTest() {
+	suite.metrics = &core.StabilityMetrics{
+		TotalOperations:  1000,
+		FailedOperations: 10,
+		Availability:     0.99,
+		P99Latency:       1234567 * time.Nanosecond,
+		Duration:         90 * time.Second,
+		StartTime:        time.Date(2025, 10, 30, 14, 0, 0, 0, time.UTC),
+		ErrorsByType: map[core.ErrorType]int64{
+			core.ErrorTypeNetwork: 3,
+			core.ErrorTypeTimeout: 7,
+		},
+		Operations: map[string]*core.OperationMetrics{
+			"get": {Name: "get", Operations: 800},
+			"set": {Name: "set", Operations: 200},
+		},
+		Events: []core.Event{
+			{Offset: 10 * time.Second, Type: core.EventFaultStart, Phase: "outage", Fault: "drop=100%"},
+		},
+	}
+	suite.evaluation = &core.EvaluationResult{Score: 88.25, Grade: core.GradeGood, Status: core.StatusPass}
+	suite.evaluation.Scores.Availability = 27
+}
+
+// render 用Markdown报告生成器按模板输出
+func (suite *TemplateTestSuite) render(template string, cfg *config.Config) (string, error) {
+	r := reporter.NewMarkdownReporter()
+	r.SetTemplate(template)
+	r.SetConfig(cfg)
+	var buf bytes.Buffer
+	err := r.GenerateReport(suite.metrics, suite.evaluation, &buf)
+	return buf.String(), err
+}
+
+// TestDataModel 测试模板数据模型
+func (suite *TemplateTestSuite) TestDataModel() {
+	out, err := suite.render(
+		`{{printf "%.1f" .Evaluation.Score}} {{.Evaluation.Grade}} {{pct .Metrics.Availability}}`+
+			`|{{range .Errors}}{{.Label}}={{.Count}}/{{percent 0 .Percent}};{{end}}`+
+			`|{{range .Operations}}{{.Name}};{{end}}`+
+			`|{{range .Events}}{{offset .Offset}} {{eventLabel .Type}} {{eventDetail .}}{{end}}`+
+			`|{{range .Scores}}{{.Name}}={{.Score}}/{{.Max}};{{end}}`, nil)
+	suite.Require().NoError(err)
+	suite.Equal("88.2 GOOD 99.00%"+
+		"|超时=7/70%;网络错误=3/30%;"+
+		"|get;set;"+
+		"|+10s 故障开始 outage [drop=100%]"+
+		"|可用性=27/30;性能=0/25;可靠性=0/25;恢复力=0/20;", out)
+}
+
+// TestHelpers 测试模板函数
+func (suite *TemplateTestSuite) TestHelpers() {
+	out, err := suite.render(
+		`{{duration .Metrics.P99Latency}} {{ms .Metrics.P99Latency}} {{us .Metrics.P99Latency}} `+
+			`{{seconds .Metrics.Duration}} {{duration .Metrics.Duration}} `+
+			`{{date "2006-01-02" .Metrics.StartTime}} {{upper "ok"}} {{yesNo true}}`, nil)
+	suite.Require().NoError(err)
+	suite.Equal("1.23ms 1ms 1.235ms 90 1m30s 2025-10-30 OK 是", out)
+}
+
+// TestConfig 测试模板中的测试配置，密码不输出
+func (suite *TemplateTestSuite) TestConfig() {
+	cfg := &config.Config{Name: "nightly", Labels: map[string]string{"env": "ci"}}
+	cfg.Connection.Password = "secret"
+
+	out, err := suite.render(`{{.Config.Name}} {{.Config.Labels.env}} [{{.Config.Connection.Password}}]`, cfg)
+	suite.Require().NoError(err)
+	suite.Equal("nightly ci []", out)
+	suite.Equal("secret", cfg.Connection.Password)
+
+	out, err = suite.render(`{{with .Config}}{{.Name}}{{else}}none{{end}}`, nil)
+	suite.Require().NoError(err)
+	suite.Equal("none", out)
+}
+
+// TestConsoleTemplate 测试控制台报告的模板
+func (suite *TemplateTestSuite) TestConsoleTemplate() {
+	r := reporter.NewConsoleReporter()
+	var buf bytes.Buffer
+	suite.Require().NoError(r.GenerateReport(suite.metrics, suite.evaluation, &buf))
+	suite.Contains(buf.String(), "中间件稳定性测试报告")
+
+	r.SetTemplate("score={{.Evaluation.Score}}\n")
+	buf.Reset()
+	suite.Require().NoError(r.GenerateReport(suite.metrics, suite.evaluation, &buf))
+	suite.Equal("score=88.25\n", buf.String())
+
+	var _ reporter.TemplateReporter = r
+	var _ reporter.TemplateReporter = reporter.NewMarkdownReporter()
+}
+
+// TestInvalidTemplate 测试模板错误
+func (suite *TemplateTestSuite) TestInvalidTemplate() {
+	_, err := reporter.ParseTemplate("{{.Metrics")
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+
+	_, err = reporter.ParseTemplate("{{nope .Metrics}}")
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+
+	_, err = suite.render("{{.Metrics.Nope}}", nil)
+	suite.ErrorContains(err, "failed to render report template")
+
+	_, err = reporter.LoadTemplate(filepath.Join(suite.T().TempDir(), "missing.tmpl"))
+	suite.Error(err)
+
+	path := filepath.Join(suite.T().TempDir(), "bad.tmpl")
+	suite.Require().NoError(os.WriteFile(path, []byte("{{end}}"), 0o644))
+	_, err = reporter.LoadTemplate(path)
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+	suite.Contains(err.Error(), path)
+}
+
+// TestExampleTemplate 测试仓库自带的示例模板
+func (suite *TemplateTestSuite) TestExampleTemplate() {
+	text, err := reporter.LoadTemplate("../../../configs/templates/summary.md.tmpl")
+	suite.Require().NoError(err)
+
+	out, err := suite.render(text, &config.Config{Name: "nightly", Middleware: "redis"})
+	suite.Require().NoError(err)
+	suite.Contains(out, "# nightly\n")
+	suite.Contains(out, "| 可用性 | 99.00% |")
+	suite.Contains(out, "- 超时: 7 (70.0%)")
+	suite.Contains(out, "`+10s` 故障开始 outage [drop=100%]")
+}
+
+func TestTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplateTestSuite))
+}
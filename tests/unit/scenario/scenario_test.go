@@ -0,0 +1,316 @@
+package scenario_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/chaos"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/scenario"
+)
+
+// fakeInjector 记录故障切换的替身注入器，base 为场景开始前已有的故障
+type fakeInjector struct {
+	mu     sync.Mutex
+	base   chaos.Faults
+	faults []chaos.Faults
+	resets int
+}
+
+func (f *fakeInjector) Faults() chaos.Faults {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.faults) == 0 {
+		return f.base
+	}
+	return f.faults[len(f.faults)-1]
+}
+
+func (f *fakeInjector) SetFaults(faults chaos.Faults) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, faults)
+}
+
+func (f *fakeInjector) ResetConnections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resets++
+	return 3
+}
+
+// pausableClock 可暂停的时钟，模拟编排器扣除暂停后的运行时长
+type pausableClock struct {
+	mu       sync.Mutex
+	start    time.Time
+	pausedAt time.Time
+	paused   time.Duration
+}
+
+func newPausableClock() *pausableClock {
+	return &pausableClock{start: time.Now()}
+}
+
+func (c *pausableClock) Elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if !c.pausedAt.IsZero() {
+		now = c.pausedAt
+	}
+	return now.Sub(c.start) - c.paused
+}
+
+func (c *pausableClock) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pausedAt = time.Now()
+}
+
+func (c *pausableClock) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused += time.Since(c.pausedAt)
+	c.pausedAt = time.Time{}
+}
+
+// eventRecorder 收集事件
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []core.Event
+}
+
+func (r *eventRecorder) record(e core.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) types() []core.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]core.EventType, len(r.events))
+	for i, e := range r.events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+// ScenarioTestSuite 混沌场景测试套件
+type ScenarioTestSuite struct {
+	suite.Suite
+}
+
+// TestParse 测试解析场景
+func (suite *ScenarioTestSuite) TestParse() {
+	s, err := scenario.Parse([]byte(`
+name: latency-then-reset
+phases:
+  - name: baseline
+    duration: 60s
+  - name: latency
+    duration: 30s
+    fault: latency=200ms,jitter=20ms
+  - name: recovery
+    duration: 60s
+    reset_connections: true
+`))
+	suite.Require().NoError(err)
+
+	suite.Equal("latency-then-reset", s.Name)
+	suite.Require().Len(s.Phases, 3)
+	suite.Equal(150*time.Second, s.Duration())
+	suite.True(s.Phases[0].Faults().IsZero())
+	suite.Equal(chaos.Faults{Latency: 200 * time.Millisecond, Jitter: 20 * time.Millisecond}, s.Phases[1].Faults())
+	suite.True(s.Phases[2].ResetConnections)
+}
+
+// TestParse_Invalid 测试无效场景
+func (suite *ScenarioTestSuite) TestParse_Invalid() {
+	cases := map[string]string{
+		"no phases":      "name: empty\n",
+		"zero duration":  "phases:\n  - name: a\n",
+		"bad fault":      "phases:\n  - duration: 1s\n    fault: flood\n",
+		"unknown field":  "phases:\n  - duration: 1s\n    faults: latency=1s\n",
+		"bad duration":   "phases:\n  - duration: soon\n",
+		"negative fault": "phases:\n  - duration: 1s\n    fault: latency=-1s\n",
+	}
+
+	for name, data := range cases {
+		suite.Run(name, func() {
+			_, err := scenario.Parse([]byte(data))
+			suite.Error(err)
+			suite.True(errors.Is(err, core.ErrInvalidConfig), "got %v", err)
+		})
+	}
+}
+
+// TestLoadExamples 测试仓库自带的示例场景
+func (suite *ScenarioTestSuite) TestLoadExamples() {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "..", "configs", "scenarios", "*.yaml"))
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(paths)
+
+	for _, path := range paths {
+		_, err := scenario.Load(path)
+		suite.NoError(err, path)
+	}
+}
+
+// TestRunner_Timeline 测试按时间线注入故障并记录事件
+func (suite *ScenarioTestSuite) TestRunner_Timeline() {
+	s, err := scenario.Parse([]byte(`
+phases:
+  - name: baseline
+    duration: 30ms
+  - name: latency
+    duration: 50ms
+    fault: latency=100ms
+  - name: recovery
+    duration: 30ms
+    reset_connections: true
+`))
+	suite.Require().NoError(err)
+
+	injector := &fakeInjector{}
+	recorder := &eventRecorder{}
+
+	start := time.Now()
+	suite.NoError(scenario.NewRunner(s, injector).Run(context.Background(), recorder.record))
+	suite.GreaterOrEqual(time.Since(start), 110*time.Millisecond)
+
+	suite.Equal([]core.EventType{
+		core.EventPhaseStart, core.EventPhaseEnd,
+		core.EventPhaseStart, core.EventFaultStart, core.EventFaultStop, core.EventPhaseEnd,
+		core.EventPhaseStart, core.EventFaultInject, core.EventPhaseEnd,
+	}, recorder.types())
+
+	suite.Equal([]chaos.Faults{{Latency: 100 * time.Millisecond}, {}}, injector.faults)
+	suite.Equal(1, injector.resets)
+
+	events := recorder.events
+	suite.Equal("latency", events[3].Phase)
+	suite.Equal("latency=100ms", events[3].Fault)
+	suite.GreaterOrEqual(events[4].Time.Sub(events[3].Time), 50*time.Millisecond)
+	suite.Equal("reset_connections", events[7].Fault)
+	suite.Contains(events[7].Message, "3 connection")
+	for _, e := range events {
+		suite.False(e.Time.IsZero())
+	}
+}
+
+// TestRunner_Cancel 测试取消时清除故障
+func (suite *ScenarioTestSuite) TestRunner_Cancel() {
+	s, err := scenario.Parse([]byte("phases:\n  - name: outage\n    duration: 1h\n    fault: blackhole\n"))
+	suite.Require().NoError(err)
+
+	injector := &fakeInjector{}
+	recorder := &eventRecorder{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	suite.NoError(scenario.NewRunner(s, injector).Run(ctx, recorder.record))
+	suite.Equal([]chaos.Faults{{Blackhole: true}, {}}, injector.faults, "Faults should be cleared on cancel")
+	suite.Equal([]core.EventType{
+		core.EventPhaseStart, core.EventFaultStart, core.EventFaultStop, core.EventPhaseEnd,
+	}, recorder.types())
+	suite.Equal("interrupted", recorder.events[3].Message)
+}
+
+// TestRunner_PauseResume 测试时钟暂停期间阶段不计时，故障持续到恢复后阶段时长走完
+func (suite *ScenarioTestSuite) TestRunner_PauseResume() {
+	s, err := scenario.Parse([]byte("phases:\n  - name: outage\n    duration: 100ms\n    fault: blackhole\n"))
+	suite.Require().NoError(err)
+
+	injector := &fakeInjector{}
+	recorder := &eventRecorder{}
+	clock := newPausableClock()
+	runner := scenario.NewRunner(s, injector)
+	runner.SetClock(clock.Elapsed)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		suite.NoError(runner.Run(context.Background(), recorder.record))
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	clock.Pause()
+	time.Sleep(200 * time.Millisecond)
+
+	select {
+	case <-done:
+		suite.FailNow("phase ended while the clock was paused")
+	default:
+	}
+	suite.Equal([]chaos.Faults{{Blackhole: true}}, injector.faults, "Fault should stay injected while paused")
+
+	clock.Resume()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.FailNow("phase did not end after resume")
+	}
+
+	suite.Equal([]chaos.Faults{{Blackhole: true}, {}}, injector.faults)
+	events := recorder.events
+	suite.Require().Len(events, 4)
+	suite.Empty(events[3].Message)
+	suite.GreaterOrEqual(events[2].Time.Sub(events[1].Time), 300*time.Millisecond)
+}
+
+// TestRunner_BaseFaults 测试阶段故障叠加在已有故障（--fault）之上，阶段结束后恢复
+func (suite *ScenarioTestSuite) TestRunner_BaseFaults() {
+	s, err := scenario.Parse([]byte(`
+phases:
+  - name: outage
+    duration: 20ms
+    fault: blackhole
+  - name: slow
+    duration: 20ms
+    fault: latency=200ms
+`))
+	suite.Require().NoError(err)
+
+	base := chaos.Faults{Latency: 50 * time.Millisecond, Bandwidth: 1024}
+	injector := &fakeInjector{base: base}
+	suite.NoError(scenario.NewRunner(s, injector).Run(context.Background(), (&eventRecorder{}).record))
+	suite.Equal([]chaos.Faults{
+		{Latency: 50 * time.Millisecond, Bandwidth: 1024, Blackhole: true},
+		base,
+		{Latency: 200 * time.Millisecond, Bandwidth: 1024},
+		base,
+	}, injector.faults)
+
+	// 真实代理
+	proxy := chaos.NewProxy("127.0.0.1:1")
+	defer proxy.Close()
+	proxy.SetFaults(base)
+	suite.NoError(scenario.NewRunner(s, proxy).Run(context.Background(), (&eventRecorder{}).record))
+	suite.Equal(base, proxy.Faults())
+}
+
+// TestRunner_Proxy 测试驱动真实的故障注入代理
+func (suite *ScenarioTestSuite) TestRunner_Proxy() {
+	s, err := scenario.Parse([]byte("phases:\n  - duration: 20ms\n    fault: latency=10ms\n"))
+	suite.Require().NoError(err)
+
+	proxy := chaos.NewProxy("127.0.0.1:1")
+	defer proxy.Close()
+
+	recorder := &eventRecorder{}
+	suite.NoError(scenario.NewRunner(s, proxy).Run(context.Background(), recorder.record))
+	suite.True(proxy.Faults().IsZero())
+	suite.Len(recorder.events, 4)
+}
+
+// TestScenarioTestSuite 运行测试套件
+func TestScenarioTestSuite(t *testing.T) {
+	suite.Run(t, new(ScenarioTestSuite))
+}
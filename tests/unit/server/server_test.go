@@ -0,0 +1,409 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/config"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/history"
+	"middleware-chaos-testing/internal/server"
+)
+
+// fakeClient 本地替身客户端，可配置操作延迟和连接错误
+type fakeClient struct {
+	latency    time.Duration
+	connectErr error
+}
+
+func (f *fakeClient) Connect(ctx context.Context) error {
+	return f.connectErr
+}
+
+func (f *fakeClient) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeClient) Execute(ctx context.Context, op core.Operation) (*core.Result, error) {
+	start := time.Now()
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	return core.NewResult(true, time.Since(start), nil), nil
+}
+
+func (f *fakeClient) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeClient) GetMetrics() *core.ClientMetrics {
+	return &core.ClientMetrics{}
+}
+
+// status 运行状态中用到的字段
+type status struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Middleware string            `json:"middleware"`
+	Labels     map[string]string `json:"labels"`
+	State      string            `json:"state"`
+	Progress   float64           `json:"progress"`
+	Operations int64             `json:"operations"`
+	FinishedAt *time.Time        `json:"finished_at"`
+	Error      string            `json:"error"`
+	Result     *struct {
+		Score  float64 `json:"score"`
+		Grade  string  `json:"grade"`
+		Status string  `json:"status"`
+	} `json:"result"`
+}
+
+// ServerTestSuite 控制API测试套件
+type ServerTestSuite struct {
+	suite.Suite
+	client  *fakeClient
+	clients atomic.Int32 // 创建的客户端数
+	server  *server.Server
+	http    *httptest.Server
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	suite.client = &fakeClient{latency: time.Millisecond}
+	suite.clients.Store(0)
+	suite.server = server.NewServer(func(cfg *config.Config) (core.MiddlewareClient, error) {
+		suite.clients.Add(1)
+		return suite.client, nil
+	})
+	suite.http = httptest.NewServer(suite.server)
+}
+
+func (suite *ServerTestSuite) TearDownTest() {
+	suite.http.Close()
+	suite.server.Close()
+}
+
+// do 发送请求，返回状态码和响应内容；POST请求的内容类型为YAML
+func (suite *ServerTestSuite) do(method, path, body string) (int, http.Header, []byte) {
+	return suite.doWithType(method, path, "application/yaml", body)
+}
+
+// doWithType 按指定的内容类型发送请求
+func (suite *ServerTestSuite) doWithType(method, path, contentType, body string) (int, http.Header, []byte) {
+	req, err := http.NewRequest(method, suite.http.URL+server.APIPrefix+path, strings.NewReader(body))
+	suite.Require().NoError(err)
+	if method == http.MethodPost && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	suite.Require().NoError(err)
+	return resp.StatusCode, resp.Header, data
+}
+
+// submit 提交测试定义，返回运行状态
+func (suite *ServerTestSuite) submit(definition string) *status {
+	code, header, body := suite.do(http.MethodPost, "/runs", definition)
+	suite.Require().Equal(http.StatusAccepted, code, string(body))
+	s := &status{}
+	suite.Require().NoError(json.Unmarshal(body, s))
+	suite.Equal(server.APIPrefix+"/runs/"+s.ID, header.Get("Location"))
+	return s
+}
+
+// status 获取运行状态
+func (suite *ServerTestSuite) status(id string) *status {
+	code, _, body := suite.do(http.MethodGet, "/runs/"+id, "")
+	suite.Require().Equal(http.StatusOK, code, string(body))
+	s := &status{}
+	suite.Require().NoError(json.Unmarshal(body, s))
+	return s
+}
+
+// waitFor 等待运行进入指定状态；结束状态还要等评估完成（finished_at 不为空）
+func (suite *ServerTestSuite) waitFor(id string, states ...string) *status {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s := suite.status(id)
+		for _, state := range states {
+			if s.State == state && (s.FinishedAt != nil) == (state != "running" && state != "paused") {
+				return s
+			}
+		}
+		if time.Now().After(deadline) {
+			suite.FailNow("Timed out waiting for run state", "want %v, got %s", states, s.State)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSubmitAndResult 测试提交测试并获取结果和各格式报告
+func (suite *ServerTestSuite) TestSubmitAndResult() {
+	s := suite.submit(`
+name: api-test
+middleware: redis
+labels:
+  team: platform
+test:
+  operations: 50
+  concurrency: 2
+`)
+	suite.Equal("api-test", s.Name)
+	suite.Equal("redis", s.Middleware)
+	suite.Equal(map[string]string{"team": "platform"}, s.Labels)
+	suite.Contains([]string{"pending", "running", "completed"}, s.State)
+
+	s = suite.waitFor(s.ID, "completed")
+	suite.Equal(1.0, s.Progress)
+	suite.Equal(int64(50), s.Operations)
+	suite.Require().NotNil(s.Result)
+	suite.Equal("PASS", s.Result.Status)
+	suite.Empty(s.Error)
+
+	code, _, body := suite.do(http.MethodGet, "/runs/"+s.ID+"/metrics", "")
+	suite.Require().Equal(http.StatusOK, code)
+	metrics := &core.StabilityMetrics{}
+	suite.Require().NoError(json.Unmarshal(body, metrics))
+	suite.Equal(int64(50), metrics.TotalOperations)
+	suite.Equal(1.0, metrics.Availability)
+
+	code, _, body = suite.do(http.MethodGet, "/runs/"+s.ID+"/evaluation", "")
+	suite.Require().Equal(http.StatusOK, code)
+	evaluation := &core.EvaluationResult{}
+	suite.Require().NoError(json.Unmarshal(body, evaluation))
+	suite.Equal(s.Result.Score, evaluation.Score)
+	suite.Equal(core.StatusPass, evaluation.Status)
+
+	for format, want := range map[string]struct{ contentType, content string }{
+		"":         {"application/json", `"total_operations": 50`},
+		"json":     {"application/json", `"total_operations": 50`},
+		"console":  {"text/plain", "中间件稳定性测试报告"},
+		"markdown": {"text/markdown", "# "},
+		"html":     {"text/html", "<svg"},
+		"junit":    {"application/xml", "<testsuite"},
+	} {
+		code, header, body := suite.do(http.MethodGet, "/runs/"+s.ID+"/report?format="+format, "")
+		suite.Equal(http.StatusOK, code, format)
+		suite.True(strings.HasPrefix(header.Get("Content-Type"), want.contentType), format)
+		suite.Contains(string(body), want.content, format)
+	}
+
+	code, _, body = suite.do(http.MethodGet, "/runs/"+s.ID+"/report?format=pdf", "")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(string(body), "unsupported output format: pdf")
+
+	code, _, body = suite.do(http.MethodGet, "/runs", "")
+	suite.Require().Equal(http.StatusOK, code)
+	var list struct {
+		Runs []status `json:"runs"`
+	}
+	suite.Require().NoError(json.Unmarshal(body, &list))
+	suite.Require().Len(list.Runs, 1)
+	suite.Equal(s.ID, list.Runs[0].ID)
+	suite.Equal("completed", list.Runs[0].State)
+}
+
+// TestJUnitReportInEnglish 测试JUnit报告中的问题描述为英文，其他报告仍使用运行配置的语言
+func (suite *ServerTestSuite) TestJUnitReportInEnglish() {
+	suite.client.latency = 3 * time.Millisecond
+	s := suite.submit(`
+middleware: redis
+test:
+  operations: 20
+  concurrency: 2
+thresholds:
+  p95_latency:
+    excellent: 100us
+    good: 200us
+    fair: 500us
+    pass: 1ms
+`)
+	s = suite.waitFor(s.ID, "completed")
+
+	code, _, body := suite.do(http.MethodGet, "/runs/"+s.ID+"/evaluation", "")
+	suite.Require().Equal(http.StatusOK, code)
+	evaluation := &core.EvaluationResult{}
+	suite.Require().NoError(json.Unmarshal(body, evaluation))
+	suite.Require().NotEmpty(evaluation.Issues)
+
+	code, _, body = suite.do(http.MethodGet, "/runs/"+s.ID+"/report?format=junit", "")
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Contains(string(body), `name="p95_latency"`)
+	suite.NotContains(string(body), evaluation.Issues[0].Message, "JUnit issues should not use the run language")
+	for _, c := range string(body) {
+		suite.Require().False(unicode.Is(unicode.Han, c), "unexpected CJK text in JUnit report")
+	}
+}
+
+// TestInvalidDefinition 测试无效的测试定义
+func (suite *ServerTestSuite) TestInvalidDefinition() {
+	code, _, body := suite.do(http.MethodPost, "/runs", "middleware: mysql\ntest:\n  concurrency: -1\n")
+	suite.Equal(http.StatusBadRequest, code)
+	var resp struct {
+		Error  string `json:"error"`
+		Fields []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"fields"`
+	}
+	suite.Require().NoError(json.Unmarshal(body, &resp))
+	suite.Contains(resp.Error, "config error")
+	fields := make([]string, len(resp.Fields))
+	for i, f := range resp.Fields {
+		fields[i] = f.Field
+	}
+	suite.Contains(fields, "middleware")
+	suite.Contains(fields, "test.concurrency")
+
+	code, _, body = suite.do(http.MethodPost, "/runs", "middleware: redis\nconection: {}\n")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(string(body), "conection")
+
+	code, _, _ = suite.do(http.MethodPost, "/runs", "  ")
+	suite.Equal(http.StatusBadRequest, code)
+
+	// JSON定义
+	code, _, body = suite.do(http.MethodPost, "/runs", `{"middleware": "kafka", "test": {"operations": 5}}`)
+	suite.Equal(http.StatusAccepted, code, string(body))
+}
+
+// TestContentType 测试只接受YAML和JSON内容类型，浏览器可跨域发送的简单请求被拒绝
+func (suite *ServerTestSuite) TestContentType() {
+	definition := "middleware: redis\ntest:\n  operations: 5\n"
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded", "multipart/form-data"} {
+		code, _, _ := suite.doWithType(http.MethodPost, "/runs", contentType, definition)
+		suite.Equal(http.StatusUnsupportedMediaType, code, contentType)
+	}
+
+	code, _, body := suite.doWithType(http.MethodPost, "/runs", "application/json; charset=utf-8",
+		`{"middleware": "redis", "test": {"operations": 5}}`)
+	suite.Equal(http.StatusAccepted, code, string(body))
+}
+
+// TestNoEnvExpansion 测试提交的定义不展开 ${VAR}，也不使用服务所在主机的凭据环境变量
+func (suite *ServerTestSuite) TestNoEnvExpansion() {
+	suite.T().Setenv("MCT_TEST_SECRET", "top-secret")
+	suite.T().Setenv(config.EnvPassword, "server-password")
+	suite.T().Setenv(config.EnvUsername, "server-user")
+
+	configs := make(chan *config.Config, 1)
+	suite.server.Close()
+	suite.server = server.NewServer(func(cfg *config.Config) (core.MiddlewareClient, error) {
+		configs <- cfg
+		return suite.client, nil
+	})
+	suite.http.Config.Handler = suite.server
+
+	s := suite.submit("name: ${MCT_TEST_SECRET}\nmiddleware: redis\nconnection:\n  password: ${MCT_TEST_SECRET}\ntest:\n  operations: 5\n")
+	suite.Equal("${MCT_TEST_SECRET}", s.Name)
+
+	cfg := <-configs
+	suite.Equal("${MCT_TEST_SECRET}", cfg.Connection.Password)
+	suite.Empty(cfg.Connection.Username)
+	suite.NotContains(suite.status(s.ID).Name, "top-secret")
+}
+
+// TestPauseResumeStop 测试暂停、恢复和停止
+func (suite *ServerTestSuite) TestPauseResumeStop() {
+	s := suite.submit("middleware: redis\ntest:\n  duration: 30s\n  operations: 100000\n")
+	suite.waitFor(s.ID, "running")
+
+	code, _, _ := suite.do(http.MethodGet, "/runs/"+s.ID+"/metrics", "")
+	suite.Equal(http.StatusConflict, code, "No result while running")
+
+	code, _, body := suite.do(http.MethodPost, "/runs/"+s.ID+"/pause", "")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(string(body), `"state": "paused"`)
+
+	code, _, body = suite.do(http.MethodPost, "/runs/"+s.ID+"/pause", "")
+	suite.Equal(http.StatusConflict, code)
+	suite.Contains(string(body), "not running")
+
+	code, _, body = suite.do(http.MethodPost, "/runs/"+s.ID+"/resume", "")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(string(body), `"state": "running"`)
+
+	code, _, body = suite.do(http.MethodPost, "/runs/"+s.ID+"/resume", "")
+	suite.Equal(http.StatusConflict, code)
+	suite.Contains(string(body), "not paused")
+
+	code, _, _ = suite.do(http.MethodPost, "/runs/"+s.ID+"/stop", "")
+	suite.Equal(http.StatusOK, code)
+	s = suite.waitFor(s.ID, "stopped")
+	suite.NotNil(s.Result, "A stopped run is still evaluated")
+	suite.Less(s.Operations, int64(100000))
+
+	code, _, _ = suite.do(http.MethodGet, "/runs/"+s.ID+"/metrics", "")
+	suite.Equal(http.StatusOK, code)
+
+	code, _, _ = suite.do(http.MethodPost, "/runs/"+s.ID+"/stop", "")
+	suite.Equal(http.StatusConflict, code)
+
+	code, _, _ = suite.do(http.MethodPost, "/runs/"+s.ID+"/restart", "")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+// TestMaxActive 测试同时进行的运行数上限
+func (suite *ServerTestSuite) TestMaxActive() {
+	s := suite.submit("middleware: redis\ntest:\n  duration: 30s\n")
+
+	code, _, body := suite.do(http.MethodPost, "/runs", "middleware: redis\ntest:\n  operations: 10\n")
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.Contains(string(body), "too many active runs")
+	suite.Equal(int32(1), suite.clients.Load(), "Rejected submissions should not create a client")
+
+	suite.do(http.MethodPost, "/runs/"+s.ID+"/stop", "")
+	suite.waitFor(s.ID, "stopped")
+	suite.submit("middleware: redis\ntest:\n  operations: 10\n")
+}
+
+// TestFailedRun 测试连接失败的运行
+func (suite *ServerTestSuite) TestFailedRun() {
+	suite.client.connectErr = errors.New("connection refused")
+	s := suite.submit("middleware: redis\ntest:\n  operations: 10\n")
+
+	s = suite.waitFor(s.ID, "failed")
+	suite.Contains(s.Error, "connection refused")
+	suite.Nil(s.Result)
+
+	code, _, body := suite.do(http.MethodGet, "/runs/"+s.ID+"/report", "")
+	suite.Equal(http.StatusConflict, code)
+	suite.Contains(string(body), "run failed")
+
+	code, _, _ = suite.do(http.MethodGet, "/runs/unknown", "")
+	suite.Equal(http.StatusNotFound, code)
+	code, _, _ = suite.do(http.MethodPost, "/runs/unknown/stop", "")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+// TestHistory 测试运行结束后记录运行历史
+func (suite *ServerTestSuite) TestHistory() {
+	store := history.NewStore(filepath.Join(suite.T().TempDir(), "history"))
+	suite.server.SetHistory(store)
+
+	s := suite.submit("middleware: redis\nhistory:\n  dir: /ignored\nlabels:\n  env: ci\ntest:\n  operations: 20\n")
+	suite.waitFor(s.ID, "completed")
+
+	records, err := store.List(history.Filter{Labels: map[string]string{"env": "ci"}})
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 1)
+	suite.Equal(s.ID, records[0].ID)
+	suite.Equal(int64(20), records[0].Metrics.TotalOperations)
+}
+
+// TestServerTestSuite 运行测试套件
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
@@ -0,0 +1,158 @@
+package workload_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/workload"
+)
+
+// ProfileTestSuite 目标速率曲线测试套件
+type ProfileTestSuite struct {
+	suite.Suite
+}
+
+// newProfile 创建速率曲线，失败时终止测试
+func (suite *ProfileTestSuite) newProfile(rate float64, p *core.LoadProfile, duration time.Duration) *workload.RateProfile {
+	rp, err := workload.NewRateProfile(rate, p, duration)
+	suite.Require().NoError(err)
+	return rp
+}
+
+// countScheduled 统计 [0, until) 内计划发送的操作数
+func countScheduled(rp *workload.RateProfile, until time.Duration) int {
+	n := 0
+	for due := time.Duration(0); due < until; due = rp.Next(due) {
+		n++
+	}
+	return n
+}
+
+// TestConstant 测试恒定速率
+func (suite *ProfileTestSuite) TestConstant() {
+	rp := suite.newProfile(100, nil, time.Minute)
+	suite.Equal(100.0, rp.Rate(30*time.Second))
+	suite.Equal(10*time.Millisecond, rp.Next(0))
+	suite.Equal(100.0, rp.Average(0, time.Second))
+	suite.Equal(100, countScheduled(rp, time.Second))
+}
+
+// TestRamp 测试线性爬坡
+func (suite *ProfileTestSuite) TestRamp() {
+	rp := suite.newProfile(1000, &core.LoadProfile{Shape: core.ProfileRamp}, 10*time.Second)
+	suite.Zero(rp.Rate(0))
+	suite.InDelta(500, rp.Rate(5*time.Second), 1e-9)
+	suite.Equal(1000.0, rp.Rate(20*time.Second))
+
+	// 0到1000线性增加10s，共计划约5000次操作
+	suite.InDelta(5000, countScheduled(rp, 10*time.Second), 10)
+	suite.InDelta(500, rp.Average(0, 10*time.Second), 1)
+
+	rp = suite.newProfile(1000, &core.LoadProfile{Shape: core.ProfileRamp, StartRate: 200, RampTime: 2 * time.Second},
+		10*time.Second)
+	suite.InDelta(600, rp.Rate(time.Second), 1e-9)
+	suite.Equal(1000.0, rp.Rate(3*time.Second))
+}
+
+// TestStep 测试阶梯增加
+func (suite *ProfileTestSuite) TestStep() {
+	rp := suite.newProfile(250, &core.LoadProfile{
+		Shape: core.ProfileStep, StartRate: 100, StepRate: 50, StepTime: time.Second,
+	}, 0)
+	suite.Equal(100.0, rp.Rate(500*time.Millisecond))
+	suite.Equal(150.0, rp.Rate(time.Second))
+	suite.Equal(200.0, rp.Rate(2500*time.Millisecond))
+	suite.Equal(250.0, rp.Rate(10*time.Second), "Step rate should not exceed the base rate")
+	suite.InDelta(450, countScheduled(rp, 3*time.Second), 2)
+}
+
+// TestSpike 测试突发尖峰
+func (suite *ProfileTestSuite) TestSpike() {
+	rp := suite.newProfile(100, &core.LoadProfile{
+		Shape: core.ProfileSpike, SpikeRate: 1000, SpikeAt: 2 * time.Second, SpikeTime: time.Second,
+	}, 0)
+	suite.Equal(100.0, rp.Rate(time.Second))
+	suite.Equal(1000.0, rp.Rate(2500*time.Millisecond))
+	suite.Equal(100.0, rp.Rate(3*time.Second))
+	suite.Equal(100.0, rp.Rate(12500*time.Millisecond), "A single spike should not repeat")
+	suite.InDelta((3*100+1000)/4.0, rp.Average(0, 4*time.Second), 1)
+
+	rp = suite.newProfile(100, &core.LoadProfile{
+		Shape: core.ProfileSpike, SpikeRate: 1000, SpikeAt: 2 * time.Second, SpikeTime: time.Second,
+		Period: 10 * time.Second,
+	}, 0)
+	suite.Equal(1000.0, rp.Rate(12500*time.Millisecond), "Spikes should repeat every period")
+	suite.Equal(100.0, rp.Rate(500*time.Millisecond))
+}
+
+// TestSine 测试正弦波
+func (suite *ProfileTestSuite) TestSine() {
+	rp := suite.newProfile(500, &core.LoadProfile{
+		Shape: core.ProfileSine, Amplitude: 200, Period: 4 * time.Second,
+	}, 0)
+	suite.InDelta(500, rp.Rate(0), 1e-9)
+	suite.InDelta(700, rp.Rate(time.Second), 1e-9)
+	suite.InDelta(300, rp.Rate(3*time.Second), 1e-9)
+	suite.InDelta(500, rp.Average(0, 4*time.Second), 1)
+	suite.InDelta(2000, countScheduled(rp, 4*time.Second), 2)
+
+	rp = suite.newProfile(100, &core.LoadProfile{
+		Shape: core.ProfileSine, Amplitude: 200, Period: 4 * time.Second,
+	}, 0)
+	suite.Zero(rp.Rate(3*time.Second), "Rate should not be negative")
+	suite.Greater(rp.Next(2*time.Second), 2*time.Second)
+}
+
+// TestInvalidProfile 测试无效曲线
+func (suite *ProfileTestSuite) TestInvalidProfile() {
+	for name, tc := range map[string]struct {
+		rate     float64
+		profile  *core.LoadProfile
+		duration time.Duration
+	}{
+		"no rate":         {0, nil, time.Minute},
+		"unknown shape":   {100, &core.LoadProfile{Shape: "square"}, time.Minute},
+		"ramp no length":  {100, &core.LoadProfile{Shape: core.ProfileRamp}, 0},
+		"step no time":    {100, &core.LoadProfile{Shape: core.ProfileStep, StepRate: 10}, time.Minute},
+		"step start":      {100, &core.LoadProfile{Shape: core.ProfileStep, StartRate: 200, StepRate: 10, StepTime: time.Second}, 0},
+		"spike no length": {100, &core.LoadProfile{Shape: core.ProfileSpike, SpikeRate: 1000}, time.Minute},
+		"spike period": {100, &core.LoadProfile{
+			Shape: core.ProfileSpike, SpikeRate: 1000, SpikeTime: time.Second, Period: time.Second,
+		}, time.Minute},
+		"sine no period": {100, &core.LoadProfile{Shape: core.ProfileSine, Amplitude: 10}, time.Minute},
+	} {
+		_, err := workload.NewRateProfile(tc.rate, tc.profile, tc.duration)
+		suite.True(errors.Is(err, core.ErrInvalidConfig), name)
+	}
+}
+
+// TestParseProfileSpec 测试解析命令行曲线描述
+func (suite *ProfileTestSuite) TestParseProfileSpec() {
+	p, err := workload.ParseProfileSpec("shape=spike,spike_rate=5000,spike_at=30s,spike_time=10s,period=1m")
+	suite.Require().NoError(err)
+	suite.Equal(&core.LoadProfile{
+		Shape:     core.ProfileSpike,
+		SpikeRate: 5000,
+		SpikeAt:   30 * time.Second,
+		SpikeTime: 10 * time.Second,
+		Period:    time.Minute,
+	}, p)
+
+	p, err = workload.ParseProfileSpec("ramp,start_rate=10")
+	suite.Require().NoError(err)
+	suite.Equal(core.ProfileRamp, p.Shape)
+	suite.Equal(10.0, p.StartRate)
+
+	for _, spec := range []string{"", "start_rate=10", "ramp,bogus=1", "step,step_time=x", "ramp,sine"} {
+		_, err := workload.ParseProfileSpec(spec)
+		suite.True(errors.Is(err, core.ErrInvalidConfig), spec)
+	}
+}
+
+// TestProfileTestSuite 运行测试套件
+func TestProfileTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileTestSuite))
+}
@@ -0,0 +1,210 @@
+package workload_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"middleware-chaos-testing/internal/core"
+	"middleware-chaos-testing/internal/middleware"
+	"middleware-chaos-testing/internal/workload"
+)
+
+// WorkloadTestSuite 工作负载生成器测试套件
+type WorkloadTestSuite struct {
+	suite.Suite
+}
+
+// TestDefaultWorkload_Redis 测试Redis默认工作负载
+func (suite *WorkloadTestSuite) TestDefaultWorkload_Redis() {
+	gen, err := workload.NewGenerator("redis", nil, 1)
+	suite.Require().NoError(err)
+
+	counts := map[core.OperationType]int{}
+	for i := int64(0); i < 1000; i++ {
+		op := gen.Next(i)
+		suite.Require().NotNil(op)
+		counts[op.Type()]++
+		suite.True(strings.HasPrefix(op.Key(), "test:key:"))
+	}
+	suite.Greater(counts[core.OpTypeWrite], 400)
+	suite.Greater(counts[core.OpTypeRead], 400)
+}
+
+// TestDefaultWorkload_Kafka 测试Kafka默认工作负载
+func (suite *WorkloadTestSuite) TestDefaultWorkload_Kafka() {
+	gen, err := workload.NewGenerator("kafka", nil, 1)
+	suite.Require().NoError(err)
+
+	for i := int64(0); i < 100; i++ {
+		switch op := gen.Next(i).(type) {
+		case *middleware.KafkaProduceOperation:
+			suite.Len(op.Value(), workload.DefaultValueSize)
+		case *middleware.KafkaConsumeOperation:
+			suite.Equal(middleware.DefaultConsumeMaxWait, op.MaxWait)
+		default:
+			suite.Failf("unexpected operation", "%T", op)
+		}
+	}
+}
+
+// TestWeights 测试按权重选择操作
+func (suite *WorkloadTestSuite) TestWeights() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "get", Weight: 90, KeyPattern: "k"},
+		{Operation: "set", Weight: 10, KeyPattern: "k", ValueSize: 8},
+		{Operation: "delete", Weight: 0, KeyPattern: "k"},
+	}, 42)
+	suite.Require().NoError(err)
+
+	counts := map[core.OperationType]int{}
+	const n = 10000
+	for i := int64(0); i < n; i++ {
+		counts[gen.Next(i).Type()]++
+	}
+
+	suite.InDelta(0.9, float64(counts[core.OpTypeRead])/n, 0.03)
+	suite.InDelta(0.1, float64(counts[core.OpTypeWrite])/n, 0.03)
+	suite.Zero(counts[core.OpTypeDelete], "Zero-weight entries should never be picked")
+}
+
+// TestValueSize 测试值大小
+func (suite *WorkloadTestSuite) TestValueSize() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "set", Weight: 1, KeyPattern: "k", ValueSize: 1024},
+	}, 1)
+	suite.Require().NoError(err)
+
+	op := gen.Next(7)
+	suite.Len(op.Value(), 1024)
+	suite.True(strings.HasPrefix(string(op.Value()), "7-"))
+}
+
+// TestKeyPattern_Sequential 测试顺序键
+func (suite *WorkloadTestSuite) TestKeyPattern_Sequential() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "get", Weight: 1, KeyPattern: "user:{seq}:name"},
+	}, 1)
+	suite.Require().NoError(err)
+
+	for i := 0; i < 5; i++ {
+		suite.Equal("user:"+strconv.Itoa(i)+":name", gen.Next(int64(i)).Key())
+	}
+}
+
+// TestKeyPattern_Default 测试未配置键模式时使用默认键模式
+func (suite *WorkloadTestSuite) TestKeyPattern_Default() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "set", Weight: 1},
+	}, 1)
+	suite.Require().NoError(err)
+	suite.Equal("test:key:0", gen.Next(0).Key())
+	suite.Equal("test:key:1", gen.Next(1).Key())
+
+	gen, err = workload.NewGenerator("kafka", []core.WorkloadConfig{
+		{Operation: "produce", Weight: 1},
+	}, 1)
+	suite.Require().NoError(err)
+	suite.Equal("test-key-0", gen.Next(0).Key())
+}
+
+// TestKeyPattern_RandomAndUniform 测试区间随机和均匀分布
+func (suite *WorkloadTestSuite) TestKeyPattern_RandomAndUniform() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "get", Weight: 1, KeyPattern: "a{random:100-105}b{uniform:3}"},
+	}, 1)
+	suite.Require().NoError(err)
+
+	for i := int64(0); i < 500; i++ {
+		key := gen.Next(i).Key()
+		var r, u int
+		err := parseRangeKey(key, &r, &u)
+		suite.Require().NoError(err, key)
+		suite.GreaterOrEqual(r, 100)
+		suite.LessOrEqual(r, 105)
+		suite.GreaterOrEqual(u, 0)
+		suite.Less(u, 3)
+	}
+}
+
+// TestKeyPattern_Zipfian 测试zipfian分布偏斜
+func (suite *WorkloadTestSuite) TestKeyPattern_Zipfian() {
+	gen, err := workload.NewGenerator("redis", []core.WorkloadConfig{
+		{Operation: "get", Weight: 1, KeyPattern: "{zipf:1000}"},
+	}, 1)
+	suite.Require().NoError(err)
+
+	counts := map[string]int{}
+	const n = 10000
+	for i := int64(0); i < n; i++ {
+		counts[gen.Next(i).Key()]++
+	}
+
+	// 最热的键应显著高于均匀分布的期望（n/1000 = 10）
+	suite.Greater(counts["0"], n/10)
+	suite.Greater(counts["0"], counts["10"])
+}
+
+// TestInvalidConfig 测试无效配置
+func (suite *WorkloadTestSuite) TestInvalidConfig() {
+	cases := map[string][]core.WorkloadConfig{
+		"unknown operation":  {{Operation: "lpush", Weight: 1}},
+		"zero total weight":  {{Operation: "get", Weight: 0}},
+		"negative weight":    {{Operation: "get", Weight: -1}},
+		"bad placeholder":    {{Operation: "get", Weight: 1, KeyPattern: "{nope}"}},
+		"unterminated":       {{Operation: "get", Weight: 1, KeyPattern: "key:{seq"}},
+		"inverted range":     {{Operation: "get", Weight: 1, KeyPattern: "{random:9-1}"}},
+		"bad zipf exponent":  {{Operation: "get", Weight: 1, KeyPattern: "{zipf:10:0.5}"}},
+		"empty uniform size": {{Operation: "get", Weight: 1, KeyPattern: "{uniform:0}"}},
+	}
+
+	for name, workloads := range cases {
+		suite.Run(name, func() {
+			_, err := workload.NewGenerator("redis", workloads, 1)
+			suite.Error(err)
+			suite.True(errors.Is(err, core.ErrInvalidConfig), "got %v", err)
+		})
+	}
+}
+
+// TestParseSpec 测试命令行工作负载描述
+func (suite *WorkloadTestSuite) TestParseSpec() {
+	wl, err := workload.ParseSpec("operation=set,weight=40,key_pattern=user:{uniform:1000},value_size=1024")
+	suite.Require().NoError(err)
+	suite.Equal(core.WorkloadConfig{
+		Operation:  "set",
+		Weight:     40,
+		KeyPattern: "user:{uniform:1000}",
+		ValueSize:  1024,
+	}, wl)
+
+	_, err = workload.ParseSpec("weight=1")
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+
+	_, err = workload.ParseSpec("op=get,colour=blue")
+	suite.True(errors.Is(err, core.ErrInvalidConfig))
+}
+
+// TestWorkloadTestSuite 运行测试套件
+func TestWorkloadTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkloadTestSuite))
+}
+
+// parseRangeKey 解析 "a<r>b<u>" 形式的键
+func parseRangeKey(key string, r, u *int) error {
+	rest := strings.TrimPrefix(key, "a")
+	parts := strings.SplitN(rest, "b", 2)
+	if len(parts) != 2 {
+		return errors.New("malformed key")
+	}
+	var err error
+	if *r, err = strconv.Atoi(parts[0]); err != nil {
+		return err
+	}
+	if *u, err = strconv.Atoi(parts[1]); err != nil {
+		return err
+	}
+	return nil
+}